@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gmlewis/go-xtp/api"
+	"github.com/gmlewis/go-xtp/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is read when xtp2code is run without -config, -appid
+// or -yaml.
+const defaultConfigFile = "xtp2code.yaml"
+
+// config is a project config file, such as xtp2code.yaml, that lists the
+// schemas to generate code from and the targets of each, e.g.:
+//
+//	schemas:
+//	  - yaml: schema.yaml
+//	    pkg: fruit
+//	    targets:
+//	      - lang: go
+//	        types: go-types
+//	        host: go-host
+//	        plugin: go-plugin
+//	  - appId: app_01j1b1mek5frq9x7ymk52m7bw5
+//	    extensionPoint: fruit
+//	    targets:
+//	      - lang: mbt
+//	        plugin: api-mbt-plugin
+//
+// Relative paths are relative to the directory of the config file.
+type config struct {
+	Schemas []*schemaConfig `yaml:"schemas"`
+}
+
+// schemaConfig is the source of a schema, either a local schema.yaml file or
+// the extension point of an XTP app, and the targets to generate it for.
+type schemaConfig struct {
+	YAML           string `yaml:"yaml,omitempty"`
+	AppID          string `yaml:"appId,omitempty"`
+	ExtensionPoint string `yaml:"extensionPoint,omitempty"`
+	// Pkg is the package name of the generated code. It defaults to the
+	// name of the extension point.
+	Pkg     string    `yaml:"pkg,omitempty"`
+	Targets []*target `yaml:"targets"`
+}
+
+// target is a language to generate the code of a schema in, and the output
+// dirs of its types, Host SDK and Plugin PDK code, at least one of which
+// must be provided.
+type target struct {
+	Lang   string `yaml:"lang"`
+	Types  string `yaml:"types,omitempty"`
+	Host   string `yaml:"host,omitempty"`
+	Plugin string `yaml:"plugin,omitempty"`
+	// Validate and FastJSON are like the -validate and -fastjson flags.
+	Validate bool `yaml:"validate,omitempty"`
+	FastJSON bool `yaml:"fastjson,omitempty"`
+}
+
+// readConfig parses and checks the config file, and makes its relative
+// paths relative to the current directory instead.
+func readConfig(filename string) (*config, error) {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(buf))
+	dec.KnownFields(true)
+	cfg := &config{}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("%v: %v", filename, err)
+	}
+	if len(cfg.Schemas) == 0 {
+		return nil, fmt.Errorf("%v: no schemas", filename)
+	}
+
+	dir := filepath.Dir(filename)
+	relPath := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	for i, sc := range cfg.Schemas {
+		if err := sc.check(); err != nil {
+			return nil, fmt.Errorf("%v: schemas[%v]: %v", filename, i, err)
+		}
+		sc.YAML = relPath(sc.YAML)
+		for _, t := range sc.Targets {
+			t.Lang = normalizeLang(t.Lang)
+			t.Types, t.Host, t.Plugin = relPath(t.Types), relPath(t.Host), relPath(t.Plugin)
+		}
+	}
+
+	return cfg, nil
+}
+
+func (sc *schemaConfig) check() error {
+	switch {
+	case sc.YAML == "" && sc.AppID == "", sc.YAML != "" && sc.AppID != "":
+		return errors.New("must specify either 'yaml' or 'appId' but not both")
+	case sc.YAML != "" && sc.ExtensionPoint != "":
+		return errors.New("'extensionPoint' can only be used with 'appId'")
+	case sc.AppID != "" && sc.ExtensionPoint == "":
+		return errors.New("must specify the 'extensionPoint' of the app")
+	case sc.YAML != "" && sc.Pkg == "":
+		return errors.New("must specify 'pkg' with 'yaml'")
+	case len(sc.Targets) == 0:
+		return errors.New("no targets")
+	}
+
+	for i, t := range sc.Targets {
+		if normalizeLang(t.Lang) == "" {
+			return fmt.Errorf("targets[%v]: 'lang' must be 'go' or 'mbt', not %q", i, t.Lang)
+		}
+		if t.Types == "" && t.Host == "" && t.Plugin == "" {
+			return fmt.Errorf("targets[%v]: must specify at least one of 'types', 'host' or 'plugin'", i)
+		}
+	}
+	return nil
+}
+
+// processConfig generates the code of every schema of the config file for
+// each of its targets, and reports whether -dryrun or -check found any
+// difference.
+func processConfig(filename string) bool {
+	cfg, err := readConfig(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apps := map[string]*api.AppsExtensionPointsResponse{}
+	var differs bool
+	for _, sc := range cfg.Schemas {
+		name, yamlStr, err := sc.load(apps)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		// Every target gets its own copy of the schema to generate code from.
+		for _, t := range sc.Targets {
+			p, err := schema.ParseNamedStr(name, yamlStr)
+			if err != nil {
+				log.Fatalf("schema.Parse: %v", err)
+			}
+			validatePlugin(p)
+			p.PkgName = sc.Pkg
+
+			if p.Version == "v0" {
+				if !*quiet {
+					log.Printf("Skipping v0 plugin")
+				}
+				break
+			}
+
+			d, err := processPlugin("", p, t)
+			if err != nil {
+				log.Fatalf("processPlugin: %v", err)
+			}
+			differs = differs || d
+		}
+	}
+
+	return differs
+}
+
+// load returns the name and contents of the schema, fetching the extension
+// points of each app from the XTP API only once, and sets the default Pkg.
+func (sc *schemaConfig) load(apps map[string]*api.AppsExtensionPointsResponse) (string, string, error) {
+	if sc.YAML != "" {
+		buf, err := os.ReadFile(sc.YAML)
+		return sc.YAML, string(buf), err
+	}
+
+	resp, ok := apps[sc.AppID]
+	if !ok {
+		var err error
+		if resp, err = api.New().GetAppsExtensionPoints(sc.AppID); err != nil {
+			return "", "", err
+		}
+		apps[sc.AppID] = resp
+	}
+
+	want := strings.TrimSuffix(sc.ExtensionPoint, ".yaml")
+	for _, ep := range resp.ExtensionPoints {
+		if name := strings.TrimSuffix(ep.Name, ".yaml"); name == want {
+			if sc.Pkg == "" {
+				sc.Pkg = name
+			}
+			return ep.Name, ep.SchemaYaml, nil
+		}
+	}
+	return "", "", fmt.Errorf("app %v has no extension point %q", sc.AppID, sc.ExtensionPoint)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "xtp2code.yaml")
+	if err := os.WriteFile(filename, []byte(`schemas:
+  - yaml: schema.yaml
+    pkg: fruit
+    targets:
+      - lang: go
+        types: go-types
+        plugin: /abs/go-plugin
+        fastjson: true
+      - lang: MoonBit
+        host: mbt-host
+  - appId: app_123
+    extensionPoint: user
+    targets:
+      - lang: go
+        plugin: api-go-plugin
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readConfig(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &config{Schemas: []*schemaConfig{
+		{
+			YAML: filepath.Join(dir, "schema.yaml"),
+			Pkg:  "fruit",
+			Targets: []*target{
+				{Lang: "go", Types: filepath.Join(dir, "go-types"), Plugin: "/abs/go-plugin", FastJSON: true},
+				{Lang: "mbt", Host: filepath.Join(dir, "mbt-host")},
+			},
+		},
+		{
+			AppID:          "app_123",
+			ExtensionPoint: "user",
+			Targets: []*target{
+				{Lang: "go", Plugin: filepath.Join(dir, "api-go-plugin")},
+			},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("readConfig mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestReadConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			name: "no schemas",
+			yaml: "schemas: []\n",
+			want: "xtp2code.yaml: no schemas",
+		},
+		{
+			name: "unknown field",
+			yaml: "schemas:\n  - yml: schema.yaml\n",
+			want: "xtp2code.yaml: yaml: unmarshal errors:\n  line 2: field yml not found in type main.schemaConfig",
+		},
+		{
+			name: "both sources",
+			yaml: "schemas:\n  - yaml: schema.yaml\n    appId: app_123\n",
+			want: "xtp2code.yaml: schemas[0]: must specify either 'yaml' or 'appId' but not both",
+		},
+		{
+			name: "app without extension point",
+			yaml: "schemas:\n  - appId: app_123\n",
+			want: "xtp2code.yaml: schemas[0]: must specify the 'extensionPoint' of the app",
+		},
+		{
+			name: "yaml without pkg",
+			yaml: "schemas:\n  - yaml: schema.yaml\n",
+			want: "xtp2code.yaml: schemas[0]: must specify 'pkg' with 'yaml'",
+		},
+		{
+			name: "no targets",
+			yaml: "schemas:\n  - yaml: schema.yaml\n    pkg: fruit\n",
+			want: "xtp2code.yaml: schemas[0]: no targets",
+		},
+		{
+			name: "bad lang",
+			yaml: "schemas:\n  - yaml: schema.yaml\n    pkg: fruit\n    targets:\n      - lang: rust\n        types: rs-types\n",
+			want: `xtp2code.yaml: schemas[0]: targets[0]: 'lang' must be 'go' or 'mbt', not "rust"`,
+		},
+		{
+			name: "no output dirs",
+			yaml: "schemas:\n  - yaml: schema.yaml\n    pkg: fruit\n    targets:\n      - lang: go\n",
+			want: "xtp2code.yaml: schemas[0]: targets[0]: must specify at least one of 'types', 'host' or 'plugin'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			filename := filepath.Join(dir, "xtp2code.yaml")
+			if err := os.WriteFile(filename, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := readConfig(filename)
+			if err == nil {
+				t.Fatalf("readConfig = nil error, want %q", tt.want)
+			}
+			if got := strings.ReplaceAll(err.Error(), filename, "xtp2code.yaml"); got != tt.want {
+				t.Errorf("readConfig error = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,13 +14,49 @@
 //	 [-pkg=<packageName>] \
 //	 [-q ] \
 //	 [-appid=<id> | -yaml=<filename>] \
+//	 [-check] \
+//	 [-dryrun] \
+//	 [-fastjson] \
 //	 [-force] \
 //	 [-host=<filename>] \
+//	 [-merge] \
 //	 [-plugin=<filename>] \
-//	 [-types=<filename>]
+//	 [-types=<filename>] \
+//	 [-validate]
+//
+// Instead of -lang, -pkg, -appid or -yaml and the output dirs, the schemas
+// and targets to generate code for can be listed in a project config file,
+// which is read from xtp2code.yaml by default when no source is given:
+//
+//	xtp2code [-config=<filename>] [-check | -dryrun] [-force] [-merge] [-q]
+//
+// With -dryrun, no file is written: every generated file is listed as new,
+// changed, unchanged or skipped, followed by a unified diff of each new,
+// changed or skipped file, and xtp2code exits with status 1 if any generated
+// file differs from the file on disk.
+//
+// With -check, no file is written either: the code is regenerated in memory
+// and every missing or stale file in the output dirs is listed, and xtp2code
+// exits with status 4 if any is found. The user-owned scaffolding such as
+// main.go only has to exist, and to declare the exports of the schema.
+//
+// To check whether a new version of a schema is compatible with plugins
+// bound to an old version, use:
+//
+//	xtp2code -compat=<old.yaml> -yaml=<new.yaml> [-json]
+//
+// which reports every change and exits with status 3 if any is breaking.
+//
+// To convert the component schemas of an OpenAPI 3 document into a schema.yaml
+// file, use:
+//
+//	xtp2code -openapi=<openapi.yaml|openapi.json> [-q] > schema.yaml
+//
+// which prints a warning for everything that XTP cannot express.
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -38,14 +74,23 @@ var (
 	lang    = flag.String("lang", "", "Target language for generated code ('go' or 'mbt').")
 	pkgName = flag.String("pkg", "", "Set name of generated package code when using -yaml option.")
 	// Optional:
-	appID     = flag.String("appid", "", "XTP App ID to generate code from.")
-	force     = flag.Bool("force", false, "Force overwrite of any existing files.")
-	hostDir   = flag.String("host", "", "Output dirname to generate Host SDK code.")
-	pluginDir = flag.String("plugin", "", "Output dirname to generate Plugin PDK code.")
-	quiet     = flag.Bool("q", false, "Do not print warnings.")
-	typesDir  = flag.String("types", "", "Output dirname to generate simple types code.")
-	version   = flag.Bool("v", false, "Print version and quit.")
-	yamlFile  = flag.String("yaml", "", "Input schema.yaml file to generate code from. (Must also provide -pkg with this option.)")
+	appID      = flag.String("appid", "", "XTP App ID to generate code from.")
+	check      = flag.Bool("check", false, "Write nothing; list the missing or stale generated files in the output dirs, and exit with status 4 if any is found.")
+	compat     = flag.String("compat", "", "Old schema.yaml file to compare against the -yaml file for breaking changes instead of generating code.")
+	configFile = flag.String("config", "", "Project config file listing the schemas to generate code from and their targets. (Defaults to "+defaultConfigFile+" if it exists and neither -appid nor -yaml is provided.)")
+	dryRun     = flag.Bool("dryrun", false, "Write nothing; list the new, changed, unchanged and skipped files with a diff of each difference, and exit with status 1 if any file differs.")
+	fastJSON   = flag.Bool("fastjson", false, "Generate Go types that encode and decode JSON without reflection, for faster code and smaller TinyGo binaries.")
+	force      = flag.Bool("force", false, "Force overwrite of any existing files.")
+	hostDir    = flag.String("host", "", "Output dirname to generate Host SDK code.")
+	jsonOut    = flag.Bool("json", false, "Print the -compat report as JSON.")
+	merge      = flag.Bool("merge", false, "Refresh the generated files while keeping the code in main.go or main.mbt, adding stubs for new exports and flagging removed ones.")
+	openAPI    = flag.String("openapi", "", "OpenAPI 3 document whose component schemas are printed as a schema.yaml instead of generating code.")
+	pluginDir  = flag.String("plugin", "", "Output dirname to generate Plugin PDK code.")
+	quiet      = flag.Bool("q", false, "Do not print warnings.")
+	typesDir   = flag.String("types", "", "Output dirname to generate simple types code.")
+	validate   = flag.Bool("validate", false, "Generate Go code that validates every decoded struct against the constraints of its schema.")
+	version    = flag.Bool("v", false, "Print version and quit.")
+	yamlFile   = flag.String("yaml", "", "Input schema.yaml file to generate code from. (Must also provide -pkg with this option.)")
 )
 
 func main() {
@@ -56,20 +101,61 @@ func main() {
 		return
 	}
 
+	if *compat != "" {
+		checkCompat(*compat, *yamlFile)
+		return
+	}
+
+	if *openAPI != "" {
+		importOpenAPI(*openAPI)
+		return
+	}
+
+	if *check && *dryRun {
+		log.Fatal("Must specify either -check or -dryrun but not both.")
+	}
+
+	if *configFile == "" && *appID == "" && *yamlFile == "" {
+		if _, err := os.Stat(defaultConfigFile); err == nil {
+			*configFile = defaultConfigFile
+		}
+	}
+
+	var differs bool
+	if *configFile != "" {
+		if *appID != "" || *yamlFile != "" || *lang != "" || *pkgName != "" || *hostDir != "" || *pluginDir != "" || *typesDir != "" {
+			log.Fatal("Must not specify -appid, -yaml, -lang, -pkg, -host, -plugin or -types with -config=<filename>")
+		}
+		differs = processConfig(*configFile)
+	} else {
+		differs = processFlags()
+	}
+
+	if !*quiet {
+		log.Printf("Done.")
+	}
+	switch {
+	case differs && *check:
+		os.Exit(exitStale)
+	case differs:
+		os.Exit(exitDiffers)
+	}
+}
+
+// processFlags generates the code of the schema given by -yaml or of every
+// extension point of the app given by -appid, as configured by the flags,
+// and reports whether -dryrun or -check found any difference.
+func processFlags() bool {
 	if (*appID == "" && *yamlFile == "") || (*appID != "" && *yamlFile != "") {
-		log.Fatal("Must specify either '-appid=<id>' or '-yaml=<filename>' but not both.")
+		log.Fatal("Must specify either '-appid=<id>', '-yaml=<filename>' or '-config=<filename>'.")
 	}
 
 	if *hostDir == "" && *pluginDir == "" && *typesDir == "" {
 		log.Fatal("Must specify at least one of: -host=<dirname>, -plugin=<dirname>, or -types=<dirname>")
 	}
 
-	switch *lang {
-	case "go", "Go":
-		*lang = "go"
-	case "mbt", "moon", "moonbit", "MoonBit":
-		*lang = "mbt"
-	default:
+	t := &target{Lang: normalizeLang(*lang), Types: *typesDir, Host: *hostDir, Plugin: *pluginDir}
+	if t.Lang == "" {
 		log.Fatal("Must specify either -lang=go or -lang=mbt")
 	}
 
@@ -77,16 +163,14 @@ func main() {
 		log.Fatal("Must specify -pkg=<packageName> when using -yaml option")
 	}
 
+	var differs bool
 	switch {
 	case *yamlFile != "":
-		buf, err := os.ReadFile(*yamlFile)
-		if err != nil {
-			log.Fatal(err)
-		}
-		p, err := schema.ParseStr(string(buf))
+		p, err := schema.ParseFile(*yamlFile)
 		if err != nil {
 			log.Fatalf("schema.Parse: %v", err)
 		}
+		validatePlugin(p)
 		p.PkgName = *pkgName
 
 		if p.Version == "v0" {
@@ -94,9 +178,11 @@ func main() {
 				log.Printf("Skipping v0 plugin")
 			}
 		} else {
-			if err := processPlugin("", p); err != nil {
+			d, err := processPlugin("", p, t)
+			if err != nil {
 				log.Fatalf("processPlugin: %v", err)
 			}
+			differs = differs || d
 		}
 	case *appID != "":
 		c := api.New()
@@ -105,10 +191,11 @@ func main() {
 			log.Fatal(err)
 		}
 		for _, ep := range resp.ExtensionPoints {
-			p, err := schema.ParseStr(ep.SchemaYaml)
+			p, err := schema.ParseNamedStr(ep.Name, ep.SchemaYaml)
 			if err != nil {
 				log.Fatalf("schema.Parse: %v", err)
 			}
+			validatePlugin(p)
 			p.PkgName = strings.TrimSuffix(ep.Name, ".yaml")
 			if *pkgName != "" {
 				log.Printf("WARNING: Overriding PkgName=%q from API name %q", *pkgName, p.PkgName)
@@ -122,53 +209,145 @@ func main() {
 				continue
 			}
 
-			if err := processPlugin(p.PkgName, p); err != nil {
+			d, err := processPlugin(p.PkgName, p, t)
+			if err != nil {
 				log.Fatalf("processPlugin: %v", err)
 			}
+			differs = differs || d
 		}
 	}
 
-	if !*quiet {
-		log.Printf("Done.")
+	return differs
+}
+
+// normalizeLang returns "go" or "mbt" for the name of a target language,
+// or "" if it is not supported.
+func normalizeLang(lang string) string {
+	switch lang {
+	case "go", "Go":
+		return "go"
+	case "mbt", "moon", "moonbit", "MoonBit":
+		return "mbt"
+	}
+	return ""
+}
+
+const (
+	// exitDiffers is the exit status of -dryrun when any file would be written.
+	exitDiffers = 1
+	// exitStale is the exit status of -check when any file is missing or stale.
+	exitStale = 4
+)
+
+// validatePlugin reports every problem found in the plugin schema
+// and exits before any code is generated.
+func validatePlugin(plugin *schema.Plugin) {
+	diags := plugin.Validate()
+	if diags == nil {
+		return
+	}
+
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d)
 	}
+	log.Fatalf("%v: found %v problem(s) in schema", plugin.Filename, len(diags))
 }
 
-func processPlugin(rootDir string, plugin *schema.Plugin) error {
-	opts := &codegen.ClientOpts{Force: *force, Quiet: *quiet}
-	c, err := codegen.New(*lang, plugin, opts)
+// exitIncompatible is the exit status of -compat when a change is breaking.
+const exitIncompatible = 3
+
+// checkCompat reports the changes from the old schema to the new one
+// and exits with exitIncompatible if any of them is breaking.
+func checkCompat(oldFile, newFile string) {
+	if newFile == "" {
+		log.Fatal("Must specify -yaml=<filename> with the new schema when using -compat option")
+	}
+
+	oldPlugin, err := schema.ParseFile(oldFile)
+	if err != nil {
+		log.Fatalf("schema.Parse: %v", err)
+	}
+	newPlugin, err := schema.ParseFile(newFile)
 	if err != nil {
-		return err
+		log.Fatalf("schema.Parse: %v", err)
 	}
 
-	if *typesDir != "" {
-		dirName := *typesDir
-		if rootDir != "" {
-			dirName = filepath.Join(rootDir, dirName)
-		}
-		if err := c.GenTypesDir(dirName); err != nil {
-			return err
+	report := schema.Compare(oldPlugin, newPlugin)
+	if *jsonOut {
+		buf, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatal(err)
 		}
+		fmt.Printf("%s\n", buf)
+	} else {
+		fmt.Print(report)
+	}
+
+	if !report.Compatible {
+		os.Exit(exitIncompatible)
+	}
+}
+
+// importOpenAPI prints the component schemas of an OpenAPI 3 document
+// as a schema.yaml, with a warning for every unsupported construct.
+func importOpenAPI(filename string) {
+	doc, err := os.ReadFile(filename)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	if *hostDir != "" {
-		dirName := *hostDir
-		if rootDir != "" {
-			dirName = filepath.Join(rootDir, dirName)
+	plugin, diags, err := schema.FromOpenAPI(filename, doc)
+	if err != nil {
+		log.Fatalf("schema.FromOpenAPI: %v", err)
+	}
+	if !*quiet {
+		for _, d := range diags {
+			log.Printf("WARNING: %v", d)
 		}
-		if err := c.GenHostDir(dirName); err != nil {
-			return err
+	}
+
+	yamlStr, err := plugin.ToYaml()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(yamlStr)
+}
+
+// processPlugin generates the code of the plugin for the target into its
+// output dirs below rootDir and reports whether -dryrun found any file to
+// write, or whether -check found any missing or stale file.
+func processPlugin(rootDir string, plugin *schema.Plugin, t *target) (bool, error) {
+	opts := &codegen.ClientOpts{
+		Force:    *force,
+		Quiet:    *quiet,
+		Merge:    *merge,
+		Validate: *validate || t.Validate,
+		FastJSON: *fastJSON || t.FastJSON,
+		DryRun:   *dryRun,
+		Check:    *check,
+	}
+	c, err := codegen.New(t.Lang, plugin, opts)
+	if err != nil {
+		return false, err
+	}
+
+	if t.Types != "" {
+		if err := c.GenTypesDir(filepath.Join(rootDir, t.Types)); err != nil {
+			return false, err
 		}
 	}
 
-	if *pluginDir != "" {
-		dirName := *pluginDir
-		if rootDir != "" {
-			dirName = filepath.Join(rootDir, dirName)
+	if t.Host != "" {
+		if err := c.GenHostDir(filepath.Join(rootDir, t.Host)); err != nil {
+			return false, err
 		}
-		if err := c.GenPluginDir(dirName); err != nil {
-			return err
+	}
+
+	if t.Plugin != "" {
+		if err := c.GenPluginDir(filepath.Join(rootDir, t.Plugin)); err != nil {
+			return false, err
 		}
 	}
 
-	return nil
+	return c.Differs(), nil
 }
@@ -13,6 +13,22 @@ var (
 	errUnreachable = errors.New("unreachable")
 )
 
+// warnf logs a warning about the schema value found at pos.
+func warnf(pos schema.Position, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if pos.IsValid() {
+		msg = fmt.Sprintf("%v: %v", pos, msg)
+	}
+	log.Printf("WARNING: %v", msg)
+}
+
+// warnMissingItems logs a warning if the array found at pos has no items.
+func warnMissingItems(pos schema.Position, items *schema.Property) {
+	if items == nil {
+		warnf(pos, "array is missing its items")
+	}
+}
+
 func (c *Client) GenCustomTypes() (GeneratedFiles, error) {
 	m := GeneratedFiles{
 		c.CustTypesFilename:      c.CustTypes,
@@ -73,32 +89,72 @@ var funcMap = map[string]any{
 	"exportHasInputOrOutputDescription": exportHasInputOrOutputDescription,
 	"exportHasInputDescription":         exportHasInputDescription,
 	"exportHasOutputDescription":        exportHasOutputDescription,
+	"exportsUseJSON":                    exportsUseJSON,
 	"getExtismType":                     getExtismType,
 	"getGoType":                         getGoType,
 	"getMbtType":                        getMbtType,
+	"goAppendJSONProperty":              goAppendJSONProperty,
+	"goDecodeJSONProperty":              goDecodeJSONProperty,
+	"goDefaultJSON":                     goDefaultJSON,
+	"goDefaultValue":                    goDefaultValue,
+	"goFastJSON":                        goFastJSON,
+	"goJSONFieldNames":                  goJSONFieldNames,
+	"goMarshalJSON":                     goMarshalJSON,
 	"goMultilineComment":                goMultilineComment,
+	"goNewStruct":                       goNewStruct,
+	"goValidTestValue":                  goValidTestValue,
+	"goValidateProperty":                goValidateProperty,
+	"goValidateTestCases":               goValidateTestCases,
+	"goValidatesRef":                    goValidatesRef,
+	"goTypeIsEnum":                      goTypeIsEnum,
+	"goUnmarshalJSON":                   goUnmarshalJSON,
+	"goUnionExampleJSON":                goUnionExampleJSON,
+	"goUnionExampleValue":               goUnionExampleValue,
+	"goUnionJSONPrefix":                 goUnionJSONPrefix,
+	"goUnionVariantList":                goUnionVariantList,
+	"hasDefaults":                       hasDefaults,
+	"hasNestedDefaults":                 hasNestedDefaults,
 	"hasOptionalFields":                 hasOptionalFields,
-	"inputIsVoidType":                   inputIsVoidType,
-	"inputIsPrimitiveType":              inputIsPrimitiveType,
+	"goPluginExportsUseFmt":             goPluginExportsUseFmt,
+	"goPluginExportsUseJSON":            goPluginExportsUseJSON,
+	"importsUseJSON":                    importsUseJSON,
+	"inputIsBuffer":                     inputIsBuffer,
+	"inputIsBufferType":                 inputIsBufferType,
+	"inputIsJSON":                       inputIsJSON,
+	"inputIsText":                       inputIsText,
 	"inputIsReferenceType":              inputIsReferenceType,
 	"inputReferenceTypeName":            inputReferenceTypeName,
 	"inputToGoType":                     inputToGoType,
+	"inputToGoTypeName":                 inputToGoTypeName,
 	"inputToMbtType":                    inputToMbtType,
-	"jsonOutputAsGoType":                jsonOutputAsGoType,
-	"jsonOutputAsMbtType":               jsonOutputAsMbtType,
+	"inputToMbtTypeName":                inputToMbtTypeName,
 	"leftJustify":                       leftJustify,
 	"lowerSnakeCase":                    lowerSnakeCase,
+	"mbtAbsentArms":                     mbtAbsentArms,
+	"mbtBufferElems":                    mbtBufferElems,
 	"mbtConvertFromJSONValue":           mbtConvertFromJSONValue,
+	"mbtDefaultArm":                     mbtDefaultArm,
+	"mbtExampleValue":                   mbtExampleValue,
 	"mbtFromJSONMatchKey":               mbtFromJSONMatchKey,
 	"mbtFromJSONMatchValue":             mbtFromJSONMatchValue,
+	"mbtIsSetByNew":                     mbtIsSetByNew,
 	"mbtMultilineComment":               mbtMultilineComment,
 	"mbtTypeIs":                         mbtTypeIs,
+	"mbtTypeIsNumberFormat":             mbtTypeIsNumberFormat,
 	"mbtTypeIsOptional":                 mbtTypeIsOptional,
+	"mbtTypeIsOptionalArray":            mbtTypeIsOptionalArray,
+	"mbtUnionExampleJSON":               mbtUnionExampleJSON,
+	"mbtUnionExampleValue":              mbtUnionExampleValue,
 	"multilineComment":                  multilineComment,
+	"nestedDefaultsGoJSON":              nestedDefaultsGoJSON,
+	"nestedDefaultsGoValues":            nestedDefaultsGoValues,
 	"optionalGoMultilineComment":        optionalGoMultilineComment,
 	"optionalMbtJSONValue":              optionalMbtJSONValue,
 	"optionalMbtMultilineComment":       optionalMbtMultilineComment,
 	"optionalMbtValue":                  optionalMbtValue,
+	"outputIsBuffer":                    outputIsBuffer,
+	"outputIsJSON":                      outputIsJSON,
+	"outputIsText":                      outputIsText,
 	"outputToGoExampleLiteral":          outputToGoExampleLiteral,
 	"outputToMbtExampleLiteral":         outputToMbtExampleLiteral,
 	"outputToGoType":                    outputToGoType,
@@ -109,6 +165,7 @@ var funcMap = map[string]any{
 	"requiredMbtValue":                  requiredMbtValue,
 	"showJSONCommaForOptional":          showJSONCommaForOptional,
 	"showJSONCommaForRequired":          showJSONCommaForRequired,
+	"showJSONCommaForSetByNew":          showJSONCommaForSetByNew,
 	"stripLeadingSlashes":               stripLeadingSlashes,
 	"uppercaseFirst":                    uppercaseFirst,
 }
@@ -142,58 +199,95 @@ func exportHasOutputDescription(export *schema.Export) bool {
 		export.Output != nil && export.Output.Description != ""
 }
 
+// exportsUseJSON reports whether any export input or output is JSON encoded.
+func exportsUseJSON(exports []*schema.Export) bool {
+	for _, export := range exports {
+		if inputIsJSON(export.Input) || outputIsJSON(export.Output) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCustomType returns the CustomType referenced by ref or nil if not found.
+func findCustomType(plugin *schema.Plugin, ref string) *schema.CustomType {
+	parts := strings.Split(ref, "/")
+	refName := parts[len(parts)-1]
+	for _, ct := range plugin.CustomTypes {
+		if ct.Name == refName {
+			return ct
+		}
+	}
+	return nil
+}
+
 func getExtismType(prop *schema.Property, ct *schema.CustomType) string {
 	var optionalMark string
 	if !prop.IsRequired {
 		optionalMark = "?"
 	}
 
+	return optionalMark + getExtismItemsType(prop)
+}
+
+// getExtismItemsType returns the language-agnostic type of the property
+// without its optional mark.
+func getExtismItemsType(prop *schema.Property) string {
+	if prop == nil {
+		return "any"
+	}
+
 	var extismType string
 	switch prop.Type {
-	case "integer", "number", "boolean":
+	case "integer", "number", "boolean", "buffer":
 		extismType = prop.Type
 	case "string":
 		extismType = prop.Type
 		if prop.Format == "date-time" {
 			extismType = "Date"
 		}
+	case "array":
+		extismType = "Array<" + getExtismItemsType(prop.Items) + ">"
+	case "object":
+		extismType = "Map<string, " + getExtismItemsType(prop.AdditionalProperties) + ">"
 	default:
 		if prop.Ref != "" {
 			parts := strings.Split(prop.Ref, "/")
 			extismType = parts[len(parts)-1]
 		} else {
-			log.Printf("WARNING: unknown property type %q: %#v", prop.Type, prop)
+			warnf(prop.Pos, "unknown property type %q", prop.Type)
 		}
 	}
 
-	return optionalMark + extismType
+	return extismType
+}
+
+// propType returns the type of the property to generate code for.
+// A string with `format: byte` holds base64-encoded bytes in JSON,
+// so it is generated just like a buffer.
+func propType(prop *schema.Property) string {
+	if prop.Type == "string" && prop.Format == "byte" {
+		return "buffer"
+	}
+	return prop.Type
 }
 
 func hasOptionalFields(ct *schema.CustomType) bool {
 	return len(ct.Required) != len(ct.Properties)
 }
 
-func inputIsVoidType(export *schema.Export) bool {
-	return export.Input == nil
+// importsUseJSON reports whether any import input or output is JSON encoded.
+func importsUseJSON(imports []*schema.Import) bool {
+	for _, imp := range imports {
+		if inputIsJSON(imp.Input) || outputIsJSON(imp.Output) {
+			return true
+		}
+	}
+	return false
 }
 
-func inputIsPrimitiveType(export *schema.Export) bool {
-	if export.Input == nil || export.Input.Ref != "" {
-		return false
-	}
-	switch export.Input.Type {
-	case "integer", "string", "number", "boolean":
-		return true
-	case "object":
-		return false // TODO - what should this be?
-	case "array":
-		return false // TODO - what should this be?
-	case "buffer":
-		return false // TODO - what should this be?
-	default:
-		log.Printf("WARNING: unknown export input type %q", export.Input.Type)
-		return false
-	}
+func inputIsBufferType(export *schema.Export) bool {
+	return inputIsBuffer(export.Input)
 }
 
 func inputIsReferenceType(export *schema.Export) bool {
@@ -0,0 +1,142 @@
+package codegen
+
+import (
+	"fmt"
+	"mime"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+// Payload encodings of export and import inputs and outputs,
+// selected by their contentType.
+const (
+	encodingJSON   = "json"
+	encodingText   = "text"
+	encodingBinary = "binary"
+)
+
+// payloadEncoding returns the encoding of a payload with the given
+// $ref, type, and contentType. A missing contentType defaults to raw bytes
+// for buffers and JSON for everything else.
+func payloadEncoding(ref, typ, contentType string) (string, error) {
+	isBuffer := ref == "" && typ == "buffer"
+	if contentType == "" {
+		if isBuffer {
+			return encodingBinary, nil
+		}
+		return encodingJSON, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("invalid contentType %q: %w", contentType, err)
+	}
+
+	switch mediaType {
+	case "application/json":
+		if isBuffer {
+			return "", fmt.Errorf("contentType %q is not supported for type \"buffer\"; use \"application/x-binary\"", contentType)
+		}
+		return encodingJSON, nil
+	case "text/plain":
+		if ref != "" || typ != "string" {
+			return "", fmt.Errorf("contentType %q requires type \"string\"", contentType)
+		}
+		return encodingText, nil
+	case "application/x-binary":
+		if !isBuffer {
+			return "", fmt.Errorf("contentType %q requires type \"buffer\"", contentType)
+		}
+		return encodingBinary, nil
+	default:
+		return "", fmt.Errorf("unsupported contentType %q; must be one of \"application/json\", \"text/plain\", or \"application/x-binary\"", contentType)
+	}
+}
+
+// checkContentTypes returns an error if the contentType of any export or
+// import input or output cannot be generated.
+func checkContentTypes(plugin *schema.Plugin) error {
+	check := func(pos schema.Position, kind, name, dir, ref, typ, contentType string) error {
+		if _, err := payloadEncoding(ref, typ, contentType); err != nil {
+			if pos.IsValid() {
+				return fmt.Errorf("%v: %v %q %v: %w", pos, kind, name, dir, err)
+			}
+			return fmt.Errorf("%v %q %v: %w", kind, name, dir, err)
+		}
+		return nil
+	}
+
+	for _, export := range plugin.Exports {
+		if in := export.Input; in != nil {
+			if err := check(in.Pos, "export", export.Name, "input", in.Ref, in.Type, in.ContentType); err != nil {
+				return err
+			}
+		}
+		if out := export.Output; out != nil {
+			if err := check(out.Pos, "export", export.Name, "output", out.Ref, out.Type, out.ContentType); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, imp := range plugin.Imports {
+		if in := imp.Input; in != nil {
+			if err := check(in.Pos, "import", imp.Name, "input", in.Ref, in.Type, in.ContentType); err != nil {
+				return err
+			}
+		}
+		if out := imp.Output; out != nil {
+			if err := check(out.Pos, "import", imp.Name, "output", out.Ref, out.Type, out.ContentType); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func inputEncoding(input *schema.Input) string {
+	if input == nil {
+		return ""
+	}
+	enc, _ := payloadEncoding(input.Ref, input.Type, input.ContentType)
+	return enc
+}
+
+func outputEncoding(output *schema.Output) string {
+	if output == nil {
+		return ""
+	}
+	enc, _ := payloadEncoding(output.Ref, output.Type, output.ContentType)
+	return enc
+}
+
+// inputIsBuffer reports whether the input is raw bytes which bypass JSON.
+func inputIsBuffer(input *schema.Input) bool {
+	return inputEncoding(input) == encodingBinary
+}
+
+// outputIsBuffer reports whether the output is raw bytes which bypass JSON.
+func outputIsBuffer(output *schema.Output) bool {
+	return outputEncoding(output) == encodingBinary
+}
+
+// inputIsText reports whether the input is a raw UTF-8 string which bypasses JSON.
+func inputIsText(input *schema.Input) bool {
+	return inputEncoding(input) == encodingText
+}
+
+// outputIsText reports whether the output is a raw UTF-8 string which bypasses JSON.
+func outputIsText(output *schema.Output) bool {
+	return outputEncoding(output) == encodingText
+}
+
+// inputIsJSON reports whether the input is JSON encoded.
+func inputIsJSON(input *schema.Input) bool {
+	return inputEncoding(input) == encodingJSON
+}
+
+// outputIsJSON reports whether the output is JSON encoded.
+func outputIsJSON(output *schema.Output) bool {
+	return outputEncoding(output) == encodingJSON
+}
@@ -0,0 +1,96 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+func TestPayloadEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		ref         string
+		typ         string
+		contentType string
+		want        string
+		wantErr     string
+	}{
+		{name: "default string", typ: "string", want: encodingJSON},
+		{name: "default ref", ref: "#/schemas/Fruit", want: encodingJSON},
+		{name: "default buffer", typ: "buffer", want: encodingBinary},
+		{name: "json integer", typ: "integer", contentType: "application/json", want: encodingJSON},
+		{name: "json ref", ref: "#/schemas/Fruit", contentType: "application/json", want: encodingJSON},
+		{name: "text string", typ: "string", contentType: "text/plain", want: encodingText},
+		{name: "text string with charset", typ: "string", contentType: "text/plain; charset=UTF-8", want: encodingText},
+		{name: "binary buffer", typ: "buffer", contentType: "application/x-binary", want: encodingBinary},
+		{name: "json buffer", typ: "buffer", contentType: "application/json", wantErr: `not supported for type "buffer"`},
+		{name: "text integer", typ: "integer", contentType: "text/plain", wantErr: `requires type "string"`},
+		{name: "text ref", ref: "#/schemas/Fruit", contentType: "text/plain", wantErr: `requires type "string"`},
+		{name: "binary string", typ: "string", contentType: "application/x-binary", wantErr: `requires type "buffer"`},
+		{name: "unknown", typ: "string", contentType: "text/html", wantErr: `unsupported contentType "text/html"`},
+		{name: "malformed", typ: "string", contentType: "text/", wantErr: `invalid contentType "text/"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := payloadEncoding(tt.ref, tt.typ, tt.contentType)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("payloadEncoding err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("payloadEncoding = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRejectsUnknownContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		plugin *schema.Plugin
+		want   string
+	}{
+		{
+			name: "export input",
+			plugin: &schema.Plugin{
+				Version: "v1-draft",
+				PkgName: "bad",
+				Exports: []*schema.Export{
+					{Name: "render", Input: &schema.Input{Type: "string", ContentType: "text/html"}},
+				},
+			},
+			want: `export "render" input: unsupported contentType "text/html"; must be one of "application/json", "text/plain", or "application/x-binary"`,
+		},
+		{
+			name: "import output",
+			plugin: &schema.Plugin{
+				Version: "v1-draft",
+				PkgName: "bad",
+				Imports: []*schema.Import{
+					{Name: "count", Output: &schema.Output{Type: "integer", ContentType: "text/plain"}},
+				},
+			},
+			want: `import "count" output: contentType "text/plain" requires type "string"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("go", tt.plugin, nil)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("New err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package codegen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+// checkDefaults returns an error if the `default` of any property does not
+// type-check against the property's type and format, since it could not be
+// used by the generated constructors and decoders.
+func checkDefaults(plugin *schema.Plugin) error {
+	for _, ct := range plugin.CustomTypes {
+		for _, prop := range ct.Properties {
+			if prop.Default == nil {
+				continue
+			}
+			if err := checkDefault(plugin, prop); err != nil {
+				if prop.Pos.IsValid() {
+					return fmt.Errorf("%v: schema %q property %q: invalid default %q: %w", prop.Pos, ct.Name, prop.Name, *prop.Default, err)
+				}
+				return fmt.Errorf("schema %q property %q: invalid default %q: %w", ct.Name, prop.Name, *prop.Default, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkDefault(plugin *schema.Plugin, prop *schema.Property) error {
+	value := *prop.Default
+	if prop.Ref != "" {
+		ct := findCustomType(plugin, prop.Ref)
+		if ct == nil || len(ct.Enum) == 0 {
+			return fmt.Errorf("a default is not supported for %q", prop.Ref)
+		}
+		for _, e := range ct.Enum {
+			if e == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("not one of %q", ct.Enum)
+	}
+
+	switch propType(prop) {
+	case "integer":
+		bitSize := 32 // a plain integer is an `Int` in MoonBit.
+		if prop.Format == "int64" {
+			bitSize = 64
+		}
+		n, err := strconv.ParseInt(value, 10, bitSize)
+		if err != nil {
+			return fmt.Errorf("not an int%v", bitSize)
+		}
+		return checkDefaultBounds(prop, float64(n))
+	case "number":
+		bitSize := 64
+		if prop.Format == "float" {
+			bitSize = 32
+		}
+		f, err := strconv.ParseFloat(value, bitSize)
+		if err != nil {
+			return errors.New("not a number")
+		}
+		return checkDefaultBounds(prop, f)
+	case "boolean":
+		if value != "true" && value != "false" {
+			return errors.New("not true or false")
+		}
+	case "string":
+		if prop.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return errors.New("not an RFC 3339 date-time")
+			}
+		}
+	case "buffer":
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return errors.New("not base64-encoded")
+		}
+	default:
+		return fmt.Errorf("a default is not supported for type %q", prop.Type)
+	}
+	return nil
+}
+
+func checkDefaultBounds(prop *schema.Property, f float64) error {
+	if prop.Minimum != nil && f < *prop.Minimum {
+		return fmt.Errorf("less than minimum %v", formatBound(*prop.Minimum))
+	}
+	if prop.Maximum != nil && f > *prop.Maximum {
+		return fmt.Errorf("greater than maximum %v", formatBound(*prop.Maximum))
+	}
+	return nil
+}
+
+// hasDefaults reports whether any property of the struct has a `default`.
+func hasDefaults(ct *schema.CustomType) bool {
+	for _, prop := range ct.Properties {
+		if prop.Default != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// goNewStruct returns the body of the generated `New` function of the
+// struct, which sets every property that has a `default`. The defaults of
+// optional scalars are declared first so that they can be pointed to.
+func goNewStruct(ct *schema.CustomType) string {
+	var vars, fields []string
+	for _, prop := range ct.Properties {
+		if prop.Default == nil {
+			continue
+		}
+		value := goDefaultLiteral(prop)
+		if strings.HasPrefix(getGoType(prop), "*") {
+			name := "default" + uppercaseFirst(prop.Name)
+			if t := goScalarType(prop); (prop.Type == "integer" && t != "int") || (prop.Type == "number" && t != "float64") {
+				value = fmt.Sprintf("%v(%v)", t, value)
+			}
+			vars = append(vars, fmt.Sprintf("\t%v := %v\n", name, value))
+			value = "&" + name
+		}
+		fields = append(fields, fmt.Sprintf("\t\t%v: %v,\n", uppercaseFirst(prop.Name), value))
+	}
+
+	if len(fields) == 0 {
+		return fmt.Sprintf("\treturn &%v{}\n", ct.Name)
+	}
+	return fmt.Sprintf("%v\treturn &%v{\n%v\t}\n", strings.Join(vars, ""), ct.Name, strings.Join(fields, ""))
+}
+
+// goDefaultLiteral returns the `default` of the property as a Go literal.
+// The default has been checked by checkDefaults.
+func goDefaultLiteral(prop *schema.Property) string {
+	value := *prop.Default
+	if prop.Ref != "" {
+		return fmt.Sprintf("%vEnum%v", uppercaseFirst(getGoItemsType(prop)), uppercaseFirst(value))
+	}
+
+	switch propType(prop) {
+	case "integer":
+		n, _ := strconv.ParseInt(value, 10, 64)
+		return strconv.FormatInt(n, 10)
+	case "number":
+		f, _ := strconv.ParseFloat(value, 64)
+		return formatBound(f)
+	case "string":
+		if prop.Format == "date-time" {
+			t, _ := time.Parse(time.RFC3339, value)
+			t = t.UTC()
+			return fmt.Sprintf("time.Date(%v, time.%v, %v, %v, %v, %v, %v, time.UTC)",
+				t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond())
+		}
+		return fmt.Sprintf("%q", value)
+	case "buffer":
+		b, _ := base64.StdEncoding.DecodeString(value)
+		return fmt.Sprintf("[]byte(%q)", b)
+	}
+	return value // a boolean
+}
+
+// goDefaultValue returns the `default` of the property as a Go value of the
+// type of its field, for use in generated tests.
+func goDefaultValue(prop *schema.Property) string {
+	value := goDefaultLiteral(prop)
+	if !strings.HasPrefix(getGoType(prop), "*") {
+		return value
+	}
+	if t := goScalarType(prop); t != "time.Time" {
+		return fmt.Sprintf("%vPtr(%v)", t, value)
+	}
+	return fmt.Sprintf("timePtr(%v)", value)
+}
+
+// goDefaultJSON returns the JSON encoding of the `default` of the property
+// as encoded by encoding/json, for use in generated tests.
+func goDefaultJSON(prop *schema.Property) string {
+	value := *prop.Default
+	var v any = value // a string or an enum
+	if prop.Ref == "" {
+		switch propType(prop) {
+		case "integer":
+			v, _ = strconv.ParseInt(value, 10, 64)
+		case "number":
+			if prop.Format == "float" {
+				f, _ := strconv.ParseFloat(value, 32)
+				v = float32(f)
+			} else {
+				v, _ = strconv.ParseFloat(value, 64)
+			}
+		case "boolean":
+			v = value == "true"
+		case "string":
+			if prop.Format == "date-time" {
+				t, _ := time.Parse(time.RFC3339, value)
+				v = t.UTC()
+			}
+		case "buffer":
+			v, _ = base64.StdEncoding.DecodeString(value)
+		}
+	}
+	buf, _ := json.Marshal(v)
+	return string(buf)
+}
+
+// nestedDefaultsType returns the struct with defaults that the property
+// holds directly or as the elements of an array or map, if any.
+func nestedDefaultsType(prop *schema.Property) *schema.CustomType {
+	elem := prop
+	switch {
+	case prop.Items != nil:
+		elem = prop.Items
+	case prop.AdditionalProperties != nil:
+		elem = prop.AdditionalProperties
+	}
+	if ct := elem.RefCustomType; ct != nil && hasDefaults(ct) {
+		return ct
+	}
+	return nil
+}
+
+// hasNestedDefaults reports whether any property of the struct holds a
+// struct with defaults.
+func hasNestedDefaults(ct *schema.CustomType) bool {
+	for _, prop := range ct.Properties {
+		if nestedDefaultsType(prop) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedDefaultsGoJSON returns the JSON object of the struct with its
+// required properties and an empty object for each struct with defaults
+// that it holds, which is decoded into the value of its `New` function.
+func nestedDefaultsGoJSON(ct *schema.CustomType) string {
+	var fields []string
+	for _, prop := range ct.Properties {
+		var v string
+		switch {
+		case nestedDefaultsType(prop) == nil && prop.IsRequired:
+			v = requiredGoJSONValue(prop)
+		case nestedDefaultsType(prop) == nil:
+			continue
+		case prop.Items != nil:
+			v = "[{}]"
+		case prop.AdditionalProperties != nil:
+			v = `{"key":{}}`
+		default:
+			v = "{}"
+		}
+		fields = append(fields, fmt.Sprintf("%q:%v", prop.Name, v))
+	}
+	return "{" + strings.Join(fields, ",") + "}"
+}
+
+// nestedDefaultsGoValues returns the Go statements that set the properties
+// of `want` to the values decoded from nestedDefaultsGoJSON.
+func nestedDefaultsGoValues(ct *schema.CustomType) string {
+	var stmts []string
+	for _, prop := range ct.Properties {
+		field := "want." + uppercaseFirst(prop.Name)
+		nested := nestedDefaultsType(prop)
+		if nested == nil {
+			if prop.IsRequired {
+				stmts = append(stmts, fmt.Sprintf("\t%v = %v\n", field, requiredGoValue(prop)))
+			}
+			continue
+		}
+
+		goType := getGoType(prop)
+		elem := strings.TrimPrefix(strings.TrimPrefix(goType, "[]"), "map[string]")
+		value := fmt.Sprintf("New%v()", nested.Name)
+		if !strings.HasPrefix(elem, "*") {
+			value = "*" + value
+		}
+		switch {
+		case prop.Items != nil:
+			value = fmt.Sprintf("%v{%v}", goType, value)
+		case prop.AdditionalProperties != nil:
+			value = fmt.Sprintf(`%v{"key": %v}`, goType, value)
+		}
+		stmts = append(stmts, fmt.Sprintf("\t%v = %v\n", field, value))
+	}
+	return strings.Join(stmts, "")
+}
+
+// mbtDefaultLiteral returns the `default` of the property as a MoonBit
+// literal of its type, wrapped in `Some` for an optional type.
+// The default has been checked by checkDefaults.
+func mbtDefaultLiteral(prop *schema.Property) string {
+	value := *prop.Default
+	var literal string
+	switch {
+	case prop.Ref != "":
+		literal = uppercaseFirst(value)
+	case propType(prop) == "integer":
+		n, _ := strconv.ParseInt(value, 10, 64)
+		literal = strconv.FormatInt(n, 10)
+	case propType(prop) == "number":
+		literal = mbtDoubleLiteral(value)
+	case propType(prop) == "string":
+		literal = mbtStringLiteral(value)
+	case propType(prop) == "buffer":
+		b, _ := base64.StdEncoding.DecodeString(value)
+		literal = mbtBytesLiteral(b)
+	default: // a boolean
+		literal = value
+	}
+
+	if mbtTypeIsOptional(prop) {
+		return "Some(" + literal + ")"
+	}
+	return literal
+}
+
+// mbtDefaultJSON returns the JSON encoding of the `default` of the property
+// as stringified by MoonBit.
+func mbtDefaultJSON(prop *schema.Property) string {
+	value := *prop.Default
+	switch {
+	case prop.Ref != "":
+		return mbtJSONString(value)
+	case propType(prop) == "number":
+		return mbtDoubleLiteral(value)
+	case propType(prop) == "integer":
+		n, _ := strconv.ParseInt(value, 10, 64)
+		return strconv.FormatInt(n, 10)
+	case propType(prop) == "boolean":
+		return value
+	}
+	return mbtJSONString(value) // a string or base64-encoded buffer
+}
+
+// mbtStringLiteral returns s as a MoonBit string literal, which, unlike Go,
+// only has the escapes below and `\u{...}` for any other character.
+func mbtStringLiteral(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteString(`\` + string(r))
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\b':
+			b.WriteString(`\b`)
+		case unicode.IsPrint(r):
+			b.WriteRune(r)
+		default:
+			fmt.Fprintf(&b, `\u{%x}`, r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// mbtBytesLiteral returns buf as a MoonBit bytes literal, with `\x..` for
+// every byte that is not printable ASCII.
+func mbtBytesLiteral(buf []byte) string {
+	var b strings.Builder
+	b.WriteString(`b"`)
+	for _, c := range buf {
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteString(`\` + string(c))
+		case c >= ' ' && c <= '~':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// mbtJSONString returns s as a JSON string as stringified by MoonBit,
+// which only escapes the quote, the backslash and the control characters.
+func mbtJSONString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteString(`\` + string(r))
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\b':
+			b.WriteString(`\b`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r < ' ':
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func mbtDoubleLiteral(value string) string {
+	f, _ := strconv.ParseFloat(value, 64)
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// mbtIsSetByNew reports whether the property has a value in the struct
+// returned by the generated MoonBit `new` function, and is therefore
+// present in its JSON encoding.
+func mbtIsSetByNew(prop *schema.Property) bool {
+	return prop.IsRequired || prop.Default != nil
+}
+
+// mbtPropsSetByNew returns the properties of the struct that have a value
+// in the struct returned by the generated MoonBit `new` function.
+func mbtPropsSetByNew(ct *schema.CustomType) []*schema.Property {
+	var props []*schema.Property
+	for _, prop := range ct.Properties {
+		if mbtIsSetByNew(prop) {
+			props = append(props, prop)
+		}
+	}
+	return props
+}
+
+// showJSONCommaForSetByNew is like showJSONCommaForRequired
+// for the properties that are set by the generated MoonBit `new` function.
+func showJSONCommaForSetByNew(index int, ct *schema.CustomType) string {
+	for index++; index < len(ct.Properties); index++ {
+		if mbtIsSetByNew(ct.Properties[index]) {
+			return ","
+		}
+	}
+	return ""
+}
+
+// mbtAbsentArms returns the match arms of the generated MoonBit `from_json`
+// for a null or missing optional property. A missing property takes its
+// `default`.
+func mbtAbsentArms(prop *schema.Property) string {
+	if prop.Default == nil {
+		return "    Some(Null) | None => None"
+	}
+	return "    Some(Null) => None\n    None => " + mbtDefaultLiteral(prop)
+}
+
+// mbtDefaultArm returns the match arm of the generated MoonBit `from_json`
+// for a missing property of a non-optional type that has a `default`.
+func mbtDefaultArm(prop *schema.Property) string {
+	if prop.Default == nil || mbtTypeIsOptional(prop) {
+		return ""
+	}
+	return "\n    None => " + mbtDefaultLiteral(prop)
+}
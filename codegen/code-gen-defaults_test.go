@@ -0,0 +1,139 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+func TestNewRejectsInvalidDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		prop string
+		want string
+	}{
+		{
+			name: "integer",
+			prop: "{name: p, type: integer, default: '1.5'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "1.5": not an int32`,
+		},
+		{
+			name: "int64",
+			prop: "{name: p, type: integer, format: int64, default: '9223372036854775808'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "9223372036854775808": not an int64`,
+		},
+		{
+			name: "below minimum",
+			prop: "{name: p, type: integer, minimum: 1, default: '0'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "0": less than minimum 1`,
+		},
+		{
+			name: "above maximum",
+			prop: "{name: p, type: number, maximum: 0.5, default: '0.75'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "0.75": greater than maximum 0.5`,
+		},
+		{
+			name: "float",
+			prop: "{name: p, type: number, format: float, default: '1e39'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "1e39": not a number`,
+		},
+		{
+			name: "boolean",
+			prop: "{name: p, type: boolean, default: 'yes'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "yes": not true or false`,
+		},
+		{
+			name: "date-time",
+			prop: "{name: p, type: string, format: date-time, default: '2024-06-01'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "2024-06-01": not an RFC 3339 date-time`,
+		},
+		{
+			name: "byte",
+			prop: "{name: p, type: string, format: byte, default: '!'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "!": not base64-encoded`,
+		},
+		{
+			name: "enum",
+			prop: "{name: p, $ref: '#/schemas/E', default: purple}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "purple": not one of ["red" "green"]`,
+		},
+		{
+			name: "array",
+			prop: "{name: p, type: array, items: {type: string}, default: '[]'}",
+			want: `schema.yaml:13:9: schema "S" property "p": invalid default "[]": a default is not supported for type "array"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := schema.ParseNamedStr("schema.yaml", `version: v1-draft
+exports:
+  - name: run
+    input:
+      $ref: '#/schemas/S'
+schemas:
+  - name: E
+    enum: [red, green]
+  - name: S
+    properties:
+      - name: q
+        type: string
+      - `+tt.prop+`
+`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plugin.PkgName = "defaults"
+
+			for _, lang := range []string{"go", "mbt"} {
+				if _, err := New(lang, plugin, nil); err == nil || err.Error() != tt.want {
+					t.Errorf("New(%q) err = %v, want %q", lang, err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMbtDefaultLiteral(t *testing.T) {
+	t.Parallel()
+
+	str := func(value string) *string { return &value }
+	tests := []struct {
+		name     string
+		prop     *schema.Property
+		want     string
+		wantJSON string
+	}{
+		{
+			name:     "string",
+			prop:     &schema.Property{Type: "string", IsRequired: true, Default: str("a\"b\\c\n\t\a\v\x7f\u200bé😀{}")},
+			want:     `"a\"b\\c\n\t\u{7}\u{b}\u{7f}\u{200b}é😀{}"`,
+			wantJSON: `"a\"b\\c\n\t\u0007\u000b` + "\x7f\u200bé😀{}\"",
+		},
+		{
+			name:     "optional string",
+			prop:     &schema.Property{Type: "string", Default: str("\x00")},
+			want:     `Some("\u{0}")`,
+			wantJSON: `"\u0000"`,
+		},
+		{
+			name:     "buffer",
+			prop:     &schema.Property{Type: "buffer", IsRequired: true, Default: str("AEEi3P8=")}, // "\x00A\"\xdc\xff"
+			want:     `b"\x00A\"\xdc\xff"`,
+			wantJSON: `"AEEi3P8="`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mbtDefaultLiteral(tt.prop); got != tt.want {
+				t.Errorf("mbtDefaultLiteral = %v, want %v", got, tt.want)
+			}
+			if got := mbtDefaultJSON(tt.prop); got != tt.wantJSON {
+				t.Errorf("mbtDefaultJSON = %v, want %v", got, tt.wantJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around each hunk of a diff.
+const diffContext = 3
+
+// maxDiffEdits limits the work of diffLines: files that differ by more
+// lines are diffed as a single replacement.
+const maxDiffEdits = 1000
+
+// diffOp is a line of an edit script: kept (' '), deleted ('-') or inserted ('+').
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns the unified diff that turns the old contents of a file
+// into the new ones, or "" if they are the same.
+func unifiedDiff(oldName, newName, old, new string) string {
+	if old == new {
+		return ""
+	}
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	// oldLine[i] and newLine[i] are the numbers of lines before ops[i].
+	oldLine, newLine := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if op.kind != '+' {
+			oldLine[i+1]++
+		}
+		if op.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %v\n+++ %v\n", oldName, newName)
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+
+		start, end := max(i-diffContext, 0), i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			same := end
+			for same < len(ops) && ops[same].kind == ' ' {
+				same++
+			}
+			if same == len(ops) || same-end > 2*diffContext {
+				end = min(end+diffContext, same)
+				break
+			}
+			end = same
+		}
+
+		fmt.Fprintf(&b, "@@ -%v +%v @@\n", diffRange(oldLine[start], oldLine[end]), diffRange(newLine[start], newLine[end]))
+		for _, op := range ops[start:end] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// diffRange formats the range of a hunk header like GNU diff does, for the
+// lines after the first start lines up to line end.
+func diffRange(start, end int) string {
+	switch end - start {
+	case 0:
+		return fmt.Sprintf("%v,0", start)
+	case 1:
+		return fmt.Sprint(start + 1)
+	}
+	return fmt.Sprintf("%v,%v", start+1, end-start)
+}
+
+// splitLines splits s after each newline.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns a shortest edit script that turns a into b.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	ops = append(ops, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	return ops
+}
+
+// myersDiff implements "An O(ND) Difference Algorithm and Its Variations"
+// by Eugene W. Myers.
+func myersDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	// trace[d] holds v[-d..d] before the d-th step, for backtracking.
+	var trace [][]int
+
+	for d := 0; d <= n+m && d <= maxDiffEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[offset+k-1] < v[offset+k+1] {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return myersBacktrack(a, b, trace)
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+	for _, line := range a {
+		ops = append(ops, diffOp{kind: '-', line: line})
+	}
+	for _, line := range b {
+		ops = append(ops, diffOp{kind: '+', line: line})
+	}
+	return ops
+}
+
+// myersBacktrack returns the edit script found by myersDiff.
+func myersBacktrack(a, b []string, trace [][]int) []diffOp {
+	var ops []diffOp
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		v := func(k int) int { return trace[d][k+d] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || k != d && v(k-1) < v(k+1) {
+			prevK = k + 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{kind: ' ', line: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, diffOp{kind: '+', line: b[y-1]})
+			y--
+		} else {
+			ops = append(ops, diffOp{kind: '-', line: a[x-1]})
+			x--
+		}
+	}
+	for ; x > 0; x-- {
+		ops = append(ops, diffOp{kind: ' ', line: a[x-1]})
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
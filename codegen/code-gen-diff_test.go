@@ -0,0 +1,186 @@
+package codegen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{
+			name: "identical",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+		},
+		{
+			name: "changes within twice the context share a hunk",
+			old:  "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n",
+			new:  "a\nb\nc\nD\ne\nf\ng\nh\ni\nj\nk\n",
+			want: `--- f
++++ f
+@@ -1,10 +1,11 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+ h
+ i
+ j
++k
+`,
+		},
+		{
+			name: "distant changes get their own hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n12\n",
+			want: `--- f
++++ f
+@@ -1,3 +1,4 @@
++0
+ 1
+ 2
+ 3
+@@ -8,5 +9,4 @@
+ 8
+ 9
+ 10
+-11
+ 12
+`,
+		},
+		{
+			name: "no newline at end of file",
+			old:  "one\ntwo",
+			new:  "one\nthree\n",
+			want: `--- f
++++ f
+@@ -1,2 +1,2 @@
+ one
+-two
+\ No newline at end of file
++three
+`,
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "package main\n",
+			want: `--- f
++++ f
+@@ -0,0 +1 @@
++package main
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unifiedDiff("f", "f", tt.old, tt.new)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unifiedDiff mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestWriteSrcFilesDryRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := map[string]string{
+		"build.sh":        "#!/bin/bash -ex\ntinygo build\n",
+		"types.go":        "package main\n\ntype Fruit string\n",
+		"types-extra.txt": "unchanged\n",
+	}
+	for name, src := range existing {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	c := &Client{opts: ClientOpts{DryRun: true, DryRunOutput: &out, Merge: true}}
+	if err := c.writeSrcFiles(dir, GeneratedFiles{
+		"build.sh":        "#!/bin/bash -ex\n",
+		"main.go":         "package main\n\nfunc main() {}\n",
+		"types.go":        "package main\n\ntype Fruit int\n",
+		"types-extra.txt": "unchanged\n",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range existing {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("DryRun modified %v", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "main.go")); !os.IsNotExist(err) {
+		t.Errorf("DryRun created main.go: %v", err)
+	}
+
+	buildSh, mainGo, typesExtra, typesGo := filepath.Join(dir, "build.sh"), filepath.Join(dir, "main.go"), filepath.Join(dir, "types-extra.txt"), filepath.Join(dir, "types.go")
+	want := `skipped   ` + buildSh + `
+--- ` + buildSh + `
++++ ` + buildSh + `
+@@ -1,2 +1 @@
+ #!/bin/bash -ex
+-tinygo build
+new       ` + mainGo + `
+--- ` + os.DevNull + `
++++ ` + mainGo + `
+@@ -0,0 +1,3 @@
++package main
++
++func main() {}
+unchanged ` + typesExtra + `
+changed   ` + typesGo + `
+--- ` + typesGo + `
++++ ` + typesGo + `
+@@ -1,3 +1,3 @@
+ package main
+ 
+-type Fruit string
++type Fruit int
+`
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("DryRun output mismatch (-want +got):\n%v", diff)
+	}
+	if !c.Differs() {
+		t.Error("Differs = false, want true")
+	}
+
+	c = &Client{opts: ClientOpts{DryRun: true, DryRunOutput: &out}}
+	if err := c.writeSrcFiles(dir, GeneratedFiles{"types-extra.txt": "unchanged\n"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.Differs() {
+		t.Error("Differs = true for unchanged files, want false")
+	}
+
+	c = &Client{opts: ClientOpts{DryRun: true, DryRunOutput: &out}}
+	if err := c.writeSrcFiles(dir, GeneratedFiles{"build.sh": "#!/bin/bash -ex\n", "types-extra.txt": "unchanged\n"}); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Differs() {
+		t.Error("Differs = false for a skipped file, want true")
+	}
+}
@@ -1,9 +1,12 @@
 package codegen
 
 import (
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -35,8 +38,34 @@ func (c *Client) GenPluginDir(dirName string) error {
 }
 
 func (c *Client) writeSrcFiles(dirName string, srcFiles GeneratedFiles) error {
-	for filename, src := range srcFiles {
+	filenames := make([]string, 0, len(srcFiles))
+	for filename := range srcFiles {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		src := srcFiles[filename]
 		dirFile := filepath.Join(dirName, filename)
+		if c.opts.Check {
+			if err := c.checkSrcFile(dirFile, src); err != nil {
+				return err
+			}
+			continue
+		}
+		if merge := mergeFuncs[filename]; c.opts.Merge && merge != nil {
+			merged, err := c.mergeSrcFile(dirFile, src, merge)
+			if err != nil {
+				return err
+			}
+			src = merged
+		}
+		if c.opts.DryRun {
+			if err := c.reportSrcFile(dirFile, src); err != nil {
+				return err
+			}
+			continue
+		}
 		fileWriter := c.maybeWriteSourceFile
 		if strings.HasSuffix(filename, ".sh") {
 			fileWriter = c.maybeWriteScriptFile
@@ -65,7 +94,7 @@ func (c *Client) maybeWriteFile(path, buf string, perm os.FileMode) error {
 		}
 	}
 
-	if !c.opts.Force {
+	if !c.overwrites(path) {
 		if _, err := os.Stat(path); err == nil {
 			if !c.opts.Quiet {
 				log.Printf("WARNING: not writing file %q - add -force to overwrite and -q to silence", path)
@@ -80,3 +109,160 @@ func (c *Client) maybeWriteFile(path, buf string, perm os.FileMode) error {
 
 	return nil
 }
+
+// overwrites reports whether an existing file at path is replaced: always
+// with Force, and with Merge unless it is user-owned scaffolding that cannot
+// be merged.
+func (c *Client) overwrites(path string) bool {
+	filename := filepath.Base(path)
+	return c.opts.Force || c.opts.Merge && (!isUserOwned(filename) || mergeFuncs[filename] != nil)
+}
+
+// mergeSrcFile returns the newly generated src of a user-owned file merged
+// into the existing file at path, if any, and logs every merge warning.
+func (c *Client) mergeSrcFile(path, src string, merge mergeFunc) (string, error) {
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return src, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	// main.go and main.mbt sort before the plugin-functions file that they
+	// are merged with, which is therefore not yet overwritten.
+	exports, err := previousExports(path)
+	if err != nil {
+		return "", err
+	}
+
+	merged, warnings, err := merge(path, string(existing), src, exports)
+	if err != nil {
+		return "", err
+	}
+	if !c.opts.Quiet {
+		for _, warning := range warnings {
+			log.Printf("WARNING: %v", warning)
+		}
+	}
+	return merged, nil
+}
+
+// fileStatus is what writeSrcFiles does, or would do, with a generated file,
+// or what Check finds wrong with it.
+type fileStatus string
+
+const (
+	fileNew       fileStatus = "new"
+	fileChanged   fileStatus = "changed"
+	fileUnchanged fileStatus = "unchanged"
+	fileSkipped   fileStatus = "skipped"
+	fileMissing   fileStatus = "missing"
+	fileStale     fileStatus = "stale"
+)
+
+// srcFileStatus compares the generated src with the file at path and
+// returns the existing contents of the file, if any.
+func (c *Client) srcFileStatus(path, src string) (fileStatus, string, error) {
+	existing, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return fileNew, "", nil
+	case err != nil:
+		return "", "", err
+	case string(existing) == src:
+		return fileUnchanged, string(existing), nil
+	case !c.overwrites(path):
+		return fileSkipped, string(existing), nil
+	}
+	return fileChanged, string(existing), nil
+}
+
+// reportSrcFile prints what writing the generated src to path would do,
+// with the unified diff of a new, changed or skipped file, to DryRunOutput.
+func (c *Client) reportSrcFile(path, src string) error {
+	status, existing, err := c.srcFileStatus(path, src)
+	if err != nil {
+		return err
+	}
+
+	out := c.reportOutput()
+	fmt.Fprintf(out, "%-9v %v\n", status, path)
+
+	switch status {
+	case fileNew:
+		fmt.Fprint(out, unifiedDiff(os.DevNull, path, "", src))
+	case fileChanged, fileSkipped:
+		fmt.Fprint(out, unifiedDiff(path, path, existing, src))
+	default:
+		return nil
+	}
+	c.differs = true
+	return nil
+}
+
+// checkSrcFile prints the path of the file to DryRunOutput if it is missing,
+// or if it is stale compared to the generated src.
+func (c *Client) checkSrcFile(path, src string) error {
+	status, err := checkSrcFileStatus(path, src)
+	if err != nil || status == "" {
+		return err
+	}
+	fmt.Fprintf(c.reportOutput(), "%-7v %v\n", status, path)
+	c.differs = true
+	return nil
+}
+
+// checkSrcFileStatus returns fileMissing or fileStale for a file that fails
+// the Check, or "" if it is up to date.
+func checkSrcFileStatus(path, src string) (fileStatus, error) {
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fileMissing, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	filename := filepath.Base(path)
+	merge := mergeFuncs[filename]
+	switch {
+	case !isUserOwned(filename):
+		if string(existing) != src {
+			return fileStale, nil
+		}
+	case merge != nil:
+		// Merging the existing file into itself normalizes it the same way
+		// as merging the generated src does, but without new stubs or TODO
+		// comments, so they only differ if the exports are out of date.
+		exports, err := previousExports(path)
+		if err != nil {
+			return "", err
+		}
+		merged, _, err := merge(path, string(existing), src, exports)
+		if err != nil {
+			return "", err
+		}
+		current, _, err := merge(path, string(existing), string(existing), exports)
+		if err != nil {
+			return "", err
+		}
+		if merged != current {
+			return fileStale, nil
+		}
+	}
+	return "", nil
+}
+
+func (c *Client) reportOutput() io.Writer {
+	if c.opts.DryRunOutput != nil {
+		return c.opts.DryRunOutput
+	}
+	return os.Stdout
+}
+
+// Differs reports whether a DryRun found any generated file that is new or
+// differs from the existing file, even if it would not be overwritten, or
+// whether Check found any missing or stale file.
+func (c *Client) Differs() bool {
+	return c.differs
+}
@@ -0,0 +1,105 @@
+package codegen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteSrcFilesCheck(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	generated := GeneratedFiles{
+		"build.sh":            "#!/bin/bash -ex\n",
+		"main.go":             "package main\n\nfunc Greet(input string) string {\n\treturn \"\"\n}\n\nfunc main() {}\n",
+		"plugin-functions.go": "package main\n\n// new\n",
+		"types.go":            "package main\n\ntype Fruit string\n",
+		"xtp.toml":            "app_id = \"\"\n",
+	}
+
+	tests := []struct {
+		name     string
+		existing map[string]string
+		want     string
+	}{
+		{
+			name: "up to date",
+			existing: map[string]string{
+				"build.sh":            "#!/bin/bash -ex\ntinygo build\n",
+				"main.go":             "package main\n\n// Greet greets.\nfunc Greet(name string) string { return \"Hello, \" + name }\n\nfunc main() {}\n",
+				"plugin-functions.go": "package main\n\n// new\n",
+				"types.go":            "package main\n\ntype Fruit string\n",
+				"xtp.toml":            "app_id = \"app_123\"\n",
+			},
+		},
+		{
+			name: "missing and stale",
+			existing: map[string]string{
+				"main.go":             "package main\n\nfunc main() {}\n",
+				"plugin-functions.go": "package main\n\n// old\n",
+				"types.go":            "package main\n\ntype Fruit string\n",
+			},
+			want: `missing build.sh
+stale   main.go
+stale   plugin-functions.go
+missing xtp.toml
+`,
+		},
+		{
+			name: "changed export",
+			existing: map[string]string{
+				"build.sh":            "#!/bin/bash -ex\n",
+				"main.go":             "package main\n\nfunc Greet(input int) string { return \"\" }\n\nfunc main() {}\n",
+				"plugin-functions.go": "package main\n\n// new\n",
+				"types.go":            "package main\n\ntype Fruit int\n",
+				"xtp.toml":            "app_id = \"\"\n",
+			},
+			want: `stale   main.go
+stale   types.go
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(dir, filepath.Base(t.Name()))
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for name, src := range tt.existing {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var out bytes.Buffer
+			c := &Client{opts: ClientOpts{Check: true, Force: true, DryRunOutput: &out}}
+			if err := c.writeSrcFiles(dir, generated); err != nil {
+				t.Fatal(err)
+			}
+
+			got := strings.ReplaceAll(out.String(), dir+string(filepath.Separator), "")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Check output mismatch (-want +got):\n%v", diff)
+			}
+			if got, want := c.Differs(), tt.want != ""; got != want {
+				t.Errorf("Differs = %v, want %v", got, want)
+			}
+
+			for name, want := range tt.existing {
+				got, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != want {
+					t.Errorf("Check modified %v", name)
+				}
+			}
+		})
+	}
+}
@@ -16,11 +16,42 @@ var fruitYaml string
 //go:embed testdata/user.yaml
 var userYaml string
 
+//go:embed testdata/arrays.yaml
+var arraysYaml string
+
+//go:embed testdata/maps.yaml
+var mapsYaml string
+
+//go:embed testdata/buffers.yaml
+var buffersYaml string
+
+//go:embed testdata/primitives.yaml
+var primitivesYaml string
+
+//go:embed testdata/trees.yaml
+var treesYaml string
+
+//go:embed testdata/bounds.yaml
+var boundsYaml string
+
+//go:embed testdata/formats.yaml
+var formatsYaml string
+
+//go:embed testdata/defaults.yaml
+var defaultsYaml string
+
+//go:embed testdata/unions.yaml
+var unionsYaml string
+
+//go:embed testdata/fastjson.yaml
+var fastJSONYaml string
+
 type embedFSTest struct {
 	name        string
 	lang        string
 	pkgName     string
 	yamlStr     string
+	opts        *ClientOpts
 	files       []string
 	embedSubdir string
 	embedFS     embed.FS
@@ -56,7 +87,7 @@ func runEmbedFSTest(t *testing.T, tests []*embedFSTest) {
 			}
 
 			plugin.PkgName = tt.pkgName
-			c, err := New(tt.lang, plugin, nil)
+			c, err := New(tt.lang, plugin, tt.opts)
 			if err != nil {
 				t.Fatal(err)
 			}
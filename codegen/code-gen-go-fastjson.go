@@ -0,0 +1,1473 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+var (
+	enumFastJSONGoTemplate       = template.Must(template.New("code-gen-go-fastjson.go:enumFastJSONGoTemplateStr").Funcs(funcMap).Parse(enumFastJSONGoTemplateStr))
+	enumFastJSONTestGoTemplate   = template.Must(template.New("code-gen-go-fastjson.go:enumFastJSONTestGoTemplateStr").Funcs(funcMap).Parse(enumFastJSONTestGoTemplateStr))
+	structFastJSONGoTemplate     = template.Must(template.New("code-gen-go-fastjson.go:structFastJSONGoTemplateStr").Funcs(funcMap).Parse(structFastJSONGoTemplateStr))
+	binarySizeTestGoTemplate     = template.Must(template.New("code-gen-go-fastjson.go:binarySizeTestGoTemplateStr").Parse(binarySizeTestGoTemplateStr))
+	structFastJSONTestGoTemplate = template.Must(template.New("code-gen-go-fastjson.go:structFastJSONTestGoTemplateStr").Funcs(funcMap).Parse(structFastJSONTestGoTemplateStr))
+	unionFastJSONGoTemplate      = template.Must(template.New("code-gen-go-fastjson.go:unionFastJSONGoTemplateStr").Funcs(funcMap).Parse(unionFastJSONGoTemplateStr))
+)
+
+var enumFastJSONGoTemplateStr = `{{ $name := .Name }}
+// MarshalJSON implements json.Marshaler without reflection.
+func (v {{ $name }}) MarshalJSON() ([]byte, error) {
+	return v.appendJSON(nil)
+}
+
+// appendJSON appends the JSON encoding of the ` + "`" + `{{ $name }}` + "`" + ` to buf.
+func (v {{ $name }}) appendJSON(buf []byte) ([]byte, error) {
+	return appendJSONQuoted(buf, string(v)), nil
+}
+
+// decodeJSON decodes the ` + "`" + `{{ $name }}` + "`" + ` from d and rejects unknown values.
+func (v *{{ $name }}) decodeJSON(d *jsonDecoder) error {
+	if d.null() {
+		return nil
+	}
+	s, err := d.string()
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+`
+
+var enumFastJSONTestGoTemplateStr = `{{ $name := .Name }}
+func Fuzz{{ $name }}MarshalJSON(f *testing.F) {
+	f.Add("{{ index .Enum 0 }}")
+	f.Add("<not-a-{{ $name | downcaseFirst }}>")
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip() // encoding/json escapes invalid UTF-8 differently in different Go versions.
+		}
+		want, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := {{ $name }}(s).MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+`
+
+var structFastJSONGoTemplateStr = `{{ $name := .Name }}
+// MarshalJSON implements json.Marshaler without reflection.
+func (c {{ $name }}) MarshalJSON() ([]byte, error) {
+	return c.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection.
+func (c *{{ $name }}) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, c.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the ` + "`" + `{{ $name }}` + "`" + ` to buf.
+func (c *{{ $name }}) appendJSON(buf []byte) ([]byte, error) {
+	var err error
+	start := len(buf)
+	buf = append(buf, '{')
+{{range .Properties}}{{ goAppendJSONProperty . }}{{ end -}}
+{{ "	return append(buf, '}'), nil" }}
+}
+
+// decodeJSON decodes the ` + "`" + `{{ $name }}` + "`" + ` from d, skipping unknown properties.
+{{- if hasDefaults . }}
+// It starts from the default values of its schema, so that missing properties keep them.
+{{- end }}
+func (c *{{ $name }}) decodeJSON(d *jsonDecoder) error {
+{{ if hasDefaults . }}	*c = *New{{ $name }}()
+{{ end }}	return d.object(func(key []byte) error {
+		switch d.field(key, {{ goJSONFieldNames . }}) {
+{{range .Properties}}{{ goDecodeJSONProperty . }}{{ end -}}
+{{ "		}" }}
+		return d.skip()
+	})
+}
+`
+
+var structFastJSONTestGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}
+func Fuzz{{ $name }}JSON(f *testing.F) {
+	f.Add(` + "`" + `{{"{"}}{{range $index, $prop := .Properties}}{{if .IsRequired}}"{{ .Name }}":{{ requiredGoJSONValue . }}{{ showJSONCommaForRequired $index $top }}{{ end }}{{ end }}{{"}"}}` + "`" + `)
+	f.Add(` + "`" + `{{"{"}}{{ $propLen := .Properties | len }}{{range $index, $prop := .Properties}}"{{ .Name }}":{{ defaultGoJSONValue . $top }}{{ showJSONCommaForOptional $index $propLen }}{{ end }}{{"}"}}` + "`" + `)
+	f.Add("null")
+
+	// reflected{{ $name }} has no methods, so encoding/json uses reflection.
+	type reflected{{ $name }} {{ $name }}
+
+	f.Fuzz(func(t *testing.T, data string) {
+{{ if hasDefaults . }}		want := *New{{ $name }}()
+{{ else }}		var want {{ $name }}
+{{ end }}		wantErr := json.Unmarshal([]byte(data), (*reflected{{ $name }})(&want))
+		var got {{ $name }}
+		gotErr := got.UnmarshalJSON([]byte(data))
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("UnmarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("UnmarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+
+		wantBuf, wantErr := json.Marshal((*reflected{{ $name }})(&want))
+		gotBuf, gotErr := got.MarshalJSON()
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("MarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if diff := cmp.Diff(string(wantBuf), string(gotBuf)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+
+func Benchmark{{ $name }}JSON(b *testing.B) {
+	data := []byte(` + "`" + `{{"{"}}{{ $propLen := .Properties | len }}{{range $index, $prop := .Properties}}"{{ .Name }}":{{ defaultGoJSONValue . $top }}{{ showJSONCommaForOptional $index $propLen }}{{ end }}{{"}"}}` + "`" + `)
+	var obj {{ $name }}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		b.Fatal(err)
+	}
+
+	// reflected{{ $name }} has no methods, so encoding/json uses reflection.
+	type reflected{{ $name }} {{ $name }}
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := obj.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal((*reflected{{ $name }})(&obj)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v {{ $name }}
+			if err := v.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v reflected{{ $name }}
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
+
+// binarySizeTest is the data of binarySizeTestGoTemplate.
+type binarySizeTest struct {
+	// Name is the struct that is decoded and encoded by the programs.
+	Name string
+	// Filename is the file of the generated custom types.
+	Filename string
+}
+
+var binarySizeTestGoTemplateStr = `
+// TestBinarySize builds the same WebAssembly program, which decodes and
+// encodes a ` + "`{{ .Name }}`" + `, with the generated JSON codecs and with encoding/json,
+// and checks that avoiding the reflection of encoding/json makes it smaller.
+func TestBinarySize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the builds in short mode")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	src, err := os.ReadFile("{{ .Filename }}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both programs include the custom types, which only use the standard library.
+	_, types, _ := strings.Cut(string(src), "\npackage ")
+	types = "package main\n" + types[strings.Index(types, "\n"):]
+
+	programs := map[string]string{
+		"fastjson": ` + "`" + `package main
+
+import "os"
+
+func main() {
+	var v {{ .Name }}
+	if err := v.UnmarshalJSON([]byte(os.Args[1])); err != nil {
+		panic(err)
+	}
+	buf, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(buf)
+}
+` + "`" + `,
+		"encoding/json": ` + "`" + `package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// reflected has no methods, so encoding/json uses reflection.
+type reflected {{ .Name }}
+
+func main() {
+	var v reflected
+	if err := json.Unmarshal([]byte(os.Args[1]), &v); err != nil {
+		panic(err)
+	}
+	buf, err := json.Marshal(&v)
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(buf)
+}
+` + "`" + `,
+	}
+
+	sizes := map[string]int64{}
+	for name, program := range programs {
+		dir := t.TempDir()
+		for filename, src := range map[string]string{"main.go": program, "types.go": types} {
+			if err := os.WriteFile(filepath.Join(dir, filename), []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		cmd := exec.Command(goCmd, "build", "-o", "plugin.wasm", "main.go", "types.go")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "GOFLAGS=")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go build with %v: %v\n%s", name, err, out)
+		}
+		fi, err := os.Stat(filepath.Join(dir, "plugin.wasm"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes[name] = fi.Size()
+	}
+
+	t.Logf("binary size: %v bytes with the generated JSON codecs, %v bytes with encoding/json", sizes["fastjson"], sizes["encoding/json"])
+	if sizes["fastjson"] >= sizes["encoding/json"] {
+		t.Errorf("binary size with the generated JSON codecs = %v, want less than %v with encoding/json", sizes["fastjson"], sizes["encoding/json"])
+	}
+}
+`
+
+var unionFastJSONGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}{{ $key := .Discriminator.PropertyName }}
+// MarshalJSON implements json.Marshaler without reflection by adding the
+// "{{ $key }}" of the variant to its properties.
+func (v {{ $name }}) MarshalJSON() ([]byte, error) {
+	return v.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection by decoding
+// the variant that is selected by the "{{ $key }}" property.
+func (v *{{ $name }}) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, v.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the ` + "`" + `{{ $name }}` + "`" + ` to buf.
+func (v *{{ $name }}) appendJSON(buf []byte) ([]byte, error) {
+	var prefix string
+	var fields []byte
+	var err error
+	switch value := v.Value.(type) {
+{{range .Variants}}	case *{{ .Name }}:
+		prefix = {{ goUnionJSONPrefix $top . }}
+		fields, err = appendJSONPointer(nil, value, appendJSONValue[{{ .Name }}])
+{{ end -}}
+{{ "	default:" }}
+		return append(buf, "null"...), nil
+	}
+	if err != nil || string(fields) == "null" {
+		return append(buf, fields...), err
+	}
+
+	buf = append(buf, prefix...)
+	if len(fields) > 2 {
+		buf = append(buf, ',')
+	}
+	return append(buf, fields[1:]...), nil
+}
+
+// decodeJSON decodes the ` + "`" + `{{ $name }}` + "`" + ` from d by decoding the variant that is
+// selected by the "{{ $key }}" property.
+func (v *{{ $name }}) decodeJSON(d *jsonDecoder) error {
+	if d.null() {
+		return nil
+	}
+	start := d.pos
+	var discriminator *string
+	if err := d.object(func(key []byte) error {
+		if d.field(key, {{ printf "%q" $key }}) != {{ printf "%q" $key }} {
+			return d.skip()
+		}
+		return decodeJSONPointer(d, &discriminator, decodeJSONString)
+	}); err != nil {
+		return err
+	}
+	if discriminator == nil {
+		return errors.New(` + "`" + `{{ $name }}: missing "{{ $key }}"` + "`" + `)
+	}
+	d.pos = start
+
+	switch *discriminator {
+{{range .Variants}}	case {{ printf "%q" .Tag }}:
+		value := &{{ .Name }}{}
+		if err := value.decodeJSON(d); err != nil {
+			return err
+		}
+		v.Value = value
+{{ end -}}
+{{ "	default:" }}
+		return fmt.Errorf("{{ $name }}: unknown {{ $key }} %q", *discriminator)
+	}
+	return nil
+}
+`
+
+// goFastJSONFuncs are the helpers of the reflection-free JSON codecs that
+// are generated for Go types with the FastJSON option. Each helper is only
+// added to the generated code if one of its names is used, and the helpers
+// are listed before the helpers that they use.
+var goFastJSONFuncs = []struct {
+	names []string
+	src   string
+}{
+	{
+		names: []string{"appendJSONAny", "decodeJSONAny"},
+		src: `
+// appendJSONAny appends a value decoded by decodeJSONAny, or a number,
+// to buf as JSON.
+func appendJSONAny(buf []byte, v *any) ([]byte, error) {
+	switch v := (*v).(type) {
+	case nil:
+		return append(buf, "null"...), nil
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case string:
+		return appendJSONQuoted(buf, v), nil
+	case float64:
+		return appendJSONNumber(buf, v, 64)
+	case float32:
+		return appendJSONNumber(buf, float64(v), 32)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(buf, v, 10), nil
+	case []any:
+		return appendJSONArray(buf, v, appendJSONAny)
+	case map[string]any:
+		return appendJSONMap(buf, v, appendJSONAny)
+	}
+	return buf, errors.New("json: unsupported type in a free-form value")
+}
+
+// decodeJSONAny decodes any JSON value like encoding/json does into an interface value.
+func decodeJSONAny(d *jsonDecoder, v *any) error {
+	switch d.peek() {
+	case 'n':
+		if !d.null() {
+			return d.error("invalid literal")
+		}
+		*v = nil
+	case 't', 'f':
+		b, err := d.bool()
+		if err != nil {
+			return err
+		}
+		*v = b
+	case '"':
+		s, err := d.string()
+		if err != nil {
+			return err
+		}
+		*v = s
+	case '[':
+		var values []any
+		if err := decodeJSONArray(d, &values, decodeJSONAny); err != nil {
+			return err
+		}
+		*v = values
+	case '{':
+		var values map[string]any
+		if err := decodeJSONMap(d, &values, decodeJSONAny); err != nil {
+			return err
+		}
+		*v = values
+	default:
+		f, err := d.float(64)
+		if err != nil {
+			return err
+		}
+		*v = f
+	}
+	return nil
+}
+`,
+	},
+	{
+		names: []string{"appendJSONBytes", "decodeJSONBytes"},
+		src: `
+// appendJSONBytes appends a buffer to buf as a base64-encoded JSON string.
+func appendJSONBytes(buf []byte, v *[]byte) ([]byte, error) {
+	if *v == nil {
+		return append(buf, "null"...), nil
+	}
+	buf = append(buf, '"')
+	buf = base64.StdEncoding.AppendEncode(buf, *v)
+	return append(buf, '"'), nil
+}
+
+// decodeJSONBytes decodes a base64-encoded JSON string, or an array of bytes.
+func decodeJSONBytes(d *jsonDecoder, v *[]byte) error {
+	switch d.peek() {
+	case 'n':
+		if d.null() {
+			*v = nil
+			return nil
+		}
+	case '[':
+		return decodeJSONArray(d, v, decodeJSONInt[byte])
+	}
+	s, err := d.string()
+	if err != nil {
+		return err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*v = b
+	return nil
+}
+`,
+	},
+	{
+		names: []string{"appendJSONTime", "decodeJSONTime"},
+		src: `
+// appendJSONTime appends a date-time to buf as an RFC 3339 JSON string.
+func appendJSONTime(buf []byte, v *time.Time) ([]byte, error) {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return buf, err
+	}
+	return append(buf, b...), nil
+}
+
+// decodeJSONTime decodes an RFC 3339 JSON string like time.Time.UnmarshalJSON.
+func decodeJSONTime(d *jsonDecoder, v *time.Time) error {
+	raw, err := d.raw()
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalJSON(raw)
+}
+`,
+	},
+	{
+		names: []string{"appendJSONString", "decodeJSONString"},
+		src: `
+// appendJSONString appends a string to buf as JSON.
+func appendJSONString(buf []byte, v *string) ([]byte, error) {
+	return appendJSONQuoted(buf, *v), nil
+}
+
+// decodeJSONString decodes a JSON string. A null is ignored.
+func decodeJSONString(d *jsonDecoder, v *string) error {
+	if d.null() {
+		return nil
+	}
+	s, err := d.string()
+	if err != nil {
+		return err
+	}
+	*v = s
+	return nil
+}
+`,
+	},
+	{
+		names: []string{"appendJSONBool", "decodeJSONBool"},
+		src: `
+// appendJSONBool appends a boolean to buf as JSON.
+func appendJSONBool(buf []byte, v *bool) ([]byte, error) {
+	return strconv.AppendBool(buf, *v), nil
+}
+
+// decodeJSONBool decodes a JSON boolean. A null is ignored.
+func decodeJSONBool(d *jsonDecoder, v *bool) error {
+	if d.null() {
+		return nil
+	}
+	b, err := d.bool()
+	if err != nil {
+		return err
+	}
+	*v = b
+	return nil
+}
+`,
+	},
+	{
+		names: []string{"appendJSONFloat", "appendJSONNumber", "decodeJSONFloat"},
+		src: `
+// appendJSONFloat appends a number to buf as JSON.
+func appendJSONFloat[T float32 | float64](buf []byte, v *T) ([]byte, error) {
+	if _, ok := any(v).(*float32); ok {
+		return appendJSONNumber(buf, float64(*v), 32)
+	}
+	return appendJSONNumber(buf, float64(*v), 64)
+}
+
+// appendJSONNumber appends f to buf like encoding/json does: without an
+// exponent unless f is very small or very large.
+func appendJSONNumber(buf []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return buf, errors.New("json: unsupported value: " + strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	buf = strconv.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf, nil
+}
+
+// decodeJSONFloat decodes a JSON number. A null is ignored.
+func decodeJSONFloat[T float32 | float64](d *jsonDecoder, v *T) error {
+	if d.null() {
+		return nil
+	}
+	bits := 64
+	if _, ok := any(v).(*float32); ok {
+		bits = 32
+	}
+	f, err := d.float(bits)
+	if err != nil {
+		return err
+	}
+	*v = T(f)
+	return nil
+}
+`,
+	},
+	{
+		names: []string{"appendJSONInt", "decodeJSONInt"},
+		src: `
+// appendJSONInt appends an integer to buf as JSON.
+func appendJSONInt[T int | int32 | int64 | byte](buf []byte, v *T) ([]byte, error) {
+	return strconv.AppendInt(buf, int64(*v), 10), nil
+}
+
+// decodeJSONInt decodes a JSON integer. A null is ignored.
+func decodeJSONInt[T int | int32 | int64 | byte](d *jsonDecoder, v *T) error {
+	if d.null() {
+		return nil
+	}
+	s, err := d.number()
+	if err != nil {
+		return err
+	}
+	var n int64
+	switch any(v).(type) {
+	case *byte:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, 8)
+		n = int64(u)
+	case *int32:
+		n, err = strconv.ParseInt(s, 10, 32)
+	case *int64:
+		n, err = strconv.ParseInt(s, 10, 64)
+	default:
+		n, err = strconv.ParseInt(s, 10, strconv.IntSize)
+	}
+	if err != nil {
+		return d.error("cannot unmarshal number " + s + " into an integer")
+	}
+	*v = T(n)
+	return nil
+}
+`,
+	},
+	{
+		names: []string{"appendJSONMap", "decodeJSONMap"},
+		src: `
+// appendJSONMap appends a map to buf as a JSON object with sorted keys.
+func appendJSONMap[V any](buf []byte, m map[string]V, elem func([]byte, *V) ([]byte, error)) ([]byte, error) {
+	if m == nil {
+		return append(buf, "null"...), nil
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	buf = append(buf, '{')
+	for i, key := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONQuoted(buf, key)
+		buf = append(buf, ':')
+		value := m[key]
+		var err error
+		if buf, err = elem(buf, &value); err != nil {
+			return buf, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// appendJSONMapOf returns the appender of a map with elements appended by elem.
+func appendJSONMapOf[V any](elem func([]byte, *V) ([]byte, error)) func([]byte, *map[string]V) ([]byte, error) {
+	return func(buf []byte, m *map[string]V) ([]byte, error) {
+		return appendJSONMap(buf, *m, elem)
+	}
+}
+
+// decodeJSONMap decodes a JSON object into a map, adding to its existing
+// entries like encoding/json does. A null sets the map to nil.
+func decodeJSONMap[V any](d *jsonDecoder, m *map[string]V, elem func(*jsonDecoder, *V) error) error {
+	if d.null() {
+		*m = nil
+		return nil
+	}
+	if d.peek() != '{' {
+		return d.error("expected an object")
+	}
+	if *m == nil {
+		*m = map[string]V{}
+	}
+	return d.object(func(key []byte) error {
+		var value V
+		if err := elem(d, &value); err != nil {
+			return err
+		}
+		(*m)[string(key)] = value
+		return nil
+	})
+}
+
+// decodeJSONMapOf returns the decoder of a map with elements decoded by elem.
+func decodeJSONMapOf[V any](elem func(*jsonDecoder, *V) error) func(*jsonDecoder, *map[string]V) error {
+	return func(d *jsonDecoder, m *map[string]V) error {
+		return decodeJSONMap(d, m, elem)
+	}
+}
+`,
+	},
+	{
+		names: []string{"appendJSONArray", "decodeJSONArray"},
+		src: `
+// appendJSONArray appends a slice to buf as a JSON array.
+func appendJSONArray[T any](buf []byte, s []T, elem func([]byte, *T) ([]byte, error)) ([]byte, error) {
+	if s == nil {
+		return append(buf, "null"...), nil
+	}
+	buf = append(buf, '[')
+	for i := range s {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = elem(buf, &s[i]); err != nil {
+			return buf, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+// appendJSONArrayOf returns the appender of a slice with elements appended by elem.
+func appendJSONArrayOf[T any](elem func([]byte, *T) ([]byte, error)) func([]byte, *[]T) ([]byte, error) {
+	return func(buf []byte, s *[]T) ([]byte, error) {
+		return appendJSONArray(buf, *s, elem)
+	}
+}
+
+// decodeJSONArray decodes a JSON array into a slice, reusing its elements
+// like encoding/json does. A null sets the slice to nil.
+func decodeJSONArray[T any](d *jsonDecoder, s *[]T, elem func(*jsonDecoder, *T) error) error {
+	if d.null() {
+		*s = nil
+		return nil
+	}
+	if d.peek() != '[' {
+		return d.error("expected an array")
+	}
+	values, i := *s, 0
+	err := d.array(func() error {
+		if i >= len(values) {
+			if i < cap(values) {
+				values = values[:i+1]
+			} else {
+				var zero T
+				values = append(values, zero)
+			}
+		}
+		i++
+		return elem(d, &values[i-1])
+	})
+	if i == 0 {
+		values = []T{}
+	}
+	*s = values[:i]
+	return err
+}
+
+// decodeJSONArrayOf returns the decoder of a slice with elements decoded by elem.
+func decodeJSONArrayOf[T any](elem func(*jsonDecoder, *T) error) func(*jsonDecoder, *[]T) error {
+	return func(d *jsonDecoder, s *[]T) error {
+		return decodeJSONArray(d, s, elem)
+	}
+}
+`,
+	},
+}
+
+// goFastJSONCore is the part of the reflection-free JSON codecs that is
+// always added to the generated code.
+var goFastJSONCore = `
+// jsonCodec is implemented by the generated structs and enums.
+type jsonCodec[T any] interface {
+	*T
+	appendJSON(buf []byte) ([]byte, error)
+	decodeJSON(d *jsonDecoder) error
+}
+
+// appendJSONValue appends a generated struct or enum to buf as JSON.
+func appendJSONValue[T any, P jsonCodec[T]](buf []byte, v *T) ([]byte, error) {
+	return P(v).appendJSON(buf)
+}
+
+// decodeJSONValue decodes a generated struct or enum.
+func decodeJSONValue[T any, P jsonCodec[T]](d *jsonDecoder, v *T) error {
+	return P(v).decodeJSON(d)
+}
+
+// appendJSONPointer appends the value that v points to, or null.
+func appendJSONPointer[T any](buf []byte, v *T, elem func([]byte, *T) ([]byte, error)) ([]byte, error) {
+	if v == nil {
+		return append(buf, "null"...), nil
+	}
+	return elem(buf, v)
+}
+
+// decodeJSONPointer decodes into the value that p points to, allocating it
+// if needed. A null sets p to nil.
+func decodeJSONPointer[T any](d *jsonDecoder, p **T, elem func(*jsonDecoder, *T) error) error {
+	if d.null() {
+		*p = nil
+		return nil
+	}
+	if *p == nil {
+		*p = new(T)
+	}
+	return elem(d, *p)
+}
+
+// appendJSONField appends the separator and the quoted key of the next
+// property of the object that starts at buf[start].
+func appendJSONField(buf []byte, start int, key string) []byte {
+	if len(buf) > start+1 {
+		buf = append(buf, ',')
+	}
+	return append(buf, key...)
+}
+
+// appendJSONQuoted appends s to buf as a JSON string, escaped like encoding/json
+// does, including the HTML characters <, > and &.
+func appendJSONQuoted(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				buf = append(buf, '\\', b)
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if c == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if c == '\u2028' || c == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hex[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
+
+// maxJSONDepth is the maximum nesting of arrays and objects, as in encoding/json.
+const maxJSONDepth = 10000
+
+// jsonDecoder decodes JSON without reflection, following the rules of
+// encoding/json for the generated UnmarshalJSON methods.
+type jsonDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// unmarshalJSON decodes all of data with decode.
+func unmarshalJSON(data []byte, decode func(d *jsonDecoder) error) error {
+	d := &jsonDecoder{data: data}
+	if err := decode(d); err != nil {
+		return err
+	}
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return d.error("invalid character after top-level value")
+	}
+	return nil
+}
+
+// error returns an error that reports the current offset.
+func (d *jsonDecoder) error(msg string) error {
+	return errors.New("json: " + msg + " at offset " + strconv.Itoa(d.pos))
+}
+
+// skipSpace skips the whitespace before the next token.
+func (d *jsonDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the first byte of the next token, or 0 at the end of the data.
+func (d *jsonDecoder) peek() byte {
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return d.data[d.pos]
+	}
+	return 0
+}
+
+// literal consumes the next token if it is lit.
+func (d *jsonDecoder) literal(lit string) bool {
+	if d.skipSpace(); len(d.data)-d.pos >= len(lit) && string(d.data[d.pos:d.pos+len(lit)]) == lit {
+		d.pos += len(lit)
+		return true
+	}
+	return false
+}
+
+// null consumes the next token if it is null.
+func (d *jsonDecoder) null() bool {
+	return d.literal("null")
+}
+
+// bool decodes a boolean.
+func (d *jsonDecoder) bool() (bool, error) {
+	switch {
+	case d.literal("true"):
+		return true, nil
+	case d.literal("false"):
+		return false, nil
+	}
+	return false, d.error("expected a boolean")
+}
+
+// number returns the next number, which is checked against the JSON grammar.
+func (d *jsonDecoder) number() (string, error) {
+	d.skipSpace()
+	start := d.pos
+	if d.pos < len(d.data) && d.data[d.pos] == '-' {
+		d.pos++
+	}
+	switch {
+	case d.pos < len(d.data) && d.data[d.pos] == '0':
+		d.pos++
+	case !d.digits():
+		return "", d.error("expected a number")
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == '.' {
+		d.pos++
+		if !d.digits() {
+			return "", d.error("invalid number")
+		}
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		d.pos++
+		if d.pos < len(d.data) && (d.data[d.pos] == '+' || d.data[d.pos] == '-') {
+			d.pos++
+		}
+		if !d.digits() {
+			return "", d.error("invalid number")
+		}
+	}
+	return string(d.data[start:d.pos]), nil
+}
+
+// digits consumes a run of digits and reports whether there were any.
+func (d *jsonDecoder) digits() bool {
+	start := d.pos
+	for d.pos < len(d.data) && '0' <= d.data[d.pos] && d.data[d.pos] <= '9' {
+		d.pos++
+	}
+	return d.pos > start
+}
+
+// float decodes a number with the given precision.
+func (d *jsonDecoder) float(bits int) (float64, error) {
+	s, err := d.number()
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		return 0, d.error("cannot unmarshal number " + s + " into a float" + strconv.Itoa(bits))
+	}
+	return f, nil
+}
+
+// string decodes a string, replacing invalid UTF-8 and unpaired surrogates
+// with U+FFFD like encoding/json does.
+func (d *jsonDecoder) string() (string, error) {
+	b, err := d.stringBytes()
+	return string(b), err
+}
+
+// stringBytes decodes a string like string does, without copying a string
+// that has nothing to unescape.
+func (d *jsonDecoder) stringBytes() ([]byte, error) {
+	if d.peek() != '"' {
+		return nil, d.error("expected a string")
+	}
+	d.pos++
+	start := d.pos
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		if c == '"' {
+			d.pos++
+			return d.data[start : d.pos-1], nil
+		}
+		if c == '\\' || c < ' ' {
+			break
+		}
+		if c < utf8.RuneSelf {
+			d.pos++
+			continue
+		}
+		r, size := utf8.DecodeRune(d.data[d.pos:])
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+		d.pos += size
+	}
+
+	buf := append([]byte(nil), d.data[start:d.pos]...)
+	for d.pos < len(d.data) {
+		switch c := d.data[d.pos]; {
+		case c == '"':
+			d.pos++
+			return buf, nil
+		case c < ' ':
+			return nil, d.error("invalid character in string")
+		case c == '\\':
+			if d.pos+1 >= len(d.data) {
+				return nil, d.error("unexpected end of string")
+			}
+			switch e := d.data[d.pos+1]; e {
+			case '"', '\\', '/':
+				buf = append(buf, e)
+			case 'b':
+				buf = append(buf, '\b')
+			case 'f':
+				buf = append(buf, '\f')
+			case 'n':
+				buf = append(buf, '\n')
+			case 'r':
+				buf = append(buf, '\r')
+			case 't':
+				buf = append(buf, '\t')
+			case 'u':
+				r := d.hex4(d.pos + 2)
+				if r < 0 {
+					return nil, d.error("invalid escape in string")
+				}
+				d.pos += 6
+				if utf16.IsSurrogate(r) {
+					r2 := rune(-1)
+					if d.pos+1 < len(d.data) && d.data[d.pos] == '\\' && d.data[d.pos+1] == 'u' {
+						r2 = d.hex4(d.pos + 2)
+					}
+					if r = utf16.DecodeRune(r, r2); r != utf8.RuneError {
+						d.pos += 6
+					}
+				}
+				buf = utf8.AppendRune(buf, r)
+				continue
+			default:
+				return nil, d.error("invalid escape in string")
+			}
+			d.pos += 2
+		case c < utf8.RuneSelf:
+			buf = append(buf, c)
+			d.pos++
+		default:
+			r, size := utf8.DecodeRune(d.data[d.pos:])
+			buf = utf8.AppendRune(buf, r)
+			d.pos += size
+		}
+	}
+	return nil, d.error("unexpected end of string")
+}
+
+// hex4 returns the value of the 4 hex digits at data[pos:], or -1.
+func (d *jsonDecoder) hex4(pos int) rune {
+	if pos+4 > len(d.data) {
+		return -1
+	}
+	var r rune
+	for _, c := range d.data[pos : pos+4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return -1
+		}
+		r = r*16 + rune(c)
+	}
+	return r
+}
+
+// object decodes an object, calling value to decode the value of each key.
+// A null is ignored like encoding/json does for structs.
+func (d *jsonDecoder) object(value func(key []byte) error) error {
+	if d.null() {
+		return nil
+	}
+	if d.peek() != '{' {
+		return d.error("expected an object")
+	}
+	return d.nested('}', func() error {
+		key, err := d.stringBytes()
+		if err != nil {
+			return err
+		}
+		if d.peek() != ':' {
+			return d.error("expected a colon after an object key")
+		}
+		d.pos++
+		return value(key)
+	})
+}
+
+// array decodes an array, calling value to decode each of its elements.
+func (d *jsonDecoder) array(value func() error) error {
+	if d.peek() != '[' {
+		return d.error("expected an array")
+	}
+	return d.nested(']', value)
+}
+
+// nested decodes the comma-separated items of the array or object that
+// starts at the current byte and ends with end.
+func (d *jsonDecoder) nested(end byte, item func() error) error {
+	if d.depth++; d.depth > maxJSONDepth {
+		return d.error("exceeded max depth")
+	}
+	d.pos++
+	if d.peek() == end {
+		d.pos++
+		d.depth--
+		return nil
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case end:
+			d.pos++
+			d.depth--
+			return nil
+		default:
+			return d.error("expected a comma or the end of an array or object")
+		}
+	}
+}
+
+// field returns the one of names that matches key exactly, or else
+// case-insensitively like encoding/json does, or else "".
+func (d *jsonDecoder) field(key []byte, names ...string) string {
+	for _, name := range names {
+		if string(key) == name {
+			return name
+		}
+	}
+	for _, name := range names {
+		if strings.EqualFold(string(key), name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// raw returns the next value undecoded.
+func (d *jsonDecoder) raw() ([]byte, error) {
+	d.skipSpace()
+	start := d.pos
+	if err := d.skip(); err != nil {
+		return nil, err
+	}
+	return d.data[start:d.pos], nil
+}
+
+// skip skips the next value, checking it against the JSON grammar.
+func (d *jsonDecoder) skip() error {
+	switch d.peek() {
+	case '{':
+		return d.object(func([]byte) error { return d.skip() })
+	case '[':
+		return d.array(d.skip)
+	case '"':
+		_, err := d.string()
+		return err
+	case 't', 'f':
+		_, err := d.bool()
+		return err
+	case 'n':
+		if d.null() {
+			return nil
+		}
+		return d.error("invalid literal")
+	}
+	_, err := d.number()
+	return err
+}
+`
+
+// goFastJSONImports are the packages imported by the generated code with
+// the FastJSON option, each with an identifier that shows it is used.
+var goFastJSONImports = []struct{ path, use string }{
+	{path: "encoding/base64", use: "base64.StdEncoding"},
+	{path: "errors", use: "errors.New"},
+	{path: "fmt", use: "fmt.Errorf"},
+	{path: "math", use: "math.IsInf"},
+	{path: "sort", use: "sort.Strings"},
+	{path: "strconv", use: "strconv."},
+	{path: "strings", use: "strings.EqualFold"},
+	{path: "time", use: "time.Time"},
+	{path: "unicode/utf16", use: "utf16."},
+	{path: "unicode/utf8", use: "utf8."},
+}
+
+// addGoFastJSONFuncs adds the JSON helpers that are used by src or by the
+// codecs of the host and plugin wrappers, and the imports that they all
+// need, to src.
+func addGoFastJSONFuncs(src, codecs string) string {
+	var funcs string
+	for _, f := range goFastJSONFuncs {
+		for _, name := range f.names {
+			if strings.Contains(src, name) || strings.Contains(codecs, name) || strings.Contains(funcs, name) {
+				funcs += f.src
+				break
+			}
+		}
+	}
+	src += funcs + goFastJSONCore
+
+	var imports []string
+	for _, imp := range goFastJSONImports {
+		if strings.Contains(src, imp.use) {
+			imports = append(imports, fmt.Sprintf("\t%q\n", imp.path))
+		}
+	}
+	return "import (\n" + strings.Join(imports, "") + ")\n\n" + src
+}
+
+// goFastJSON reports whether the Go code is generated with the FastJSON
+// option.
+func goFastJSON(c *Client) bool {
+	return c.opts.FastJSON
+}
+
+// goIsGeneratedType reports whether goType is a generated struct, enum or
+// union, which has MarshalJSON and UnmarshalJSON methods.
+func goIsGeneratedType(goType string) bool {
+	return goJSONScalarKind(goType) == "" && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[")
+}
+
+// goMarshalJSON returns the Go call that encodes the variable v of goType
+// as JSON: json.Marshal, or with FastJSON, the MarshalJSON method of a
+// generated type or the JSON helpers of the custom types.
+func goMarshalJSON(c *Client, goType, v string) string {
+	switch {
+	case !c.opts.FastJSON:
+		return fmt.Sprintf("json.Marshal(%v)", v)
+	case goIsGeneratedType(goType):
+		return v + ".MarshalJSON()"
+	}
+	return fmt.Sprintf("%v(nil, &%v)", goJSONAppender(goType), v)
+}
+
+// goUnmarshalJSON returns the Go call that decodes the JSON data into the
+// variable v of goType, like goMarshalJSON.
+func goUnmarshalJSON(c *Client, goType, data, v string) string {
+	switch {
+	case !c.opts.FastJSON:
+		return fmt.Sprintf("json.Unmarshal(%v, &%v)", data, v)
+	case goIsGeneratedType(goType):
+		return fmt.Sprintf("%v.UnmarshalJSON(%v)", v, data)
+	}
+	return fmt.Sprintf("unmarshalJSON(%v, func(d *jsonDecoder) error { return %v(d, &%v) })", data, goJSONDecoder(goType), v)
+}
+
+// goWrapperFastJSONCodecs returns the calls of goMarshalJSON and
+// goUnmarshalJSON in the host and plugin wrappers of the exports and imports.
+func goWrapperFastJSONCodecs(c *Client) string {
+	var codecs []string
+	add := func(goType string) {
+		codecs = append(codecs, goMarshalJSON(c, goType, "v"), goUnmarshalJSON(c, goType, "data", "v"))
+	}
+	for _, export := range c.Plugin.Exports {
+		if inputIsJSON(export.Input) {
+			add(inputToGoTypeName(export.Input))
+		}
+		if outputIsJSON(export.Output) {
+			add(outputToGoType(export.Output))
+		}
+	}
+	for _, imp := range c.Plugin.Imports {
+		if inputIsJSON(imp.Input) {
+			add(inputToGoTypeName(imp.Input))
+		}
+		if outputIsJSON(imp.Output) {
+			add(outputToGoType(imp.Output))
+		}
+	}
+	return strings.Join(codecs, "\n")
+}
+
+// goAppendJSONProperty returns the Go statements that append the property
+// of a struct named `c` to the JSON object that starts at buf[start].
+// An optional property is omitted when empty, like with "omitempty".
+func goAppendJSONProperty(prop *schema.Property) string {
+	goType := getGoType(prop)
+	field := "c." + uppercaseFirst(prop.Name)
+
+	var call, present string
+	switch {
+	case strings.HasPrefix(goType, "*") && prop.IsRequired:
+		call = fmt.Sprintf("appendJSONPointer(buf, %v, %v)", field, goJSONAppender(goType[1:]))
+	case strings.HasPrefix(goType, "*"):
+		call, present = goAppendJSONValue(goType[1:], field), field+" != nil"
+	case strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map["):
+		call, present = goAppendJSONValue(goType, "&"+field), "len("+field+") > 0"
+	default:
+		call, present = goAppendJSONValue(goType, "&"+field), field+` != ""`
+	}
+
+	stmts := fmt.Sprintf("buf = appendJSONField(buf, start, %v)\nif buf, err = %v; err != nil {\nreturn buf, err\n}\n", goStringLiteral(goJSONKey(prop.Name)+":"), call)
+	if prop.IsRequired {
+		return stmts
+	}
+	return fmt.Sprintf("if %v {\n%v}\n", present, stmts)
+}
+
+// goAppendJSONValue returns the Go call that appends the value of goType
+// that ptr points to.
+func goAppendJSONValue(goType, ptr string) string {
+	value := "*" + ptr
+	if strings.HasPrefix(ptr, "&") {
+		value = ptr[1:]
+	}
+	switch {
+	case goType == "[]byte":
+		return fmt.Sprintf("appendJSONBytes(buf, %v)", ptr)
+	case strings.HasPrefix(goType, "[]"):
+		return fmt.Sprintf("appendJSONArray(buf, %v, %v)", value, goJSONAppender(goType[2:]))
+	case strings.HasPrefix(goType, "map[string]"):
+		return fmt.Sprintf("appendJSONMap(buf, %v, %v)", value, goJSONAppender(goType[len("map[string]"):]))
+	}
+	if kind := goJSONScalarKind(goType); kind != "" {
+		return fmt.Sprintf("appendJSON%v(buf, %v)", kind, ptr)
+	}
+	return strings.TrimPrefix(ptr, "&") + ".appendJSON(buf)"
+}
+
+// goJSONAppender returns the Go function that appends an element of goType.
+func goJSONAppender(goType string) string {
+	switch {
+	case goType == "[]byte":
+		return "appendJSONBytes"
+	case strings.HasPrefix(goType, "[]"):
+		return "appendJSONArrayOf(" + goJSONAppender(goType[2:]) + ")"
+	case strings.HasPrefix(goType, "map[string]"):
+		return "appendJSONMapOf(" + goJSONAppender(goType[len("map[string]"):]) + ")"
+	}
+	switch kind := goJSONScalarKind(goType); kind {
+	case "":
+		return "appendJSONValue[" + goType + "]"
+	case "Int", "Float":
+		return "appendJSON" + kind + "[" + goType + "]"
+	default:
+		return "appendJSON" + kind
+	}
+}
+
+// goDecodeJSONProperty returns the Go case that decodes the property of a
+// struct named `c`.
+func goDecodeJSONProperty(prop *schema.Property) string {
+	goType := getGoType(prop)
+	field := "c." + uppercaseFirst(prop.Name)
+
+	var call string
+	switch {
+	case strings.HasPrefix(goType, "*"):
+		call = fmt.Sprintf("decodeJSONPointer(d, &%v, %v)", field, goJSONDecoder(goType[1:]))
+	case goType == "[]byte":
+		call = fmt.Sprintf("decodeJSONBytes(d, &%v)", field)
+	case strings.HasPrefix(goType, "[]"):
+		call = fmt.Sprintf("decodeJSONArray(d, &%v, %v)", field, goJSONDecoder(goType[2:]))
+	case strings.HasPrefix(goType, "map[string]"):
+		call = fmt.Sprintf("decodeJSONMap(d, &%v, %v)", field, goJSONDecoder(goType[len("map[string]"):]))
+	default:
+		if kind := goJSONScalarKind(goType); kind != "" {
+			call = fmt.Sprintf("decodeJSON%v(d, &%v)", kind, field)
+		} else {
+			call = field + ".decodeJSON(d)"
+		}
+	}
+	return fmt.Sprintf("case %q:\nreturn %v\n", prop.Name, call)
+}
+
+// goJSONDecoder returns the Go function that decodes an element of goType.
+func goJSONDecoder(goType string) string {
+	switch {
+	case goType == "[]byte":
+		return "decodeJSONBytes"
+	case strings.HasPrefix(goType, "[]"):
+		return "decodeJSONArrayOf(" + goJSONDecoder(goType[2:]) + ")"
+	case strings.HasPrefix(goType, "map[string]"):
+		return "decodeJSONMapOf(" + goJSONDecoder(goType[len("map[string]"):]) + ")"
+	}
+	switch kind := goJSONScalarKind(goType); kind {
+	case "":
+		return "decodeJSONValue[" + goType + "]"
+	case "Int", "Float":
+		return "decodeJSON" + kind + "[" + goType + "]"
+	default:
+		return "decodeJSON" + kind
+	}
+}
+
+// goJSONScalarKind returns the suffix of the JSON helpers of the predeclared
+// or standard library goType, or "" for a generated struct or enum.
+func goJSONScalarKind(goType string) string {
+	switch goType {
+	case "int", "int32", "int64":
+		return "Int"
+	case "float32", "float64":
+		return "Float"
+	case "bool":
+		return "Bool"
+	case "string":
+		return "String"
+	case "time.Time":
+		return "Time"
+	case "any":
+		return "Any"
+	}
+	return ""
+}
+
+// goJSONFieldNames returns the quoted names of the properties of the struct
+// as Go arguments, e.g. ` + "`" + `"id", "name"` + "`" + `.
+func goJSONFieldNames(ct *schema.CustomType) string {
+	names := make([]string, 0, len(ct.Properties))
+	for _, prop := range ct.Properties {
+		names = append(names, fmt.Sprintf("%q", prop.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+// goJSONKey returns the name of the property as a JSON string.
+func goJSONKey(name string) string {
+	key, _ := json.Marshal(name)
+	return string(key)
+}
@@ -0,0 +1,112 @@
+package codegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+// fastJSONSizeMainGo decodes and encodes a Measurement of fastjson.yaml with
+// the generated MarshalJSON method, so that encoding/json is not linked in.
+const fastJSONSizeMainGo = `package main
+
+import "os"
+
+func main() {
+	value, err := ParseMeasurement(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	buf, err := value.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(buf)
+}
+`
+
+// reflectJSONSizeMainGo is fastJSONSizeMainGo using encoding/json.
+const reflectJSONSizeMainGo = `package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+func main() {
+	value, err := ParseMeasurement(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	buf, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(buf)
+}
+`
+
+func TestGoFastJSONBinarySize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping WebAssembly builds in short mode")
+	}
+
+	reflectSize := buildGoTypesWasm(t, reflectJSONSizeMainGo, nil)
+	fastSize := buildGoTypesWasm(t, fastJSONSizeMainGo, &ClientOpts{FastJSON: true})
+	t.Logf("encoding/json: %v bytes, FastJSON: %v bytes (%.0f%%)", reflectSize, fastSize, 100*float64(fastSize)/float64(reflectSize))
+
+	if fastSize >= reflectSize {
+		t.Errorf("FastJSON binary is %v bytes, want less than the %v bytes with encoding/json", fastSize, reflectSize)
+	}
+}
+
+// buildGoTypesWasm builds the Go types of fastjson.yaml with mainGo as a
+// WebAssembly program, with TinyGo if it is installed, and returns its size.
+func buildGoTypesWasm(t *testing.T, mainGo string, opts *ClientOpts) int64 {
+	t.Helper()
+
+	plugin, err := schema.ParseStr(fastJSONYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin.PkgName = "main"
+	c, err := New("go", plugin, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := c.GenCustomTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"go.mod":   "module sizetest\n\ngo 1.22\n",
+		"types.go": files[c.CustTypesFilename],
+		"main.go":  mainGo,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := filepath.Join(dir, "main.wasm")
+	cmd := exec.Command("go", "build", "-o", out, ".")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "GOFLAGS=")
+	if _, err := exec.LookPath("tinygo"); err == nil {
+		cmd = exec.Command("tinygo", "build", "-o", out, "-target=wasi", ".")
+	}
+	cmd.Dir = dir
+	if buf, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v: %v\n%s", cmd, err, buf)
+	}
+
+	fi, err := os.Stat(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fi.Size()
+}
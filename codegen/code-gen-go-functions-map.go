@@ -1,8 +1,10 @@
 package codegen
 
 import (
+	"encoding/base64"
 	"fmt"
-	"log"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/gmlewis/go-xtp/schema"
@@ -10,38 +12,53 @@ import (
 
 func defaultGoJSONValue(prop *schema.Property, ct *schema.CustomType) string {
 	if prop.Ref != "" {
-		if !prop.IsRequired && prop.RefCustomType != nil {
-			// populate all the required fields recursively:
-			requiredProps := prop.RefCustomType.GetRequiredProps()
-			fields := make([]string, 0, len(requiredProps))
-			for _, p2 := range requiredProps {
-				fields = append(fields, fmt.Sprintf("%q:%v", p2.Name, defaultGoJSONValue(p2, prop.RefCustomType)))
-			}
-			return fmt.Sprintf("{%v}", strings.Join(fields, ","))
+		switch {
+		case prop.RefCustomType != nil && prop.IsRequired:
+			return "null" // a required struct is not populated by the "optional fields" test.
+		case prop.RefCustomType != nil:
+			// Only the zero value of the struct is populated so that recursive types terminate.
+			return zeroGoStructJSONValue(prop.RefCustomType)
 		}
-		return `""`
+		return fmt.Sprintf("%q", prop.FirstEnumValue) // see defaultGoValue.
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
-		if !prop.IsRequired {
+		switch {
+		case prop.Format == "date-time" && !prop.IsRequired:
+			return goTimeExampleJSON
+		case prop.Format == "date-time":
+			return goZeroTimeJSON
+		case !prop.IsRequired:
 			return fmt.Sprintf("%q", prop.Name)
 		}
 		return `""`
 	case "number":
-		return "0.0"
+		return "0"
 	case "boolean":
 		return "false"
 	case "object":
-		return "{}"
+		if prop.IsRequired {
+			return "null" // a required map is not populated by the "optional fields" test.
+		}
+		_, v := goMapExampleValue(prop.AdditionalProperties)
+		return v
 	case "array":
-		return "[]"
+		if prop.IsRequired {
+			return "null" // a required slice is not populated by the "optional fields" test.
+		}
+		_, v := goItemsExampleValue(prop.Items)
+		return v
 	case "buffer":
-		return `""`
+		if prop.IsRequired {
+			return "null" // a required buffer is not populated by the "optional fields" test.
+		}
+		_, v := goBufferExampleValue(prop.Name)
+		return v
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
@@ -51,27 +68,42 @@ func defaultGoValue(prop *schema.Property) string {
 		parts := strings.Split(prop.Ref, "/")
 		refName := parts[len(parts)-1]
 		if !prop.IsRequired && prop.RefCustomType != nil {
-			return fmt.Sprintf("&%v{}", refName)
+			return zeroGoStructPtr(prop.RefCustomType)
 		}
-		return `""`
+		// An empty enum is not valid JSON for its type.
+		return fmt.Sprintf("%vEnum%v", uppercaseFirst(refName), uppercaseFirst(prop.FirstEnumValue))
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "boolean":
 		if !prop.IsRequired {
 			return "boolPtr(false)"
 		}
 		return "false"
-	case "integer":
+	case "integer", "number":
 		if !prop.IsRequired {
-			return "intPtr(0)"
+			return goScalarType(prop) + "Ptr(0)"
 		}
 		return "0"
 	case "string":
-		if !prop.IsRequired {
+		switch {
+		case prop.Format == "date-time" && !prop.IsRequired:
+			return "timePtr(" + goTimeExample + ")"
+		case prop.Format == "date-time":
+			return "time.Time{}"
+		case !prop.IsRequired:
 			return fmt.Sprintf("stringPtr(%q)", prop.Name)
 		}
 		return `""`
+	case "object":
+		v, _ := goMapExampleValue(prop.AdditionalProperties)
+		return v
+	case "array":
+		v, _ := goItemsExampleValue(prop.Items)
+		return v
+	case "buffer":
+		v, _ := goBufferExampleValue(prop.Name)
+		return v
 	default:
 		return `""`
 	}
@@ -92,27 +124,216 @@ func getGoType(prop *schema.Property) string {
 		asterisk = "*"
 	}
 
-	switch prop.Type {
-	case "integer":
-		return asterisk + "int"
-	case "string":
-		return asterisk + "string"
-	case "number":
-		return asterisk + "float64"
-	case "boolean":
-		return asterisk + "bool"
+	switch propType(prop) {
+	case "integer", "string", "number", "boolean":
+		return asterisk + goScalarType(prop)
 	case "object":
-		return asterisk + "{}" // TODO - what should this be?
+		return "map[string]" + getGoMapValueType(prop.AdditionalProperties) // a nil map represents a missing optional object.
 	case "array":
-		return asterisk + "[]" // TODO - what should this be?
+		warnMissingItems(prop.Pos, prop.Items)
+		return "[]" + getGoItemsType(prop.Items) // a nil slice represents a missing optional array.
 	case "buffer":
-		return asterisk + "buffer" // TODO - what should this be?
+		return "[]byte" // a nil slice represents a missing optional buffer.
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return asterisk + prop.Type
 	}
 }
 
+// goScalarType returns the Go type of an integer, string, number or boolean
+// property without its pointer, as selected by its `format`.
+func goScalarType(prop *schema.Property) string {
+	switch prop.Type {
+	case "integer":
+		switch prop.Format {
+		case "int32", "int64":
+			return prop.Format
+		}
+		return "int"
+	case "string":
+		if prop.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "number":
+		if prop.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	}
+	return "bool"
+}
+
+// getGoItemsType returns the Go type of the elements of an array,
+// which is `any` if the array is missing its items.
+func getGoItemsType(items *schema.Property) string {
+	if items == nil {
+		return "any"
+	}
+
+	if items.Ref != "" {
+		parts := strings.Split(items.Ref, "/")
+		return parts[len(parts)-1]
+	}
+
+	return getGoType(items)
+}
+
+// getGoMapValueType returns the Go type of the values of a map.
+// An object without `additionalProperties` is a free-form map.
+func getGoMapValueType(values *schema.Property) string {
+	if values == nil {
+		return "any"
+	}
+	return getGoItemsType(values)
+}
+
+// goItemsExampleValue returns an example array containing a single element
+// as both a Go literal and its JSON encoding.
+func goItemsExampleValue(items *schema.Property) (goValue, jsonValue string) {
+	goElem, jsonElem := goElemExampleValue(items)
+	return fmt.Sprintf("[]%v{%v}", getGoItemsType(items), goElem), fmt.Sprintf("[%v]", jsonElem)
+}
+
+// goMapExampleValue returns an example map containing a single entry
+// as both a Go literal and its JSON encoding.
+func goMapExampleValue(values *schema.Property) (goValue, jsonValue string) {
+	goElem, jsonElem := goElemExampleValue(values)
+	return fmt.Sprintf(`map[string]%v{"key": %v}`, getGoMapValueType(values), goElem), fmt.Sprintf(`{"key":%v}`, jsonElem)
+}
+
+// goElemExampleValue returns an example array element or map value
+// as both a Go literal and its JSON encoding.
+func goElemExampleValue(elem *schema.Property) (goValue, jsonValue string) {
+	switch {
+	case elem == nil:
+		return `"item"`, `"item"`
+	case elem.Ref != "" && elem.RefCustomType != nil:
+		return zeroGoStructLiteral(elem.RefCustomType), zeroGoStructJSONValue(elem.RefCustomType)
+	case elem.Ref != "":
+		return fmt.Sprintf("%vEnum%v", getGoItemsType(elem), uppercaseFirst(elem.FirstEnumValue)), fmt.Sprintf("%q", elem.FirstEnumValue)
+	case propType(elem) == "array":
+		return goItemsExampleValue(elem.Items)
+	case propType(elem) == "object":
+		return goMapExampleValue(elem.AdditionalProperties)
+	case propType(elem) == "integer":
+		return "1", "1"
+	case propType(elem) == "number":
+		return "1.5", "1.5"
+	case propType(elem) == "boolean":
+		return "true", "true"
+	case propType(elem) == "string" && elem.Format == "date-time":
+		return goTimeExample, goTimeExampleJSON
+	case propType(elem) == "string":
+		return `"item"`, `"item"`
+	case propType(elem) == "buffer":
+		return goBufferExampleValue("item")
+	default:
+		warnf(elem.Pos, "unknown element type %q", elem.Type)
+		return `"item"`, `"item"`
+	}
+}
+
+// goTimeExample is an example `date-time` as a Go literal, and
+// goTimeExampleJSON is its JSON encoding.
+const (
+	goTimeExample     = "time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)"
+	goTimeExampleJSON = `"2024-01-02T03:04:05Z"`
+	goZeroTimeJSON    = `"0001-01-01T00:00:00Z"`
+)
+
+// goBufferExampleValue returns an example buffer holding s as both a Go
+// literal and its JSON encoding, which is base64.
+func goBufferExampleValue(s string) (goValue, jsonValue string) {
+	return fmt.Sprintf("[]byte(%q)", s), fmt.Sprintf("%q", base64.StdEncoding.EncodeToString([]byte(s)))
+}
+
+// zeroGoStructLiteral returns a Go literal of the zero value of a struct,
+// except that its required enums hold their first value since an empty
+// enum cannot be decoded. A struct with defaults is decoded over the value
+// of its `New` function, so it starts from that value instead.
+func zeroGoStructLiteral(ct *schema.CustomType) string {
+	if hasDefaults(ct) {
+		return "*" + zeroGoStructPtr(ct)
+	}
+	var fields []string
+	for _, prop := range ct.GetRequiredProps() {
+		if prop.Ref != "" && prop.RefCustomType == nil {
+			fields = append(fields, fmt.Sprintf("%v: %vEnum%v", uppercaseFirst(prop.Name), getGoItemsType(prop), uppercaseFirst(prop.FirstEnumValue)))
+		}
+	}
+	return fmt.Sprintf("%v{%v}", ct.Name, strings.Join(fields, ", "))
+}
+
+// zeroGoStructPtr returns a Go expression of a pointer to the value of
+// zeroGoStructLiteral.
+func zeroGoStructPtr(ct *schema.CustomType) string {
+	if !hasDefaults(ct) {
+		return "&" + zeroGoStructLiteral(ct)
+	}
+	var sets []string
+	for _, prop := range ct.GetRequiredProps() {
+		if prop.Ref != "" && prop.RefCustomType == nil && prop.Default == nil {
+			sets = append(sets, fmt.Sprintf("v.%v = %vEnum%v; ", uppercaseFirst(prop.Name), getGoItemsType(prop), uppercaseFirst(prop.FirstEnumValue)))
+		}
+	}
+	if len(sets) == 0 {
+		return fmt.Sprintf("New%v()", ct.Name)
+	}
+	return fmt.Sprintf("func() *%v { v := New%[1]v(); %vreturn v }()", ct.Name, strings.Join(sets, ""))
+}
+
+// zeroGoStructJSONValue returns the JSON encoding of the value of
+// zeroGoStructLiteral.
+func zeroGoStructJSONValue(ct *schema.CustomType) string {
+	fields := make([]string, 0, len(ct.Properties))
+	for _, prop := range ct.Properties {
+		var v string
+		switch {
+		case prop.Default != nil:
+			v = goDefaultJSON(prop)
+		case !prop.IsRequired:
+			continue
+		case prop.Ref != "" && prop.RefCustomType != nil:
+			v = "null"
+		case prop.Ref != "":
+			v = fmt.Sprintf("%q", prop.FirstEnumValue)
+		case propType(prop) == "integer", propType(prop) == "number":
+			v = "0"
+		case propType(prop) == "boolean":
+			v = "false"
+		case propType(prop) == "string" && prop.Format == "date-time":
+			v = goZeroTimeJSON
+		case propType(prop) == "string":
+			v = `""`
+		default:
+			v = "null"
+		}
+		fields = append(fields, fmt.Sprintf("%q:%v", prop.Name, v))
+	}
+	return fmt.Sprintf("{%v}", strings.Join(fields, ","))
+}
+
+// goPluginExportsUseFmt reports whether the plugin export wrappers use "fmt".
+func goPluginExportsUseFmt(exports []*schema.Export) bool {
+	for _, export := range exports {
+		if inputIsJSON(export.Input) || outputIsJSON(export.Output) {
+			return true
+		}
+	}
+	return false
+}
+
+// goPluginExportsUseJSON reports whether the plugin export wrappers use "encoding/json".
+func goPluginExportsUseJSON(exports []*schema.Export) bool {
+	for _, export := range exports {
+		if (inputIsJSON(export.Input) && export.Input.Ref == "") || outputIsJSON(export.Output) {
+			return true
+		}
+	}
+	return false
+}
+
 func goMultilineComment(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -121,72 +342,51 @@ func goMultilineComment(s string) string {
 	return "// " + strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n  // ")
 }
 
+// goStringLiteral returns s as a raw Go string literal if possible.
+func goStringLiteral(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
 func inputToGoType(input *schema.Input) string {
 	if input == nil {
 		return ""
 	}
+	return "input " + inputToGoTypeName(input)
+}
+
+func inputToGoTypeName(input *schema.Input) string {
+	if input == nil {
+		return ""
+	}
 
 	if input.Ref != "" {
 		parts := strings.Split(input.Ref, "/")
 		refName := parts[len(parts)-1]
-		return "input " + refName
+		return refName
 	}
 
 	switch input.Type {
 	case "integer":
-		return "input int"
+		return "int"
 	case "string":
-		return "input string"
+		return "string"
 	case "number":
-		return "input float64"
+		return "float64"
 	case "boolean":
-		return "input bool"
+		return "bool"
 	case "object":
-		return "input {}" // TODO - what should this be?
+		return "map[string]" + getGoMapValueType(input.AdditionalProperties)
 	case "array":
-		return "input []" // TODO - what should this be?
+		warnMissingItems(input.Pos, input.Items)
+		return "[]" + getGoItemsType(input.Items)
 	case "buffer":
-		return "input Buffer" // TODO - what should this be?
+		return "[]byte"
 	default:
-		log.Printf("WARNING: unknown property type %q", input.Type)
-		return "input " + input.Type
-	}
-}
-
-func jsonOutputAsGoType(output *schema.Output) string {
-	// TODO: finish this
-	// if output.Ref != "" {
-	// 	parts := strings.Split(output.Ref, "/")
-	// 	refName := parts[len(parts)-1]
-	// 	if output.RefCustomType != nil {
-	// 		return refName + "?"
-	// 	}
-	// 	return refName
-	// }
-
-	// var optional string
-	// if !output.IsRequired {
-	// 	optional = "?"
-	// }
-
-	switch output.Type {
-	// case "integer":
-	// 	return "Int" + optional
-	// case "string":
-	// 	return "String" + optional
-	// case "number":
-	// 	return "Double" + optional
-	case "boolean":
-		return "bool"
-	// case "object":
-	// 	return "{}" + optional // TODO - what should this be?
-	// case "array":
-	// 	return "[]" + optional // TODO - what should this be?
-	// case "buffer":
-	// 	return "Buffer" + optional // TODO - what should this be?
-	default:
-		log.Printf("WARNING: unknown property type %q", output.Type)
-		return output.Type
+		warnf(input.Pos, "unknown property type %q", input.Type)
+		return input.Type
 	}
 }
 
@@ -213,19 +413,15 @@ func outputToGoExampleLiteral(output *schema.Output) string {
 	case "integer":
 		return "\n\treturn 0"
 	case "string":
-		return `\n\treturn ""`
+		return "\n\treturn \"\""
 	case "number":
 		return "\n\treturn 0.0"
 	case "boolean":
 		return "\n\treturn false"
-	case "object":
-		return "\n\treturn {}" // TODO - what should this be?
-	case "array":
-		return "\n\treturn []" // TODO - what should this be?
-	case "buffer":
-		return "\n\treturn Buffer" // TODO - what should this be?
+	case "object", "array", "buffer":
+		return "\n\treturn nil"
 	default:
-		log.Printf("WARNING: unknown property type %q", output.Type)
+		warnf(output.Pos, "unknown property type %q", output.Type)
 		return "\n\t" + output.Type
 	}
 }
@@ -254,43 +450,49 @@ func outputToGoType(output *schema.Output) string {
 	case "boolean":
 		return "bool"
 	case "object":
-		return "{}" // TODO - what should this be?
+		return "map[string]" + getGoMapValueType(output.AdditionalProperties)
 	case "array":
-		return "[]" // TODO - what should this be?
+		warnMissingItems(output.Pos, output.Items)
+		return "[]" + getGoItemsType(output.Items)
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		return "[]byte"
 	default:
-		log.Printf("WARNING: unknown property type %q", output.Type)
+		warnf(output.Pos, "unknown property type %q", output.Type)
 		return output.Type
 	}
 }
 
 func requiredGoJSONValue(prop *schema.Property) string {
 	if prop.Ref != "" {
-		parts := strings.Split(prop.Ref, "/")
 		if prop.RefCustomType != nil {
-			return "&" + parts[len(parts)-1] + "{}" // FIX - not a JSON value
+			return zeroGoStructJSONValue(prop.RefCustomType)
 		}
 		return fmt.Sprintf("%q", prop.FirstEnumValue)
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
+		if prop.Format == "date-time" {
+			return goTimeExampleJSON
+		}
 		return fmt.Sprintf("%q", prop.Name)
 	case "number":
-		return "0.0"
+		return "0"
 	case "boolean":
 		return "true"
 	case "object":
-		return "{}" // TODO - what should this be?
+		_, v := goMapExampleValue(prop.AdditionalProperties)
+		return v
 	case "array":
-		return "[]" // TODO - what should this be?
+		_, v := goItemsExampleValue(prop.Items)
+		return v
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		_, v := goBufferExampleValue(prop.Name)
+		return v
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
@@ -300,28 +502,334 @@ func requiredGoValue(prop *schema.Property) string {
 		parts := strings.Split(prop.Ref, "/")
 		refName := parts[len(parts)-1]
 		if prop.RefCustomType != nil {
-			return fmt.Sprintf("&%v{}", refName)
+			return zeroGoStructPtr(prop.RefCustomType)
 		}
 		return fmt.Sprintf("%vEnum%v", uppercaseFirst(refName), uppercaseFirst(prop.FirstEnumValue))
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
+		if prop.Format == "date-time" {
+			return goTimeExample
+		}
 		return fmt.Sprintf("%q", prop.Name)
 	case "number":
-		return "0.0"
+		return "0"
 	case "boolean":
 		return "true"
 	case "object":
-		return "{}" // TODO - what should this be?
+		v, _ := goMapExampleValue(prop.AdditionalProperties)
+		return v
 	case "array":
-		return "[]" // TODO - what should this be?
+		v, _ := goItemsExampleValue(prop.Items)
+		return v
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		v, _ := goBufferExampleValue(prop.Name)
+		return v
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
+
+// goValidateProperty returns the statements of a generated `Validate` method
+// that check the property of the struct `c`: that a required pointer, slice
+// or map is present, that numbers are within their `minimum` and `maximum`,
+// and that nested structs are themselves valid.
+func goValidateProperty(prop *schema.Property) string {
+	var b strings.Builder
+	field := "c." + uppercaseFirst(prop.Name)
+	if prop.IsRequired && goTypeIsNilable(prop) {
+		fmt.Fprintf(&b, "if %v == nil {\nreturn fmt.Errorf(%q)\n}\n", field, prop.Name+": required")
+	}
+	// A missing required struct has already been reported above.
+	isOptional := !prop.IsRequired && (prop.RefCustomType != nil || goTypeIsNumeric(prop) || goTypeIsEnum(prop))
+	writeGoValidateValue(&b, prop, field, isOptional, prop.Name, nil, 1)
+	return b.String()
+}
+
+// writeGoValidateValue writes the checks of the Go value expr of the property,
+// which is a pointer that may be nil, or an enum that may be empty, if isOptional.
+// The path of the value within error messages is pathFmt formatted with the Go
+// expressions pathArgs.
+func writeGoValidateValue(b *strings.Builder, prop *schema.Property, expr string, isOptional bool, pathFmt string, pathArgs []string, depth int) {
+	if !goNeedsValidation(prop) {
+		return
+	}
+
+	errorf := func(msgFmt string, args ...string) string {
+		call := fmt.Sprintf("fmt.Errorf(%q", pathFmt+msgFmt)
+		for _, arg := range append(append([]string{}, pathArgs...), args...) {
+			call += ", " + arg
+		}
+		return call + ")"
+	}
+
+	switch {
+	case prop.RefCustomType != nil:
+		if isOptional {
+			fmt.Fprintf(b, "if %v != nil {\n", expr)
+		}
+		fmt.Fprintf(b, "if err := %v.Validate(); err != nil {\nreturn %v\n}\n", expr, errorf(".%w", "err"))
+		if isOptional {
+			b.WriteString("}\n")
+		}
+	case goTypeIsEnum(prop):
+		var guard string
+		if isOptional {
+			guard = expr + ` != "" && `
+		}
+		fmt.Fprintf(b, "if %v!%v.IsValid() {\nreturn %v\n}\n", guard, expr,
+			errorf(": %q is not a valid "+getGoItemsType(prop), expr))
+	case goTypeIsNumeric(prop):
+		value, guard := expr, ""
+		if isOptional {
+			value, guard = "*"+expr, expr+" != nil && "
+		}
+		if prop.Minimum != nil {
+			fmt.Fprintf(b, "if %v%v < %v {\nreturn %v\n}\n", guard, value, goBoundLiteral(prop, *prop.Minimum, math.Ceil),
+				errorf(": %v is less than minimum "+formatBound(*prop.Minimum), value))
+		}
+		if prop.Maximum != nil {
+			fmt.Fprintf(b, "if %v%v > %v {\nreturn %v\n}\n", guard, value, goBoundLiteral(prop, *prop.Maximum, math.Floor),
+				errorf(": %v is greater than maximum "+formatBound(*prop.Maximum), value))
+		}
+	case propType(prop) == "array":
+		i, v := loopVar("i", depth), loopVar("v", depth)
+		fmt.Fprintf(b, "for %v, %v := range %v {\n", i, v, expr)
+		writeGoValidateValue(b, prop.Items, v, false, pathFmt+"[%v]", append(append([]string{}, pathArgs...), i), depth+1)
+		b.WriteString("}\n")
+	case propType(prop) == "object":
+		k, v := loopVar("k", depth), loopVar("v", depth)
+		fmt.Fprintf(b, "for %v, %v := range %v {\n", k, v, expr)
+		writeGoValidateValue(b, prop.AdditionalProperties, v, false, pathFmt+"[%q]", append(append([]string{}, pathArgs...), k), depth+1)
+		b.WriteString("}\n")
+	}
+}
+
+// goNeedsValidation reports whether a value of the property has constraints
+// beyond those of its Go type.
+func goNeedsValidation(prop *schema.Property) bool {
+	switch {
+	case prop == nil:
+		return false
+	case prop.RefCustomType != nil, goTypeIsEnum(prop):
+		return true
+	case goTypeIsNumeric(prop):
+		return prop.Minimum != nil || prop.Maximum != nil
+	case propType(prop) == "array":
+		return goNeedsValidation(prop.Items)
+	case propType(prop) == "object":
+		return goNeedsValidation(prop.AdditionalProperties)
+	}
+	return false
+}
+
+// goTypeIsEnum reports whether the property refers to an enum.
+func goTypeIsEnum(prop *schema.Property) bool {
+	return prop.Ref != "" && prop.RefCustomType == nil
+}
+
+func goTypeIsNumeric(prop *schema.Property) bool {
+	return prop.Ref == "" && (propType(prop) == "integer" || propType(prop) == "number")
+}
+
+// goTypeIsNilable reports whether a missing property is represented by nil.
+func goTypeIsNilable(prop *schema.Property) bool {
+	if prop.Ref != "" {
+		return prop.RefCustomType != nil
+	}
+	return propType(prop) == "array" || propType(prop) == "object" || propType(prop) == "buffer"
+}
+
+// goBoundLiteral returns the bound as a Go literal of the property's type,
+// rounded inwards by round for integers.
+func goBoundLiteral(prop *schema.Property, bound float64, round func(float64) float64) string {
+	if propType(prop) == "integer" {
+		return formatBound(round(bound))
+	}
+	return formatBound(bound)
+}
+
+// formatBound formats a `minimum` or `maximum` the way "%v" does.
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// loopVar returns the name of a loop variable nested depth loops deep.
+func loopVar(name string, depth int) string {
+	if depth == 1 {
+		return name
+	}
+	return fmt.Sprintf("%v%v", name, depth)
+}
+
+// goValidTestValue returns a Go literal of the struct that passes `Validate`,
+// with every required property set within its bounds.
+func goValidTestValue(ct *schema.CustomType) string {
+	return "&" + goValidStructLiteral(ct)
+}
+
+func goValidStructLiteral(ct *schema.CustomType) string {
+	var fields []string
+	for _, prop := range ct.GetRequiredProps() {
+		fields = append(fields, fmt.Sprintf("%v: %v,", uppercaseFirst(prop.Name), goValidValue(prop, true)))
+	}
+	if len(fields) == 0 {
+		return ct.Name + "{}"
+	}
+	return fmt.Sprintf("%v{\n%v\n}", ct.Name, strings.Join(fields, "\n"))
+}
+
+// goValidValue returns a Go literal of a value of the property that passes
+// `Validate`. isField is false for array elements and map values.
+func goValidValue(prop *schema.Property, isField bool) string {
+	switch {
+	case prop.RefCustomType != nil && isField:
+		return "&" + goValidStructLiteral(prop.RefCustomType)
+	case prop.RefCustomType != nil:
+		return goValidStructLiteral(prop.RefCustomType)
+	case prop.Ref != "":
+		return fmt.Sprintf("%vEnum%v", getGoItemsType(prop), uppercaseFirst(prop.FirstEnumValue))
+	case goTypeIsNumeric(prop):
+		return formatBound(goValidNumber(prop))
+	case propType(prop) == "boolean":
+		return "true"
+	case propType(prop) == "string" && prop.Format == "date-time":
+		return goTimeExample
+	case propType(prop) == "string":
+		return fmt.Sprintf("%q", prop.Name)
+	case propType(prop) == "array":
+		return fmt.Sprintf("[]%v{}", getGoItemsType(prop.Items))
+	case propType(prop) == "object":
+		return fmt.Sprintf("map[string]%v{}", getGoMapValueType(prop.AdditionalProperties))
+	case propType(prop) == "buffer":
+		return "[]byte{}"
+	}
+	return `""`
+}
+
+// goValidNumber returns the number closest to zero within the bounds of the property.
+func goValidNumber(prop *schema.Property) float64 {
+	switch {
+	case prop.Minimum != nil && *prop.Minimum > 0:
+		if propType(prop) == "integer" {
+			return math.Ceil(*prop.Minimum)
+		}
+		return *prop.Minimum
+	case prop.Maximum != nil && *prop.Maximum < 0:
+		if propType(prop) == "integer" {
+			return math.Floor(*prop.Maximum)
+		}
+		return *prop.Maximum
+	}
+	return 0
+}
+
+// goValidateTestCases returns the test cases of the generated `Validate` test
+// of the struct. Each case modifies a valid value so as to reach a boundary.
+func goValidateTestCases(ct *schema.CustomType) string {
+	cases := []string{fmt.Sprintf("{name: %q, modify: func(v *%v) {}},", "valid", ct.Name)}
+	add := func(tc goValidateTestCase) {
+		c := fmt.Sprintf("{name: %q, modify: func(v *%v) { %v }", tc.name, ct.Name, tc.modify)
+		if tc.wantErr != "" {
+			c += fmt.Sprintf(", wantErr: %q", tc.wantErr)
+		}
+		cases = append(cases, c+"},")
+	}
+
+	for _, prop := range ct.Properties {
+		field := "v." + uppercaseFirst(prop.Name)
+		if prop.IsRequired && goTypeIsNilable(prop) {
+			add(goValidateTestCase{name: "missing " + prop.Name, modify: field + " = nil", wantErr: prop.Name + ": required"})
+		}
+		for _, tc := range goBoundTestCases(prop, field, prop.Name) {
+			add(tc)
+		}
+		if goTypeIsEnum(prop) {
+			add(goValidateTestCase{name: "invalid " + prop.Name, modify: field + ` = "invalid"`,
+				wantErr: fmt.Sprintf("%v: %q is not a valid %v", prop.Name, "invalid", getGoItemsType(prop))})
+		}
+
+		// Check that a nested struct is validated too.
+		if prop.IsRequired && prop.RefCustomType != nil {
+			for _, nested := range prop.RefCustomType.Properties {
+				tcs := goBoundTestCases(nested, field+"."+uppercaseFirst(nested.Name), prop.Name+"."+nested.Name)
+				if len(tcs) > 1 {
+					tcs[1].name = "invalid " + prop.Name
+					add(tcs[1])
+					break
+				}
+			}
+		}
+	}
+	return strings.Join(cases, "\n")
+}
+
+type goValidateTestCase struct {
+	name    string
+	modify  string
+	wantErr string
+}
+
+// goBoundTestCases returns the test cases that set the numeric property field
+// to its `minimum` and `maximum` and just beyond them. path is the path of
+// the property in error messages.
+func goBoundTestCases(prop *schema.Property, field, path string) []goValidateTestCase {
+	if !goTypeIsNumeric(prop) {
+		return nil
+	}
+
+	set := func(value float64) string {
+		switch {
+		case prop.IsRequired:
+			return fmt.Sprintf("%v = %v", field, formatBound(value))
+		case goScalarType(prop) == "int":
+			return fmt.Sprintf("x := %v; %v = &x", formatBound(value), field)
+		default:
+			return fmt.Sprintf("x := %v(%v); %v = &x", goScalarType(prop), formatBound(value), field)
+		}
+	}
+
+	var tcs []goValidateTestCase
+	if prop.Minimum != nil {
+		lowest := *prop.Minimum
+		if propType(prop) == "integer" {
+			lowest = math.Ceil(lowest)
+		}
+		tcs = append(tcs,
+			goValidateTestCase{name: path + " at minimum", modify: set(lowest)},
+			goValidateTestCase{name: path + " below minimum", modify: set(lowest - 1),
+				wantErr: fmt.Sprintf("%v: %v is less than minimum %v", path, formatBound(lowest-1), formatBound(*prop.Minimum))},
+		)
+	}
+	if prop.Maximum != nil {
+		highest := *prop.Maximum
+		if propType(prop) == "integer" {
+			highest = math.Floor(highest)
+		}
+		tcs = append(tcs,
+			goValidateTestCase{name: path + " at maximum", modify: set(highest)},
+			goValidateTestCase{name: path + " above maximum", modify: set(highest + 1),
+				wantErr: fmt.Sprintf("%v: %v is greater than maximum %v", path, formatBound(highest+1), formatBound(*prop.Maximum))},
+		)
+	}
+	return tcs
+}
+
+// goValidatesRef reports whether the generated code validates a value of the
+// referenced schema after decoding it, see `ClientOpts.Validate`.
+func goValidatesRef(c *Client, ref string) bool {
+	if !c.opts.Validate || ref == "" {
+		return false
+	}
+	parts := strings.Split(ref, "/")
+	for _, ct := range c.Plugin.CustomTypes {
+		if ct.Name == parts[len(parts)-1] {
+			return len(ct.Properties) > 0 || len(ct.OneOf) > 0
+		}
+	}
+	return false
+}
@@ -1,6 +1,217 @@
 package codegen
 
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+var (
+	goHostHostFunctionsTemplate   = template.Must(template.New("code-gen-go-host.go:goHostHostFunctionsTemplateStr").Funcs(funcMap).Parse(goHostHostFunctionsTemplateStr))
+	goHostPluginFunctionsTemplate = template.Must(template.New("code-gen-go-host.go:goHostPluginFunctionsTemplateStr").Funcs(funcMap).Parse(goHostPluginFunctionsTemplateStr))
+)
+
 // genGoHostSDK generates Host SDK code to call the extension plugin in Go.
 func (c *Client) genGoHostSDK() (GeneratedFiles, error) {
-	return nil, nil
+	m, err := c.GenCustomTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	pluginFunctions, err := c.execGoTemplate(goHostPluginFunctionsTemplate)
+	if err != nil {
+		return nil, err
+	}
+	m["plugin-functions.go"] = pluginFunctions
+
+	if len(c.Plugin.Imports) > 0 {
+		hostFunctions, err := c.execGoTemplate(goHostHostFunctionsTemplate)
+		if err != nil {
+			return nil, err
+		}
+		m["host-functions.go"] = hostFunctions
+	}
+
+	return m, nil
+}
+
+// execGoTemplate executes the template with the Client and runs gofmt on the result.
+func (c *Client) execGoTemplate(t *template.Template) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, c); err != nil {
+		return "", err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("gofmt error: %v\npre-formatted source:\n%v", err, buf.String())
+	}
+
+	return string(src), nil
+}
+
+var goHostPluginFunctionsTemplateStr = `package {{ .PkgName }}
+
+import (
+	"context"
+{{ if and (not (goFastJSON $)) (.Plugin.Exports | exportsUseJSON) }}	"encoding/json"
+{{ end }}	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an ` + "`extism.Plugin`" + ` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new ` + "`Plugin`" + ` wrapping the provided ` + "`extism.Plugin`" + `.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+{{range .Plugin.Exports }}{{ $name := .Name }}
+// {{ $name | uppercaseFirst }} - {{ .Description | goMultilineComment | stripLeadingSlashes | leftJustify }}
+func (p *Plugin) {{ $name | uppercaseFirst }}(ctx context.Context{{ if .Input }}, {{ .Input | inputToGoType }}{{ end }}) ({{ if .Output }}output {{ .Output | outputToGoType }}, {{ end }}err error) {
+{{ if .Input }}{{ if .Input | inputIsBuffer }}	inBuf := input
+{{ else if .Input | inputIsText }}	inBuf := []byte(input)
+{{ else }}	inBuf, err := {{ goMarshalJSON $ (.Input | inputToGoTypeName) "input" }}
+	if err != nil {
+		return {{ if .Output }}output, {{ end }}fmt.Errorf("{{ $name }}: unable to {{ if goFastJSON $ }}encode JSON{{ else }}json.Marshal{{ end }} input: %w", err)
+	}
+{{ end }}
+{{ end }}	rc, {{ if .Output }}outBuf{{ else }}_{{ end }}, err := p.CallWithContext(ctx, "{{ $name }}", {{ if .Input }}inBuf{{ else }}nil{{ end }})
+	if err != nil {
+		return {{ if .Output }}output, {{ end }}fmt.Errorf("{{ $name }}: %w", err)
+	}
+	if rc != 0 {
+		return {{ if .Output }}output, {{ end }}fmt.Errorf("{{ $name }}: plugin returned exit code %v", rc)
+	}
+{{ if .Output }}{{ if .Output | outputIsBuffer }}
+	return outBuf, nil
+{{ else if .Output | outputIsText }}
+	return string(outBuf), nil
+{{ else }}
+	if err := {{ goUnmarshalJSON $ (.Output | outputToGoType) "outBuf" "output" }}; err != nil {
+		return output, fmt.Errorf("{{ $name }}: unable to {{ if goFastJSON $ }}decode JSON{{ else }}json.Unmarshal{{ end }} output: %w", err)
+	}
+{{ if goValidatesRef $ .Output.Ref }}	if err := output.Validate(); err != nil {
+		return output, fmt.Errorf("{{ $name }}: invalid output: %w", err)
+	}
+{{ end }}
+	return output, nil
+{{ end }}{{ else }}
+	return nil
+{{ end }}}
+{{ end }}`
+
+var goHostHostFunctionsTemplateStr = `package {{ .PkgName }}
+
+import (
+	"context"
+{{ if and (not (goFastJSON $)) (.Plugin.Imports | importsUseJSON) }}	"encoding/json"
+{{ end }}	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// HostErrorVar is the name of the Extism var used to report an error
+// from a host function back to the calling plugin.
+const HostErrorVar = "xtp-host-error"
+
+// HostFunctions represents the functions imported by the XTP Extension Plugin
+// which must be implemented by the host.
+type HostFunctions interface {
+{{range .Plugin.Imports }}{{ $name := .Name }}	// {{ $name | uppercaseFirst }} - {{ .Description | goMultilineComment | stripLeadingSlashes | leftJustify }}
+	{{ $name | uppercaseFirst }}(ctx context.Context{{ if .Input }}, {{ .Input | inputToGoType }}{{ end }}) ({{ if .Output }}{{ .Output | outputToGoType }}, {{ end }}error)
+{{ end -}}
+}
+
+// NewHostFunctions returns the ` + "`extism.HostFunction`" + `s that call impl
+// for each of the functions imported by the XTP Extension Plugin.
+func NewHostFunctions(impl HostFunctions) []extism.HostFunction {
+	return []extism.HostFunction{
+{{range .Plugin.Imports }}		New{{ .Name | uppercaseFirst }}HostFunction(impl.{{ .Name | uppercaseFirst }}),
+{{ end -}}
+	}
+}
+
+// reportHostError logs the error and reports it back to the calling plugin
+// through ` + "`HostErrorVar`" + `.
+func reportHostError(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64, name string, err error) {
+	msg := fmt.Sprintf("%v: %v", name, err)
+	plugin.Log(extism.LogLevelError, msg)
+	if p, ok := ctx.Value(extism.PluginCtxKey("plugin")).(*extism.Plugin); ok {
+		p.Var[HostErrorVar] = []byte(msg)
+	}
+	stack[0] = 0
+}
+{{range .Plugin.Imports }}{{ $name := .Name }}
+// New{{ $name | uppercaseFirst }}HostFunction returns an ` + "`extism.HostFunction`" + ` that
+// implements the "{{ $name }}" import by calling fn.
+func New{{ $name | uppercaseFirst }}HostFunction(fn func(ctx context.Context{{ if .Input }}, {{ .Input | inputToGoType }}{{ end }}) ({{ if .Output }}{{ .Output | outputToGoType }}, {{ end }}error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"{{ $name }}",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+{{ if .Input }}{{ if .Input | inputIsBuffer }}			input, err := plugin.ReadBytes(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+{{ else if .Input | inputIsText }}			input, err := plugin.ReadString(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+{{ else }}			buf, err := plugin.ReadBytes(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			var input {{ .Input | inputToGoTypeName }}
+			if err := {{ goUnmarshalJSON $ (.Input | inputToGoTypeName) "buf" "input" }}; err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("unable to {{ if goFastJSON $ }}decode JSON{{ else }}json.Unmarshal{{ end }} input: %w", err))
+				return
+			}
+{{ if goValidatesRef $ .Input.Ref }}			if err := input.Validate(); err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("invalid input: %w", err))
+				return
+			}
+{{ end }}
+{{ end }}{{ end }}{{ if .Output }}			output, err := fn(ctx{{ if .Input }}, input{{ end }})
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", err)
+				return
+			}
+
+{{ if .Output | outputIsBuffer }}			outBuf := output
+{{ else if .Output | outputIsText }}			outBuf := []byte(output)
+{{ else }}			outBuf, err := {{ goMarshalJSON $ (.Output | outputToGoType) "output" }}
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("unable to {{ if goFastJSON $ }}encode JSON{{ else }}json.Marshal{{ end }} output: %w", err))
+				return
+			}
+{{ end }}
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+{{ else }}			if err := fn(ctx{{ if .Input }}, input{{ end }}); err != nil {
+				reportHostError(ctx, plugin, stack, "{{ $name }}", err)
+				return
+			}
+
+			stack[0] = 0
+{{ end }}		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
 }
+{{ end }}`
@@ -1,20 +1,231 @@
 package codegen
 
-import "testing"
+import (
+	"embed"
+	"testing"
+)
+
+//go:embed testdata/fruit/go-host/*
+var wantFruitGoHostFS embed.FS
+
+//go:embed testdata/user/go-host/*
+var wantUserGoHostFS embed.FS
+
+//go:embed testdata/arrays/go-host/*
+var wantArraysGoHostFS embed.FS
+
+//go:embed testdata/maps/go-host/*
+var wantMapsGoHostFS embed.FS
+
+//go:embed testdata/buffers/go-host/*
+var wantBuffersGoHostFS embed.FS
+
+//go:embed testdata/primitives/go-host/*
+var wantPrimitivesGoHostFS embed.FS
+
+//go:embed testdata/trees/go-host/*
+var wantTreesGoHostFS embed.FS
+
+//go:embed testdata/bounds/go-host/*
+var wantBoundsGoHostFS embed.FS
+
+//go:embed testdata/formats/go-host/*
+var wantFormatsGoHostFS embed.FS
+
+//go:embed testdata/defaults/go-host/*
+var wantDefaultsGoHostFS embed.FS
+
+//go:embed testdata/unions/go-host/*
+var wantUnionsGoHostFS embed.FS
+
+//go:embed testdata/fastjson/go-host/*
+var wantFastJSONGoHostFS embed.FS
 
 func TestGenGoHostSDK(t *testing.T) {
 	t.Parallel()
-	tests := []struct {
-		name string
-	}{
+
+	tests := []*embedFSTest{
+		{
+			name:    "fruit",
+			lang:    "go",
+			pkgName: "fruit",
+			yamlStr: fruitYaml,
+			files: []string{
+				"fruit.go",
+				"fruit_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/fruit/go-host",
+			embedFS:     wantFruitGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "user",
+			lang:    "go",
+			pkgName: "user",
+			yamlStr: userYaml,
+			files: []string{
+				"user.go",
+				"user_test.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/user/go-host",
+			embedFS:     wantUserGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "arrays",
+			lang:    "go",
+			pkgName: "arrays",
+			yamlStr: arraysYaml,
+			files: []string{
+				"arrays.go",
+				"arrays_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/arrays/go-host",
+			embedFS:     wantArraysGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
 		{
-			name: "",
+			name:    "maps",
+			lang:    "go",
+			pkgName: "maps",
+			yamlStr: mapsYaml,
+			files: []string{
+				"maps.go",
+				"maps_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/maps/go-host",
+			embedFS:     wantMapsGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "buffers",
+			lang:    "go",
+			pkgName: "buffers",
+			yamlStr: buffersYaml,
+			files: []string{
+				"buffers.go",
+				"buffers_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/buffers/go-host",
+			embedFS:     wantBuffersGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "primitives",
+			lang:    "go",
+			pkgName: "primitives",
+			yamlStr: primitivesYaml,
+			files: []string{
+				"host-functions.go",
+				"plugin-functions.go",
+				"primitives.go",
+				"primitives_test.go",
+			},
+			embedSubdir: "testdata/primitives/go-host",
+			embedFS:     wantPrimitivesGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "trees",
+			lang:    "go",
+			pkgName: "trees",
+			yamlStr: treesYaml,
+			files: []string{
+				"host-functions.go",
+				"plugin-functions.go",
+				"trees.go",
+				"trees_test.go",
+			},
+			embedSubdir: "testdata/trees/go-host",
+			embedFS:     wantTreesGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "bounds",
+			lang:    "go",
+			pkgName: "bounds",
+			yamlStr: boundsYaml,
+			opts:    &ClientOpts{Validate: true},
+			files: []string{
+				"bounds.go",
+				"bounds_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/bounds/go-host",
+			embedFS:     wantBoundsGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "formats",
+			lang:    "go",
+			pkgName: "formats",
+			yamlStr: formatsYaml,
+			files: []string{
+				"formats.go",
+				"formats_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/formats/go-host",
+			embedFS:     wantFormatsGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "defaults",
+			lang:    "go",
+			pkgName: "defaults",
+			yamlStr: defaultsYaml,
+			files: []string{
+				"defaults.go",
+				"defaults_test.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/defaults/go-host",
+			embedFS:     wantDefaultsGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "unions",
+			lang:    "go",
+			pkgName: "unions",
+			yamlStr: unionsYaml,
+			files: []string{
+				"host-functions.go",
+				"plugin-functions.go",
+				"unions.go",
+				"unions_test.go",
+			},
+			embedSubdir: "testdata/unions/go-host",
+			embedFS:     wantUnionsGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
+		},
+		{
+			name:    "fastjson",
+			lang:    "go",
+			pkgName: "fastjson",
+			yamlStr: fastJSONYaml,
+			opts:    &ClientOpts{FastJSON: true},
+			files: []string{
+				"fastjson.go",
+				"fastjson_test.go",
+				"host-functions.go",
+				"plugin-functions.go",
+			},
+			embedSubdir: "testdata/fastjson/go-host",
+			embedFS:     wantFastJSONGoHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoHostSDK() },
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-		})
-	}
+	runEmbedFSTest(t, tests)
 }
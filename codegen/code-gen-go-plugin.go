@@ -72,33 +72,49 @@ var goPluginHostFunctionsTemplateStr = `//go:build tinygo
 package main
 
 import (
-	"encoding/json"
+{{ if and (not (goFastJSON $)) (.Plugin.Imports | importsUseJSON) }}	"encoding/json"
+{{ end }}	"errors"
 
 	"github.com/extism/go-pdk"
 )
+
+// hostErrorVar is the name of the Extism var used by the host to report
+// an error from a host function.
+const hostErrorVar = "xtp-host-error"
 {{range .Plugin.Imports }}{{ $name := .Name }}
 //go:wasmimport extism:host/user {{ $name }}
 func host{{ $name | uppercaseFirst }}(uint64) uint64
 
 // {{ $name | uppercaseFirst }} - {{ .Description | goMultilineComment | stripLeadingSlashes | leftJustify }}
-func {{ $name | uppercaseFirst }}({{ .Input | inputToGoType }}) ({{ .Output | outputToGoType }}, error) {
-	buf, err := json.Marshal(input)
+func {{ $name | uppercaseFirst }}({{ .Input | inputToGoType }}) ({{ if .Output }}result {{ .Output | outputToGoType }}, {{ end }}err error) {
+{{ if .Input }}{{ if .Input | inputIsBuffer }}	mem := pdk.AllocateBytes(input)
+{{ else if .Input | inputIsText }}	mem := pdk.AllocateString(input)
+{{ else }}	buf, err := {{ goMarshalJSON $ (.Input | inputToGoTypeName) "input" }}
 	if err != nil {
-		return false, err
+		return {{ if .Output }}result, {{ end }}err
 	}
 
 	mem := pdk.AllocateBytes(buf)
-	ptr := host{{ $name | uppercaseFirst }}(mem.Offset())
-
+{{ end }}	{{ if .Output }}ptr := {{ end }}host{{ $name | uppercaseFirst }}(mem.Offset())
+{{ else }}	{{ if .Output }}ptr := {{ end }}host{{ $name | uppercaseFirst }}(0)
+{{ end }}	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return {{ if .Output }}result, {{ end }}errors.New(string(errMsg))
+	}
+{{ if .Output }}
 	rmem := pdk.FindMemory(ptr)
-	buf = rmem.ReadBytes()
-
-	var result {{ .Output | jsonOutputAsGoType }}
-	if err := json.Unmarshal(buf, &result); err != nil {
-		return false, err
+{{ if .Output | outputIsBuffer }}	return rmem.ReadBytes(), nil
+{{ else if .Output | outputIsText }}	return string(rmem.ReadBytes()), nil
+{{ else }}	if err := {{ goUnmarshalJSON $ (.Output | outputToGoType) "rmem.ReadBytes()" "result" }}; err != nil {
+		return result, err
 	}
-	return result, nil
-}
+{{ if goValidatesRef $ .Output.Ref }}	if err := result.Validate(); err != nil {
+		return result, err
+	}
+{{ end }}	return result, nil
+{{ end }}{{ else }}
+	return nil
+{{ end }}}
 {{ end }}`
 
 var goPluginMainTemplateStr = `//go:build tinygo
@@ -121,52 +137,49 @@ func {{ $name | uppercaseFirst }}({{ .Input | inputToGoType }}){{ if .Output }}
 func main() {}
 `
 
-// TODO: support primitive types other than string.
 var goPluginPluginFunctionsTemplateStr = `//go:build tinygo
 
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+{{ if and (not (goFastJSON $)) (.Plugin.Exports | goPluginExportsUseJSON) }}	"encoding/json"
+{{ end }}{{ if .Plugin.Exports | goPluginExportsUseFmt }}	"fmt"
 
-	"github.com/extism/go-pdk"
+{{ end }}	"github.com/extism/go-pdk"
 )
 {{range $index, $export := .Plugin.Exports }}{{ $name := .Name }}
 //export {{ $name }}
 func {{ $name }}() int {
-{{ if . | inputIsVoidType }}	{{ $name | uppercaseFirst }}(){{ end -}}
-{{ if . | inputIsPrimitiveType }}	var input string
-	if err := json.Unmarshal([]byte(pdk.InputString()), &input); err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
-		return 1 // failure
-	}
+{{ if . | inputIsBufferType }}	input := pdk.Input()
 
-	output := {{ $name | uppercaseFirst }}(input)
+{{ else if .Input | inputIsText }}	input := pdk.InputString()
 
-	buf, err := json.Marshal(output)
+{{ else if . | inputIsReferenceType }}	in := pdk.InputString()
+	input, err := Parse{{ inputReferenceTypeName . }}(in)
 	if err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to Parse{{ inputReferenceTypeName . }} input: %v, input:\n%v\n", err, in))
 		return 1 // failure
 	}
 
-	pdk.OutputString(string(buf)){{ end -}}
-{{ if . | inputIsReferenceType }}	input := pdk.InputString()
-	v, err := Parse{{ inputReferenceTypeName . }}(input)
-	if err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to Parse{{ inputReferenceTypeName . }} input: %v, input:\n%v\n", err, input))
+{{ else if .Input }}	var input {{ .Input | inputToGoTypeName }}
+	if err := {{ goUnmarshalJSON $ (.Input | inputToGoTypeName) "pdk.Input()" "input" }}; err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to {{ if goFastJSON $ }}decode JSON{{ else }}json.Unmarshal{{ end }} input: %v", err))
 		return 1 // failure
 	}
 
-	output := {{ $name | uppercaseFirst }}(v)
-
-	buf, err := json.Marshal(output)
+{{ end }}	{{ if .Output }}output := {{ end }}{{ $name | uppercaseFirst }}({{ if .Input }}input{{ end }})
+{{ if .Output }}{{ if .Output | outputIsBuffer }}
+	pdk.Output(output)
+{{ else if .Output | outputIsText }}
+	pdk.OutputString(output)
+{{ else }}
+	buf, err := {{ goMarshalJSON $ (.Output | outputToGoType) "output" }}
 	if err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to {{ if goFastJSON $ }}encode JSON{{ else }}json.Marshal{{ end }} output: %v", err))
 		return 1 // failure
 	}
 
-	pdk.OutputString(string(buf)){{ end }}
-	return 0 // success
+	pdk.OutputString(string(buf))
+{{ end }}{{ end }}	return 0 // success
 {{ "}" }}
 {{ end }}`
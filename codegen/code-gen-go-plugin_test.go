@@ -11,6 +11,30 @@ var wantFruitGoPluginFS embed.FS
 //go:embed testdata/user/go-plugin/*
 var wantUserGoPluginFS embed.FS
 
+//go:embed testdata/buffers/go-plugin/*
+var wantBuffersGoPluginFS embed.FS
+
+//go:embed testdata/primitives/go-plugin/*
+var wantPrimitivesGoPluginFS embed.FS
+
+//go:embed testdata/trees/go-plugin/*
+var wantTreesGoPluginFS embed.FS
+
+//go:embed testdata/bounds/go-plugin/*
+var wantBoundsGoPluginFS embed.FS
+
+//go:embed testdata/formats/go-plugin/*
+var wantFormatsGoPluginFS embed.FS
+
+//go:embed testdata/defaults/go-plugin/*
+var wantDefaultsGoPluginFS embed.FS
+
+//go:embed testdata/unions/go-plugin/*
+var wantUnionsGoPluginFS embed.FS
+
+//go:embed testdata/fastjson/go-plugin/*
+var wantFastJSONGoPluginFS embed.FS
+
 func TestGenGoPluginPDK(t *testing.T) {
 	t.Parallel()
 	tests := []*embedFSTest{
@@ -49,6 +73,151 @@ func TestGenGoPluginPDK(t *testing.T) {
 			embedFS:     wantUserGoPluginFS,
 			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
 		},
+		{
+			name:    "buffers",
+			lang:    "go",
+			pkgName: "buffers",
+			yamlStr: buffersYaml,
+			files: []string{
+				"buffers.go",
+				"buffers_test.go",
+				"build.sh",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/buffers/go-plugin",
+			embedFS:     wantBuffersGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "primitives",
+			lang:    "go",
+			pkgName: "primitives",
+			yamlStr: primitivesYaml,
+			files: []string{
+				"build.sh",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"primitives.go",
+				"primitives_test.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/primitives/go-plugin",
+			embedFS:     wantPrimitivesGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "trees",
+			lang:    "go",
+			pkgName: "trees",
+			yamlStr: treesYaml,
+			files: []string{
+				"build.sh",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"trees.go",
+				"trees_test.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/trees/go-plugin",
+			embedFS:     wantTreesGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "bounds",
+			lang:    "go",
+			pkgName: "bounds",
+			yamlStr: boundsYaml,
+			opts:    &ClientOpts{Validate: true},
+			files: []string{
+				"bounds.go",
+				"bounds_test.go",
+				"build.sh",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/bounds/go-plugin",
+			embedFS:     wantBoundsGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "formats",
+			lang:    "go",
+			pkgName: "formats",
+			yamlStr: formatsYaml,
+			files: []string{
+				"build.sh",
+				"formats.go",
+				"formats_test.go",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/formats/go-plugin",
+			embedFS:     wantFormatsGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "defaults",
+			lang:    "go",
+			pkgName: "defaults",
+			yamlStr: defaultsYaml,
+			files: []string{
+				"build.sh",
+				"defaults.go",
+				"defaults_test.go",
+				"main.go",
+				"plugin-functions.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/defaults/go-plugin",
+			embedFS:     wantDefaultsGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "unions",
+			lang:    "go",
+			pkgName: "unions",
+			yamlStr: unionsYaml,
+			files: []string{
+				"build.sh",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"unions.go",
+				"unions_test.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/unions/go-plugin",
+			embedFS:     wantUnionsGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
+		{
+			name:    "fastjson",
+			lang:    "go",
+			pkgName: "fastjson",
+			yamlStr: fastJSONYaml,
+			opts:    &ClientOpts{FastJSON: true},
+			files: []string{
+				"build.sh",
+				"fastjson.go",
+				"fastjson_test.go",
+				"host-functions.go",
+				"main.go",
+				"plugin-functions.go",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/fastjson/go-plugin",
+			embedFS:     wantFastJSONGoPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genGoPluginPDK() },
+		},
 	}
 
 	runEmbedFSTest(t, tests)
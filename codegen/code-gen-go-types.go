@@ -16,6 +16,8 @@ var (
 	enumTestGoTemplate   = template.Must(template.New("code-gen-go-types.go:enumTestGoTemplateStr").Funcs(funcMap).Parse(enumTestGoTemplateStr))
 	structGoTemplate     = template.Must(template.New("code-gen-go-types.go:structGoTemplateStr").Funcs(funcMap).Parse(structGoTemplateStr))
 	structTestGoTemplate = template.Must(template.New("code-gen-go-types.go:structTestGoTemplateStr").Funcs(funcMap).Parse(structTestGoTemplateStr))
+	unionGoTemplate      = template.Must(template.New("code-gen-go-types.go:unionGoTemplateStr").Funcs(funcMap).Parse(unionGoTemplateStr))
+	unionTestGoTemplate  = template.Must(template.New("code-gen-go-types.go:unionTestGoTemplateStr").Funcs(funcMap).Parse(unionTestGoTemplateStr))
 )
 
 // genGoCustomTypes generates custom types with tests for the plugin in Go.
@@ -39,12 +41,21 @@ func (c *Client) genGoCustomTypes() error {
 	if c.numStructs > 0 {
 		srcBlocks = append(srcBlocks, goXTPSchemaMap)
 	}
+	if c.opts.FastJSON {
+		testBlock, err := c.genGoBinarySizeTest()
+		if err != nil {
+			return err
+		}
+		testBlocks = append(testBlocks, testBlock)
+	}
 
 	srcToFmt := strings.Join(srcBlocks, "\n")
-	if strings.Contains(srcToFmt, "fmt.Errorf") {
-		srcToFmt = goPreludeWithFmt + srcToFmt
+	if c.opts.FastJSON {
+		srcToFmt = addGoFastJSONFuncs(srcToFmt, goWrapperFastJSONCodecs(c))
+	} else if len(srcBlocks) > 0 {
+		srcToFmt = goPrelude(srcToFmt) + srcToFmt
 	} else {
-		srcToFmt = goPrelude + srcToFmt
+		srcToFmt = "// The schema has no custom types.\n"
 	}
 	src, err := format.Source([]byte(srcToFmt))
 	if err != nil {
@@ -53,7 +64,31 @@ func (c *Client) genGoCustomTypes() error {
 	c.CustTypesFilename = fmt.Sprintf("%v.%v", c.PkgName, c.Lang)
 	c.CustTypes = string(src)
 
-	testSrcToFmt := testGoPrelude + strings.Join(testBlocks, "\n")
+	testSrcToFmt := strings.Join(testBlocks, "\n")
+	if strings.Contains(testSrcToFmt, "cmp.") {
+		testSrcToFmt = testGoPrelude + testSrcToFmt
+	} else {
+		testSrcToFmt = strings.Replace(testGoPrelude, "\t\"github.com/google/go-cmp/cmp\"\n", "", 1) + testSrcToFmt
+	}
+	if strings.Contains(testSrcToFmt, "utf8.") {
+		testSrcToFmt = strings.Replace(testSrcToFmt, "\t\"testing\"\n", "\t\"testing\"\n\t\"unicode/utf8\"\n", 1)
+	}
+	if strings.Contains(testSrcToFmt, "time.") {
+		testSrcToFmt = strings.Replace(testSrcToFmt, "\t\"testing\"\n", "\t\"testing\"\n\t\"time\"\n", 1)
+	}
+	if strings.Contains(testSrcToFmt, "exec.Command(") {
+		testSrcToFmt = strings.Replace(testSrcToFmt, "\t\"testing\"\n", "\t\"os\"\n\t\"os/exec\"\n\t\"path/filepath\"\n\t\"strings\"\n\t\"testing\"\n", 1)
+	}
+	if strings.Contains(testSrcToFmt, "json.Marshal(") {
+		testSrcToFmt = strings.Replace(testSrcToFmt, "import (\n", "import (\n\t\"encoding/json\"\n", 1)
+	}
+	var ptrFuncs string
+	for _, f := range testGoFormatPtrFuncs {
+		if strings.Contains(testSrcToFmt, f.name+"(") {
+			ptrFuncs += f.src
+		}
+	}
+	testSrcToFmt = strings.Replace(testSrcToFmt, goStringPtrFunc, goStringPtrFunc+ptrFuncs, 1)
 	testSrc, err := format.Source([]byte(testSrcToFmt))
 	if err != nil {
 		return fmt.Errorf("gofmt error: %v\npre-formatted test source:\n%v", err, testSrcToFmt)
@@ -64,6 +99,23 @@ func (c *Client) genGoCustomTypes() error {
 	return nil
 }
 
+// genGoBinarySizeTest generates the test that compares the sizes of the
+// binaries built with the FastJSON codecs and with encoding/json, using the
+// first struct, if any.
+func (c *Client) genGoBinarySizeTest() (string, error) {
+	for _, ct := range c.Plugin.CustomTypes {
+		if len(ct.Properties) == 0 {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := binarySizeTestGoTemplate.Execute(&buf, &binarySizeTest{Name: ct.Name, Filename: fmt.Sprintf("%v.%v", c.PkgName, c.Lang)}); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	return "", nil
+}
+
 // genGoCustomType generates Go source code for a single custom datatype.
 func (c *Client) genGoCustomType(ct *schema.CustomType) (string, error) {
 	if ct == nil {
@@ -73,6 +125,8 @@ func (c *Client) genGoCustomType(ct *schema.CustomType) (string, error) {
 	switch {
 	case len(ct.Enum) > 0:
 		return c.genGoEnum(ct)
+	case len(ct.OneOf) > 0:
+		return c.genGoUnion(ct)
 	case len(ct.Properties) > 0:
 		c.numStructs++
 		return c.genGoStruct(ct)
@@ -90,6 +144,8 @@ func (c *Client) genTestGoCustomType(ct *schema.CustomType) (string, error) {
 	switch {
 	case len(ct.Enum) > 0:
 		return c.genTestGoEnum(ct)
+	case len(ct.OneOf) > 0:
+		return c.genTestGoUnion(ct)
 	case len(ct.Properties) > 0:
 		return c.genTestGoStruct(ct)
 	default:
@@ -97,12 +153,24 @@ func (c *Client) genTestGoCustomType(ct *schema.CustomType) (string, error) {
 	}
 }
 
+// goEnum is the data of enumGoTemplate.
+type goEnum struct {
+	*schema.CustomType
+	// FastJSON causes UnmarshalJSON to decode without reflection.
+	FastJSON bool
+}
+
 // getGoEnum generates Go source code for a single enum custom datatype.
 func (c *Client) genGoEnum(ct *schema.CustomType) (string, error) {
 	var buf bytes.Buffer
-	if err := enumGoTemplate.Execute(&buf, ct); err != nil {
+	if err := enumGoTemplate.Execute(&buf, &goEnum{CustomType: ct, FastJSON: c.opts.FastJSON}); err != nil {
 		return "", err
 	}
+	if c.opts.FastJSON {
+		if err := enumFastJSONGoTemplate.Execute(&buf, ct); err != nil {
+			return "", err
+		}
+	}
 
 	return buf.String(), nil
 }
@@ -113,6 +181,11 @@ func (c *Client) genTestGoEnum(ct *schema.CustomType) (string, error) {
 	if err := enumTestGoTemplate.Execute(&buf, ct); err != nil {
 		return "", err
 	}
+	if c.opts.FastJSON {
+		if err := enumFastJSONTestGoTemplate.Execute(&buf, ct); err != nil {
+			return "", err
+		}
+	}
 
 	return buf.String(), nil
 }
@@ -125,6 +198,64 @@ const (
 {{ end -}}
 )
 
+// All{{ $name }} returns all the values of ` + "`" + `{{ $name }}` + "`" + ` in the order of its schema.
+func All{{ $name }}() []{{ $name }} {
+	return []{{ $name }}{
+{{range .Enum}}		{{ $name }}Enum{{ . | uppercaseFirst }},
+{{ end -}}
+{{ "	}" }}
+}
+
+// IsValid reports whether the value is one of the values of ` + "`" + `{{ $name }}` + "`" + `.
+func (v {{ $name }}) IsValid() bool {
+	switch v {
+	case {{range $index, $value := .Enum}}{{ if $index }}, {{ end }}{{ $name }}Enum{{ $value | uppercaseFirst }}{{ end }}:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v {{ $name }}) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v {{ $name }}) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *{{ $name }}) UnmarshalText(text []byte) error {
+	value, err := {{ $name }}FromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *{{ $name }}) UnmarshalJSON(data []byte) error {
+{{ if .FastJSON }}	return unmarshalJSON(data, v.decodeJSON)
+{{ else }}	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+{{ end }}}
+
+// {{ $name }}FromString returns the ` + "`" + `{{ $name }}` + "`" + ` with the given (unquoted) value.
+func {{ $name }}FromString(s string) ({{ $name }}, error) {
+	if v := {{ $name }}(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a {{ $name }}: %q", s)
+}
+
 // Parse{{ $name }} parses a JSON string and returns the value.
 func Parse{{ $name }}(s string) (value {{ $name }}, err error) {
 	switch s {
@@ -159,14 +290,78 @@ var enumTestGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}func TestParse{{
 		t.Errorf("Parse{{ $name }} = '%v', want '%v'", got, {{ $name | downcaseFirst }})
 	}
 }
+
+func Test{{ $name }}Values(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range All{{ $name }}() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := {{ $name }}FromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("{{ $name }}FromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj {{ $name }}
+		if err := jsoncomp.Unmarshal([]byte(` + "`" + `"` + "`" + `+v.String()+` + "`" + `"` + "`" + `), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(All{{ $name }}()), {{ len .Enum }}; got != want {
+		t.Errorf("len(All{{ $name }}()) = %v, want %v", got, want)
+	}
+}
+
+func Test{{ $name }}RejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := {{ $name }}("not-a-{{ $name | downcaseFirst }}")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := {{ $name }}FromString(unknown.String()); err == nil {
+		t.Errorf("{{ $name }}FromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj {{ $name }}
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(` + "`" + `"not-a-{{ $name | downcaseFirst }}"` + "`" + `), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
 `
 
+// goStruct is the data of structGoTemplate.
+type goStruct struct {
+	*schema.CustomType
+	// ValidateOnParse causes Parse<Name> to call Validate.
+	ValidateOnParse bool
+	// FastJSON causes Parse<Name> to decode without reflection.
+	FastJSON bool
+}
+
 // getGoStruct generates Go source code for a single struct custom datatype.
 func (c *Client) genGoStruct(ct *schema.CustomType) (string, error) {
 	var buf bytes.Buffer
-	if err := structGoTemplate.Execute(&buf, ct); err != nil {
+	if err := structGoTemplate.Execute(&buf, &goStruct{CustomType: ct, ValidateOnParse: c.opts.Validate, FastJSON: c.opts.FastJSON}); err != nil {
 		return "", err
 	}
+	if c.opts.FastJSON {
+		if err := structFastJSONGoTemplate.Execute(&buf, ct); err != nil {
+			return "", err
+		}
+	}
 
 	return buf.String(), nil
 }
@@ -177,6 +372,39 @@ func (c *Client) genTestGoStruct(ct *schema.CustomType) (string, error) {
 	if err := structTestGoTemplate.Execute(&buf, ct); err != nil {
 		return "", err
 	}
+	if c.opts.FastJSON {
+		if err := structFastJSONTestGoTemplate.Execute(&buf, ct); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// genGoUnion generates Go source code for a single oneOf custom datatype.
+func (c *Client) genGoUnion(ct *schema.CustomType) (string, error) {
+	u := newUnion(c.Plugin, ct, c.opts.Validate)
+	u.FastJSON = c.opts.FastJSON
+
+	var buf bytes.Buffer
+	if err := unionGoTemplate.Execute(&buf, u); err != nil {
+		return "", err
+	}
+	if c.opts.FastJSON {
+		if err := unionFastJSONGoTemplate.Execute(&buf, u); err != nil {
+			return "", err
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// genTestGoUnion generates Go test source code for a single oneOf custom datatype.
+func (c *Client) genTestGoUnion(ct *schema.CustomType) (string, error) {
+	var buf bytes.Buffer
+	if err := unionTestGoTemplate.Execute(&buf, newUnion(c.Plugin, ct, c.opts.Validate)); err != nil {
+		return "", err
+	}
 
 	return buf.String(), nil
 }
@@ -186,21 +414,53 @@ var goXTPSchemaMap = `// XTPSchema describes the values and types of an XTP obje
 type XTPSchema map[string]string
 `
 
-var structGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}// {{ $name }} represents {{ .Description | downcaseFirst }}.
+var structGoTemplateStr = `{{ $name := .Name }}{{ $top := .CustomType }}// {{ $name }} represents {{ .Description | downcaseFirst }}.
 type {{ $name }} struct {
 {{range .Properties}}  {{ .Description | optionalGoMultilineComment }}{{ .Name | uppercaseFirst }} {{ getGoType . }} ` + "`" + `json:"{{ .Name }}{{ addOmitIfNeeded . }}"` + "`" + `
 {{ end -}}
 }
 
+// New{{ $name }} returns a new ` + "`" + `{{ $name }}` + "`" + ` with the default values of its schema.
+func New{{ $name }}() *{{ $name }} {
+{{ goNewStruct $top }}}
+{{ if and (hasDefaults $top) (not .FastJSON) }}
+// UnmarshalJSON implements json.Unmarshaler by decoding the JSON object over
+// the default values of its schema, so that missing properties keep them,
+// including in the ` + "`" + `{{ $name }}` + "`" + ` values nested in other types.
+func (c *{{ $name }}) UnmarshalJSON(data []byte) error {
+	// plain{{ $name }} has no methods, so json.Unmarshal does not call this method again.
+	type plain{{ $name }} {{ $name }}
+	value := plain{{ $name }}(*New{{ $name }}())
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*c = {{ $name }}(value)
+	return nil
+}
+{{ end }}
 // Parse{{ $name }} parses a JSON string and returns the value.
+{{- if hasDefaults $top }}
+// Properties that are missing from the JSON keep their default values.
+{{- end }}
 func Parse{{ $name }}(s string) (value {{ $name }}, err error) {
-	if err := json.Unmarshal([]byte(s), &value); err != nil {
+{{ if .FastJSON }}	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+{{ else }}	if err := json.Unmarshal([]byte(s), &value); err != nil {
+{{ end }}		return value, err
+	}
+{{ if .ValidateOnParse }}	if err := value.Validate(); err != nil {
 		return value, err
 	}
-
+{{ end }}
 	return value, nil
 }
 
+// Validate returns an error if the ` + "`" + `{{ $name }}` + "`" + ` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *{{ $name }}) Validate() error {
+{{range .Properties}}{{ goValidateProperty . }}{{ end -}}
+	return nil
+}
+
 // GetSchema returns an ` + "`" + `XTPSchema` + "`" + ` for the ` + "`" + `{{ $name }}` + "`" + `.
 func (c *{{ $name }}) GetSchema() XTPSchema {
 	return XTPSchema{
@@ -210,18 +470,31 @@ func (c *{{ $name }}) GetSchema() XTPSchema {
 }
 `
 
-var goPrelude = `import (
-	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
-)
-
-`
-
-var goPreludeWithFmt = `import (
-	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
-	"fmt"
-)
+// goImports are the packages imported by the generated custom types, in
+// sorted order, each with an identifier that shows it is used.
+var goImports = []struct{ path, use, comment string }{
+	{path: "encoding/json", use: "json.", comment: " // jsoniter/jsoncomp are not compatible with tinygo."},
+	{path: "errors", use: "errors.New"},
+	{path: "fmt", use: "fmt."},
+	{path: "time", use: "time.Time"},
+}
 
-`
+// goPrelude returns the import declaration of the packages used by src.
+func goPrelude(src string) string {
+	var imports []string
+	for _, imp := range goImports {
+		if strings.Contains(src, imp.use) {
+			imports = append(imports, fmt.Sprintf("%q%v", imp.path, imp.comment))
+		}
+	}
+	switch len(imports) {
+	case 0:
+		return ""
+	case 1:
+		return "import " + imports[0] + "\n\n"
+	}
+	return "import (\n\t" + strings.Join(imports, "\n\t") + "\n)\n\n"
+}
 
 var testGoPrelude = `import (
 	"testing"
@@ -233,11 +506,23 @@ var testGoPrelude = `import (
 var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
 
 func boolPtr(b bool) *bool { return &b }
+func float64Ptr(f float64) *float64 { return &f }
 func intPtr(i int) *int { return &i }
 func stringPtr(s string) *string { return &s }
 
 `
 
+// testGoFormatPtrFuncs are the pointer helpers of the generated tests
+// that are only needed by optional properties with a `format`.
+var testGoFormatPtrFuncs = []struct{ name, src string }{
+	{name: "float32Ptr", src: "func float32Ptr(f float32) *float32 { return &f }\n"},
+	{name: "int32Ptr", src: "func int32Ptr(i int32) *int32 { return &i }\n"},
+	{name: "int64Ptr", src: "func int64Ptr(i int64) *int64 { return &i }\n"},
+	{name: "timePtr", src: "func timePtr(t time.Time) *time.Time { return &t }\n"},
+}
+
+const goStringPtrFunc = "func stringPtr(s string) *string { return &s }\n"
+
 var structTestGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}func Test{{ $name }}Marshal(t *testing.T) {
   t.Parallel()
 	tests := []struct {
@@ -249,14 +534,15 @@ var structTestGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}func Test{{ $n
 			name: "required fields",
 			obj: &{{ .Name }}{
 {{range $index, $prop := .Properties}}{{if .IsRequired}}  {{ .Name | uppercaseFirst }}: {{ requiredGoValue . }},
+{{ else if .Default }}  {{ .Name | uppercaseFirst }}: {{ goDefaultValue . }},
 {{ end }}{{ end }}
 			},
-			want: ` + "`" + `{{"{"}}{{range $index, $prop := .Properties}}{{if .IsRequired}}"{{ .Name }}":{{ requiredGoJSONValue . }}{{ showJSONCommaForRequired $index $top }}{{ end }}{{ end }}{{"}"}}` + "`" + `,
+			want: ` + "`" + `{{"{"}}{{range $index, $prop := .Properties}}{{if .IsRequired}}"{{ .Name }}":{{ requiredGoJSONValue . }}{{ showJSONCommaForSetByNew $index $top }}{{ else if .Default }}"{{ .Name }}":{{ goDefaultJSON . }}{{ showJSONCommaForSetByNew $index $top }}{{ end }}{{ end }}{{"}"}}` + "`" + `,
 		},
 		{
 			name: "optional fields",
 			obj: &{{ .Name }}{
-{{range $index, $prop := .Properties}}{{ if .IsRequired | not }}  {{ .Name | uppercaseFirst }}: {{ defaultGoValue . }},
+{{range $index, $prop := .Properties}}{{ if or (not .IsRequired) (goTypeIsEnum .) }}  {{ .Name | uppercaseFirst }}: {{ defaultGoValue . }},
 {{ end }}{{ end }}
 			},
 			want: ` + "`" + `{{"{"}}{{ $propLen := .Properties | len }}{{range $index, $prop := .Properties}}"{{ .Name }}":{{ defaultGoJSONValue . $top }}{{ showJSONCommaForOptional $index $propLen }}{{ end }}{{"}"}}` + "`" + `,
@@ -274,7 +560,255 @@ var structTestGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}func Test{{ $n
 				t.Logf("got:\n%v", string(got))
 				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
 			}
+
+			var obj {{ .Name }}
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+{{- if hasDefaults $top }}
+
+func TestParse{{ $name }}Defaults(t *testing.T) {
+	t.Parallel()
+	got, err := Parse{{ $name }}(` + "`" + `{{"{"}}{{range $index, $prop := .Properties}}{{if .IsRequired}}"{{ .Name }}":{{ requiredGoJSONValue . }}{{ showJSONCommaForRequired $index $top }}{{ end }}{{ end }}{{"}"}}` + "`" + `)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := New{{ $name }}()
+{{range .Properties}}{{if .IsRequired}}	want.{{ .Name | uppercaseFirst }} = {{ requiredGoValue . }}
+{{ end }}{{ end -}}
+{{ "	if diff := cmp.Diff(want, &got); diff != \"\" {" }}
+		t.Errorf("Parse{{ $name }} mismatch (-want +got):\n%v", diff)
+	}
+}
+{{- end }}
+
+{{- if hasNestedDefaults $top }}
+
+func TestParse{{ $name }}NestedDefaults(t *testing.T) {
+	t.Parallel()
+	got, err := Parse{{ $name }}(` + "`" + `{{ nestedDefaultsGoJSON $top }}` + "`" + `)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := New{{ $name }}()
+{{ nestedDefaultsGoValues $top -}}
+{{ "	if diff := cmp.Diff(want, &got); diff != \"\" {" }}
+		t.Errorf("Parse{{ $name }} mismatch (-want +got):\n%v", diff)
+	}
+}
+{{- end }}
+
+func Test{{ $name }}Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *{{ $name }})
+		wantErr string
+	}{
+{{ goValidateTestCases $top }}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := {{ goValidTestValue $top }}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+`
+
+var unionGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}{{ $key := .Discriminator.PropertyName }}// {{ $name }} represents {{ .Description | downcaseFirst | multilineComment }}.
+// Its ` + "`Value`" + ` is one of {{ goUnionVariantList . }}, selected in JSON by
+// its "{{ $key }}" property.
+type {{ $name }} struct {
+	Value {{ $name }}Value
+}
+
+// {{ $name }}Value is implemented by the variants of ` + "`{{ $name }}`" + `.
+type {{ $name }}Value interface {
+	is{{ $name }}()
+}
+{{range .Variants}}
+func (*{{ .Name }}) is{{ $name }}() {}
+{{ end }}
+// Parse{{ $name }} parses a JSON string and returns the value.
+func Parse{{ $name }}(s string) (value {{ $name }}, err error) {
+{{ if .FastJSON }}	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+{{ else }}	if err := json.Unmarshal([]byte(s), &value); err != nil {
+{{ end }}		return value, err
+	}
+{{ if .ValidateOnParse }}	if err := value.Validate(); err != nil {
+		return value, err
+	}
+{{ end }}
+	return value, nil
+}
+
+{{ if not .FastJSON }}// MarshalJSON implements json.Marshaler by adding the "{{ $key }}" of the
+// variant to its properties.
+func (v {{ $name }}) MarshalJSON() ([]byte, error) {
+	var prefix string
+	switch v.Value.(type) {
+	case nil:
+		return []byte("null"), nil
+{{range .Variants}}	case *{{ .Name }}:
+		prefix = {{ goUnionJSONPrefix $top . }}
+{{ end -}}
+{{ "	}" }}
+
+	buf, err := json.Marshal(v.Value)
+	if err != nil || string(buf) == "null" {
+		return buf, err
+	}
+	if len(buf) > 2 {
+		prefix += ","
+	}
+	return append([]byte(prefix), buf[1:]...), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding the variant that is
+// selected by the "{{ $key }}" property.
+func (v *{{ $name }}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var discriminator struct {
+		Value *string ` + "`" + `json:"{{ $key }}"` + "`" + `
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+	if discriminator.Value == nil {
+		return errors.New(` + "`" + `{{ $name }}: missing "{{ $key }}"` + "`" + `)
+	}
+
+	switch *discriminator.Value {
+{{range .Variants}}	case {{ printf "%q" .Tag }}:
+		value := &{{ .Name }}{}
+		if err := json.Unmarshal(data, value); err != nil {
+			return err
+		}
+		v.Value = value
+{{ end -}}
+{{ "	default:" }}
+		return fmt.Errorf("{{ $name }}: unknown {{ $key }} %q", *discriminator.Value)
+	}
+	return nil
+}
+
+{{ end }}// Validate returns an error if the ` + "`{{ $name }}`" + ` has no value
+// or its value violates a constraint of its schema.
+func (v *{{ $name }}) Validate() error {
+	switch value := v.Value.(type) {
+{{range .Variants}}	case *{{ .Name }}:
+		if value != nil {
+			return value.Validate()
+		}
+{{ end -}}
+{{ "	}" }}
+	return errors.New("{{ $name }}: missing value")
+}
+`
+
+var unionTestGoTemplateStr = `{{ $name := .Name }}{{ $top := . }}{{ $key := .Discriminator.PropertyName }}func Test{{ $name }}Marshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *{{ $name }}
+		want string
+	}{
+{{range .Variants}}		{
+			name: "{{ .Name }}",
+			obj:  {{ goUnionExampleValue $top . }},
+			want: ` + "`{{ goUnionExampleJSON $top . }}`" + `,
+		},
+{{ end -}}
+{{ "	}" }}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj {{ $name }}
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
 		})
 	}
 }
+
+func TestParse{{ $name }}RejectsUnknownVariants(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name:    "missing {{ $key }}",
+			json:    ` + "`{}`" + `,
+			wantErr: ` + "`{{ $name }}: missing \"{{ $key }}\"`" + `,
+		},
+		{
+			name:    "unknown {{ $key }}",
+			json:    ` + "`{\"{{ $key }}\":\"not-a-{{ $name | downcaseFirst }}\"}`" + `,
+			wantErr: ` + "`{{ $name }}: unknown {{ $key }} \"not-a-{{ $name | downcaseFirst }}\"`" + `,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse{{ $name }}(tt.json); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Parse{{ $name }} = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test{{ $name }}Validate(t *testing.T) {
+	t.Parallel()
+
+	var obj {{ $name }}
+	if err := obj.Validate(); err == nil || err.Error() != "{{ $name }}: missing value" {
+		t.Errorf("Validate = %v, want {{ $name }}: missing value", err)
+	}
+{{range .Variants}}
+	obj.Value = {{ goValidTestValue .CustomType }}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate({{ .Name }}) = %v, want nil", err)
+	}
+{{ end }}}
 `
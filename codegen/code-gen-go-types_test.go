@@ -11,6 +11,36 @@ var wantFruitGoTypesFS embed.FS
 //go:embed testdata/user/go-types/*
 var wantUserGoTypesFS embed.FS
 
+//go:embed testdata/arrays/go-types/*
+var wantArraysGoTypesFS embed.FS
+
+//go:embed testdata/maps/go-types/*
+var wantMapsGoTypesFS embed.FS
+
+//go:embed testdata/buffers/go-types/*
+var wantBuffersGoTypesFS embed.FS
+
+//go:embed testdata/primitives/go-types/*
+var wantPrimitivesGoTypesFS embed.FS
+
+//go:embed testdata/trees/go-types/*
+var wantTreesGoTypesFS embed.FS
+
+//go:embed testdata/bounds/go-types/*
+var wantBoundsGoTypesFS embed.FS
+
+//go:embed testdata/formats/go-types/*
+var wantFormatsGoTypesFS embed.FS
+
+//go:embed testdata/defaults/go-types/*
+var wantDefaultsGoTypesFS embed.FS
+
+//go:embed testdata/unions/go-types/*
+var wantUnionsGoTypesFS embed.FS
+
+//go:embed testdata/fastjson/go-types/*
+var wantFastJSONGoTypesFS embed.FS
+
 func TestGenGoCustomTypes(t *testing.T) {
 	t.Parallel()
 
@@ -41,6 +71,138 @@ func TestGenGoCustomTypes(t *testing.T) {
 			embedFS:     wantUserGoTypesFS,
 			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
 		},
+		{
+			name:    "arrays",
+			lang:    "go",
+			pkgName: "arrays",
+			yamlStr: arraysYaml,
+			files: []string{
+				"arrays.go",
+				"arrays_test.go",
+			},
+			embedSubdir: "testdata/arrays/go-types",
+			embedFS:     wantArraysGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "maps",
+			lang:    "go",
+			pkgName: "maps",
+			yamlStr: mapsYaml,
+			files: []string{
+				"maps.go",
+				"maps_test.go",
+			},
+			embedSubdir: "testdata/maps/go-types",
+			embedFS:     wantMapsGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "buffers",
+			lang:    "go",
+			pkgName: "buffers",
+			yamlStr: buffersYaml,
+			files: []string{
+				"buffers.go",
+				"buffers_test.go",
+			},
+			embedSubdir: "testdata/buffers/go-types",
+			embedFS:     wantBuffersGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "primitives",
+			lang:    "go",
+			pkgName: "primitives",
+			yamlStr: primitivesYaml,
+			files: []string{
+				"primitives.go",
+				"primitives_test.go",
+			},
+			embedSubdir: "testdata/primitives/go-types",
+			embedFS:     wantPrimitivesGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "trees",
+			lang:    "go",
+			pkgName: "trees",
+			yamlStr: treesYaml,
+			files: []string{
+				"trees.go",
+				"trees_test.go",
+			},
+			embedSubdir: "testdata/trees/go-types",
+			embedFS:     wantTreesGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "bounds",
+			lang:    "go",
+			pkgName: "bounds",
+			yamlStr: boundsYaml,
+			opts:    &ClientOpts{Validate: true},
+			files: []string{
+				"bounds.go",
+				"bounds_test.go",
+			},
+			embedSubdir: "testdata/bounds/go-types",
+			embedFS:     wantBoundsGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "formats",
+			lang:    "go",
+			pkgName: "formats",
+			yamlStr: formatsYaml,
+			files: []string{
+				"formats.go",
+				"formats_test.go",
+			},
+			embedSubdir: "testdata/formats/go-types",
+			embedFS:     wantFormatsGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "defaults",
+			lang:    "go",
+			pkgName: "defaults",
+			yamlStr: defaultsYaml,
+			files: []string{
+				"defaults.go",
+				"defaults_test.go",
+			},
+			embedSubdir: "testdata/defaults/go-types",
+			embedFS:     wantDefaultsGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "unions",
+			lang:    "go",
+			pkgName: "unions",
+			yamlStr: unionsYaml,
+			files: []string{
+				"unions.go",
+				"unions_test.go",
+			},
+			embedSubdir: "testdata/unions/go-types",
+			embedFS:     wantUnionsGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "fastjson",
+			lang:    "go",
+			pkgName: "fastjson",
+			yamlStr: fastJSONYaml,
+			opts:    &ClientOpts{FastJSON: true},
+			files: []string{
+				"fastjson.go",
+				"fastjson_test.go",
+			},
+			embedSubdir: "testdata/fastjson/go-types",
+			embedFS:     wantFastJSONGoTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
 	}
 
 	runEmbedFSTest(t, tests)
@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
 	"strings"
@@ -9,12 +10,19 @@ import (
 )
 
 func defaultMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
+	if prop.Default != nil {
+		return mbtDefaultJSON(prop)
+	}
+
 	if prop.Ref != "" {
-		if !prop.IsRequired && prop.RefCustomType != nil {
-			// populate all the required fields recursively:
-			requiredProps := prop.RefCustomType.GetRequiredProps()
-			fields := make([]string, 0, len(requiredProps))
-			for _, p2 := range requiredProps {
+		switch {
+		case prop.RefCustomType != nil && prop.IsRequired:
+			return "null" // a struct defaults to `None` so that recursive types terminate.
+		case prop.RefCustomType != nil:
+			// populate all the fields set by `new`, which do not recurse any further:
+			props := mbtPropsSetByNew(prop.RefCustomType)
+			fields := make([]string, 0, len(props))
+			for _, p2 := range props {
 				fields = append(fields, fmt.Sprintf("%q:%v", p2.Name, defaultMbtJSONValue(p2, prop.RefCustomType)))
 			}
 			return fmt.Sprintf("{%v}", strings.Join(fields, ","))
@@ -22,7 +30,7 @@ func defaultMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
 		return fmt.Sprintf("%q", prop.FirstEnumValue)
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
@@ -38,17 +46,21 @@ func defaultMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
 	case "buffer":
 		return `""`
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
 
 func defaultMbtValue(prop *schema.Property) string {
+	if prop.Default != nil {
+		return mbtDefaultLiteral(prop)
+	}
+
 	if prop.Ref != "" {
 		// parts := strings.Split(prop.Ref, "/")
 		// refName := parts[len(parts)-1]
-		if !prop.IsRequired && prop.RefCustomType != nil {
-			return "None"
+		if prop.RefCustomType != nil {
+			return "None" // even a required struct, so that recursive types terminate.
 		}
 		if prop.FirstEnumValue != "" {
 			return uppercaseFirst(prop.FirstEnumValue)
@@ -60,7 +72,7 @@ func defaultMbtValue(prop *schema.Property) string {
 		return "None"
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
@@ -74,9 +86,9 @@ func defaultMbtValue(prop *schema.Property) string {
 	case "array":
 		return "[]"
 	case "buffer":
-		return `""`
+		return `b""`
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
@@ -84,19 +96,28 @@ func defaultMbtValue(prop *schema.Property) string {
 func getMbtType(item any) string {
 	var ref string
 	var isRequired bool
-	var itemType string
+	var itemType, format string
 	var refCustomType *schema.CustomType
+	var items, values *schema.Property
+	var pos schema.Position
 
 	switch t := item.(type) {
 	case *schema.Property:
+		pos = t.Pos
 		ref = t.Ref
 		isRequired = t.IsRequired
-		itemType = t.Type
+		itemType = propType(t)
+		format = t.Format
 		refCustomType = t.RefCustomType
+		items = t.Items
+		values = t.AdditionalProperties
 	case *schema.Output:
+		pos = t.Pos
 		ref = t.Ref
 		itemType = t.Type
 		isRequired = true
+		items = t.Items
+		values = t.AdditionalProperties
 	default:
 		log.Fatalf("getMbtType: unsupported type: %T", t)
 	}
@@ -117,91 +138,156 @@ func getMbtType(item any) string {
 
 	switch itemType {
 	case "integer":
+		if format == "int64" {
+			return "Int64" + optional
+		}
 		return "Int" + optional
 	case "string":
-		return "String" + optional
+		return "String" + optional // MoonBit core has no date-time type.
 	case "number":
+		if format == "float" {
+			return "Float" + optional
+		}
 		return "Double" + optional
 	case "boolean":
 		return "Bool" + optional
 	case "object":
-		return "{}" + optional // TODO - what should this be?
+		return "Map[String, " + mbtMapValueType(values) + "]" + optional
 	case "array":
-		return "[]" + optional // TODO - what should this be?
+		warnMissingItems(pos, items)
+		return "Array[" + mbtItemsType(items) + "]" + optional
 	case "buffer":
-		return "Buffer" + optional // TODO - what should this be?
+		return "Bytes" + optional
 	default:
-		log.Printf("WARNING: unknown property type %q", itemType)
+		warnf(pos, "unknown property type %q", itemType)
 		return itemType + optional
 	}
 }
 
+// mbtItemsType returns the MoonBit type of the elements of an array,
+// which is `Json` if the array is missing its items.
+func mbtItemsType(items *schema.Property) string {
+	if items == nil {
+		return "Json"
+	}
+
+	if items.Ref != "" {
+		parts := strings.Split(items.Ref, "/")
+		return parts[len(parts)-1]
+	}
+
+	// Elements are encoded by MoonBit core, which encodes an `Int64` as a
+	// JSON string, so the `format` of a number only applies to properties.
+	if items.Format != "" && (items.Type == "integer" || items.Type == "number") {
+		plain := *items
+		plain.Format = ""
+		return getMbtType(&plain)
+	}
+
+	return getMbtType(items)
+}
+
+// mbtMapValueType returns the MoonBit type of the values of a map.
+// An object without `additionalProperties` is a free-form map.
+func mbtMapValueType(values *schema.Property) string {
+	if values == nil {
+		return "Json"
+	}
+	return mbtItemsType(values)
+}
+
+// mbtItemsExampleValue returns an example array containing a single element
+// as both a MoonBit literal and its JSON encoding.
+func mbtItemsExampleValue(items *schema.Property) (mbtValue, jsonValue string) {
+	mbtElem, jsonElem := mbtElemExampleValue(items)
+	return fmt.Sprintf("[%v]", mbtElem), fmt.Sprintf("[%v]", jsonElem)
+}
+
+// mbtMapExampleValue returns an example map containing a single entry
+// as both a MoonBit literal and its JSON encoding.
+func mbtMapExampleValue(values *schema.Property) (mbtValue, jsonValue string) {
+	mbtElem, jsonElem := mbtElemExampleValue(values)
+	return fmt.Sprintf(`{ "key": %v }`, mbtElem), fmt.Sprintf(`{"key":%v}`, jsonElem)
+}
+
+// mbtElemExampleValue returns an example array element or map value
+// as both a MoonBit literal and its JSON encoding.
+func mbtElemExampleValue(elem *schema.Property) (mbtValue, jsonValue string) {
+	switch {
+	case elem == nil:
+		return `"item"`, `"item"`
+	case elem.Ref != "" && elem.RefCustomType != nil:
+		props := mbtPropsSetByNew(elem.RefCustomType)
+		fields := make([]string, 0, len(props))
+		for _, p2 := range props {
+			fields = append(fields, fmt.Sprintf("%q:%v", p2.Name, defaultMbtJSONValue(p2, elem.RefCustomType)))
+		}
+		return mbtItemsType(elem) + "::new()", fmt.Sprintf("{%v}", strings.Join(fields, ","))
+	case elem.Ref != "":
+		return uppercaseFirst(elem.FirstEnumValue), fmt.Sprintf("%q", elem.FirstEnumValue)
+	case propType(elem) == "array":
+		return mbtItemsExampleValue(elem.Items)
+	case propType(elem) == "object":
+		return mbtMapExampleValue(elem.AdditionalProperties)
+	case propType(elem) == "integer":
+		return "1", "1"
+	case propType(elem) == "number":
+		return "1.5", "1.5"
+	case propType(elem) == "boolean":
+		return "true", "true"
+	case propType(elem) == "string":
+		return `"item"`, `"item"`
+	case propType(elem) == "buffer":
+		return mbtBufferExampleValue("item")
+	default:
+		warnf(elem.Pos, "unknown element type %q", elem.Type)
+		return `"item"`, `"item"`
+	}
+}
+
+// mbtBufferExampleValue returns an example buffer holding s as both a MoonBit
+// literal and its JSON encoding, which is base64.
+func mbtBufferExampleValue(s string) (mbtValue, jsonValue string) {
+	return fmt.Sprintf("b%q", s), fmt.Sprintf("%q", base64.StdEncoding.EncodeToString([]byte(s)))
+}
+
 func inputToMbtType(input *schema.Input) string {
 	if input == nil {
 		return ""
 	}
+	return "input : " + inputToMbtTypeName(input)
+}
+
+func inputToMbtTypeName(input *schema.Input) string {
+	if input == nil {
+		return ""
+	}
 
 	if input.Ref != "" {
 		parts := strings.Split(input.Ref, "/")
 		refName := parts[len(parts)-1]
-		return "input : " + refName
+		return refName
 	}
 
 	switch input.Type {
 	case "integer":
-		return "input : Int"
+		return "Int"
 	case "string":
-		return "input : String"
+		return "String"
 	case "number":
-		return "input : Double"
+		return "Double"
 	case "boolean":
-		return "input : Bool"
+		return "Bool"
 	case "object":
-		return "input : {}" // TODO - what should this be?
+		return "Map[String, " + mbtMapValueType(input.AdditionalProperties) + "]"
 	case "array":
-		return "input : []" // TODO - what should this be?
+		warnMissingItems(input.Pos, input.Items)
+		return "Array[" + mbtItemsType(input.Items) + "]"
 	case "buffer":
-		return "input : Buffer" // TODO - what should this be?
-	default:
-		log.Printf("WARNING: unknown property type %q", input.Type)
-		return "input : " + input.Type
-	}
-}
-
-func jsonOutputAsMbtType(output *schema.Output) string {
-	// TODO: finish this
-	// if output.Ref != "" {
-	// 	parts := strings.Split(output.Ref, "/")
-	// 	refName := parts[len(parts)-1]
-	// 	if output.RefCustomType != nil {
-	// 		return refName + "?"
-	// 	}
-	// 	return refName
-	// }
-
-	// var optional string
-	// if !output.IsRequired {
-	// 	optional = "?"
-	// }
-
-	switch output.Type {
-	// case "integer":
-	// 	return "Int" + optional
-	// case "string":
-	// 	return "String" + optional
-	// case "number":
-	// 	return "Double" + optional
-	case "boolean":
-		return "as_bool"
-	// case "object":
-	// 	return "{}" + optional // TODO - what should this be?
-	// case "array":
-	// 	return "[]" + optional // TODO - what should this be?
-	// case "buffer":
-	// 	return "Buffer" + optional // TODO - what should this be?
+		return "Bytes"
 	default:
-		log.Printf("WARNING: unknown property type %q", output.Type)
-		return output.Type
+		warnf(input.Pos, "unknown property type %q", input.Type)
+		return input.Type
 	}
 }
 
@@ -224,7 +310,7 @@ func mbtConvertFromJSONValue(prop *schema.Property) string {
 	}
 
 	var asType string
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		asType = ".as_number()"
 	case "string":
@@ -234,12 +320,12 @@ func mbtConvertFromJSONValue(prop *schema.Property) string {
 	case "boolean":
 		asType = ".as_bool()"
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `"unknown"`
 	}
 
 	if !prop.IsRequired {
-		switch prop.Type {
+		switch propType(prop) {
 		case "integer":
 			return fmt.Sprintf(`match %v {
     Some(jv) => json_as_integer(jv)
@@ -253,7 +339,7 @@ func mbtConvertFromJSONValue(prop *schema.Property) string {
 		}
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return fmt.Sprintf("json_as_integer(%v)", valueGet)
 	default:
@@ -278,7 +364,7 @@ func mbtFromJSONMatchKey(prop *schema.Property) string {
 	// 	return "None"
 	// }
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer", "number":
 		return "Json::Number(n)"
 	case "string":
@@ -292,7 +378,7 @@ func mbtFromJSONMatchKey(prop *schema.Property) string {
 	case "buffer":
 		return `""` // TODO - what to do with this?
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
@@ -314,7 +400,7 @@ func mbtFromJSONMatchValue(prop *schema.Property) string {
 	// 	return "None"
 	// }
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "Some(n.to_int())"
 	case "string":
@@ -330,11 +416,57 @@ func mbtFromJSONMatchValue(prop *schema.Property) string {
 	case "buffer":
 		return `""` // TODO - what to do with this?
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
 
+// mbtExampleValue returns a MoonBit literal of the input or output type
+// for use in generated tests.
+func mbtExampleValue(plugin *schema.Plugin, item any) string {
+	var ref, itemType string
+	var items, values *schema.Property
+	var pos schema.Position
+	switch t := item.(type) {
+	case *schema.Input:
+		ref, itemType, items, values, pos = t.Ref, t.Type, t.Items, t.AdditionalProperties, t.Pos
+	case *schema.Output:
+		ref, itemType, items, values, pos = t.Ref, t.Type, t.Items, t.AdditionalProperties, t.Pos
+	default:
+		log.Fatalf("mbtExampleValue: unsupported type: %T", t)
+	}
+
+	if ref != "" {
+		ct := findCustomType(plugin, ref)
+		if ct == nil {
+			warnf(pos, "unknown reference %q", ref)
+			return `""`
+		}
+		if len(ct.Enum) > 0 {
+			return fmt.Sprintf("%v::%v", ct.Name, uppercaseFirst(ct.Enum[0]))
+		}
+		if len(ct.OneOf) > 0 {
+			u := newUnion(plugin, ct, false)
+			return mbtUnionExampleValue(u, u.Variants[0])
+		}
+		return ct.Name + "::new()"
+	}
+
+	switch itemType {
+	case "array":
+		mbtValue, _ := mbtItemsExampleValue(items)
+		return mbtValue
+	case "object":
+		mbtValue, _ := mbtMapExampleValue(values)
+		return mbtValue
+	case "buffer":
+		mbtValue, _ := mbtBufferExampleValue(itemType)
+		return mbtValue
+	}
+
+	return mbtTypeTestValue(pos, itemType, "", true)
+}
+
 func mbtMultilineComment(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -348,6 +480,38 @@ func mbtTypeIs(item any, name string) bool {
 	return mbtType == name
 }
 
+// mbtTypeIsNumberFormat reports whether the property is an `Int64` or a
+// `Float`, which are encoded as JSON numbers explicitly since MoonBit core
+// encodes an `Int64` as a JSON string.
+func mbtTypeIsNumberFormat(prop *schema.Property) bool {
+	switch getMbtType(prop) {
+	case "Int64", "Int64?", "Float", "Float?":
+		return true
+	}
+	return false
+}
+
+// mbtBufferElems returns "array" or "map" if the property is an array or a
+// map of `buffer` elements, which are base64 encoded in JSON, or "" if not.
+func mbtBufferElems(prop *schema.Property) string {
+	isBuffer := func(elem *schema.Property) bool {
+		return elem != nil && elem.Ref == "" && propType(elem) == "buffer"
+	}
+	switch {
+	case prop.Ref != "":
+		return ""
+	case propType(prop) == "array" && isBuffer(prop.Items):
+		return "array"
+	case propType(prop) == "object" && isBuffer(prop.AdditionalProperties):
+		return "map"
+	}
+	return ""
+}
+
+func mbtTypeIsOptionalArray(prop *schema.Property) bool {
+	return !prop.IsRequired && prop.Ref == "" && propType(prop) == "array"
+}
+
 func mbtTypeIsOptional(prop *schema.Property) bool {
 	mbtType := getMbtType(prop)
 	return strings.HasSuffix(mbtType, "?")
@@ -355,12 +519,24 @@ func mbtTypeIsOptional(prop *schema.Property) bool {
 
 // This function's output values matches the output from optionalMbtValue.
 func optionalMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
+	if prop.IsRequired && prop.Default != nil {
+		return mbtDefaultJSON(prop) // see optionalMbtValue.
+	}
+
 	if prop.Ref != "" {
-		if prop.RefCustomType != nil {
-			// populate all the required fields recursively:
-			requiredProps := prop.RefCustomType.GetRequiredProps()
-			fields := make([]string, 0, len(requiredProps))
-			for _, p2 := range requiredProps {
+		switch {
+		case prop.RefCustomType != nil && prop.IsRequired:
+			return "null" // see defaultMbtValue.
+		case prop.RefCustomType != nil:
+			// populate all the required fields, which do not recurse any further,
+			// and the optional fields with defaults set by `new`:
+			props := mbtPropsSetByNew(prop.RefCustomType)
+			fields := make([]string, 0, len(props))
+			for _, p2 := range props {
+				if !p2.IsRequired {
+					fields = append(fields, fmt.Sprintf("%q:%v", p2.Name, mbtDefaultJSON(p2)))
+					continue
+				}
 				fields = append(fields, fmt.Sprintf("%q:%v", p2.Name, optionalMbtJSONValue(p2, prop.RefCustomType)))
 			}
 			return fmt.Sprintf("{%v}", strings.Join(fields, ","))
@@ -368,7 +544,25 @@ func optionalMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
 		return fmt.Sprintf("%q", prop.FirstEnumValue)
 	}
 
-	return mbtTypeTestValue(prop.Type, prop.Name, prop.IsRequired)
+	switch {
+	case propType(prop) == "array" && prop.IsRequired:
+		return "[]"
+	case propType(prop) == "array":
+		_, jsonValue := mbtItemsExampleValue(prop.Items)
+		return jsonValue
+	case propType(prop) == "object" && prop.IsRequired:
+		return "{}"
+	case propType(prop) == "object":
+		_, jsonValue := mbtMapExampleValue(prop.AdditionalProperties)
+		return jsonValue
+	case propType(prop) == "buffer" && prop.IsRequired:
+		return `""`
+	case propType(prop) == "buffer":
+		_, jsonValue := mbtBufferExampleValue(prop.Name)
+		return jsonValue
+	}
+
+	return mbtTypeTestValue(prop.Pos, propType(prop), prop.Name, prop.IsRequired)
 }
 
 func optionalMbtMultilineComment(s string) string {
@@ -380,7 +574,7 @@ func optionalMbtMultilineComment(s string) string {
 }
 
 // If a property is required, use that type's default value, otherwise return a non-default value.
-func mbtTypeTestValue(propType, propName string, isRequired bool) string {
+func mbtTypeTestValue(pos schema.Position, propType, propName string, isRequired bool) string {
 	switch propType {
 	case "integer":
 		if isRequired {
@@ -407,44 +601,71 @@ func mbtTypeTestValue(propType, propName string, isRequired bool) string {
 	case "array":
 		return "[]" // TODO - what should this be?
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		if isRequired {
+			return `b""`
+		}
+		mbtValue, _ := mbtBufferExampleValue(propName)
+		return mbtValue
 	default:
-		log.Printf("WARNING: unknown property type %q", propType)
+		warnf(pos, "unknown property type %q", propType)
 		return fmt.Sprintf("%q", propName)
 	}
 }
 
 // This function's output values matches the output from optionalMbtJSONValue.
 func optionalMbtValue(prop *schema.Property, ct *schema.CustomType) string {
+	if prop.IsRequired && prop.Default != nil {
+		return mbtDefaultLiteral(prop) // the value set by `new`.
+	}
+
 	if prop.Ref != "" {
-		if prop.RefCustomType != nil {
-			// populate all the required fields recursively:
+		switch {
+		case prop.RefCustomType != nil && prop.IsRequired:
+			return "None" // see defaultMbtValue.
+		case prop.RefCustomType != nil:
+			// populate all the required fields, which do not recurse any further:
 			requiredProps := prop.RefCustomType.GetRequiredProps()
-			fields := make([]string, 0, len(requiredProps))
-			for _, p2 := range requiredProps {
-				fields = append(fields, fmt.Sprintf("%v: %v", p2.Name, optionalMbtValue(p2, prop.RefCustomType)))
+			fields := make([]string, 0, len(requiredProps)+1)
+			if hasOptionalFields(prop.RefCustomType) {
+				fields = append(fields, ".."+prop.RefCustomType.Name+"::new()")
 			}
-			if !prop.IsRequired {
-				return fmt.Sprintf("Some({%v})", strings.Join(fields, ","))
+			for _, p2 := range requiredProps {
+				fields = append(fields, fmt.Sprintf("%v: %v", lowerSnakeCase(p2.Name), optionalMbtValue(p2, prop.RefCustomType)))
 			}
-			return fmt.Sprintf("{%v}", strings.Join(fields, ","))
+			return fmt.Sprintf("Some({%v})", strings.Join(fields, ","))
 		}
-		return fmt.Sprintf("%q", prop.FirstEnumValue)
+		return uppercaseFirst(prop.FirstEnumValue)
+	}
+
+	switch {
+	case propType(prop) == "array" && prop.IsRequired:
+		return "[]"
+	case propType(prop) == "array":
+		mbtValue, _ := mbtItemsExampleValue(prop.Items)
+		return fmt.Sprintf("Some(%v)", mbtValue)
+	case propType(prop) == "object" && prop.IsRequired:
+		return "{}"
+	case propType(prop) == "object":
+		mbtValue, _ := mbtMapExampleValue(prop.AdditionalProperties)
+		return fmt.Sprintf("Some(%v)", mbtValue)
 	}
 
-	value := mbtTypeTestValue(prop.Type, prop.Name, prop.IsRequired)
+	value := mbtTypeTestValue(prop.Pos, propType(prop), prop.Name, prop.IsRequired)
 	if prop.IsRequired {
 		return value
 	}
 	return fmt.Sprintf("Some(%v)", value)
 }
 
-func outputToMbtExampleLiteral(output *schema.Output) string {
+func outputToMbtExampleLiteral(plugin *schema.Plugin, output *schema.Output) string {
 	if output == nil {
 		return ""
 	}
 
 	if output.Ref != "" {
+		if ct := findCustomType(plugin, output.Ref); ct != nil && len(ct.OneOf) > 0 {
+			return "\n  " + mbtExampleValue(plugin, output)
+		}
 		parts := strings.Split(output.Ref, "/")
 		refName := parts[len(parts)-1]
 		return fmt.Sprintf(`
@@ -457,19 +678,19 @@ func outputToMbtExampleLiteral(output *schema.Output) string {
 	case "integer":
 		return "\n  0"
 	case "string":
-		return `\n  ""`
+		return "\n  \"\""
 	case "number":
 		return "\n  0.0"
 	case "boolean":
 		return "\n  false"
 	case "object":
-		return "\n  {}" // TODO - what should this be?
+		return "\n  {}"
 	case "array":
-		return "\n  []" // TODO - what should this be?
+		return "\n  []"
 	case "buffer":
-		return "\n  Buffer" // TODO - what should this be?
+		return "\n  b\"\""
 	default:
-		log.Printf("WARNING: unknown property type %q", output.Type)
+		warnf(output.Pos, "unknown property type %q", output.Type)
 		return "\n  " + output.Type
 	}
 }
@@ -498,24 +719,29 @@ func outputToMbtType(output *schema.Output) string {
 	case "boolean":
 		return "Bool"
 	case "object":
-		return "{}" // TODO - what should this be?
+		return "Map[String, " + mbtMapValueType(output.AdditionalProperties) + "]"
 	case "array":
-		return "[]" // TODO - what should this be?
+		warnMissingItems(output.Pos, output.Items)
+		return "Array[" + mbtItemsType(output.Items) + "]"
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		return "Bytes"
 	default:
-		log.Printf("WARNING: unknown property type %q", output.Type)
+		warnf(output.Pos, "unknown property type %q", output.Type)
 		return output.Type
 	}
 }
 
 func requiredMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
+	if !prop.IsRequired && prop.Default != nil {
+		return mbtDefaultJSON(prop) // see requiredMbtValue.
+	}
+
 	if prop.Ref != "" {
 		if prop.RefCustomType != nil {
-			// populate all the required fields recursively:
-			requiredProps := prop.RefCustomType.GetRequiredProps()
-			fields := make([]string, 0, len(requiredProps))
-			for _, p2 := range requiredProps {
+			// populate all the fields set by `new` recursively:
+			props := mbtPropsSetByNew(prop.RefCustomType)
+			fields := make([]string, 0, len(props))
+			for _, p2 := range props {
 				// NOTE: This calls `defaultMbtJSONValue` recursively, not _THIS_ function recursively!
 				fields = append(fields, fmt.Sprintf("%q:%v", p2.Name, defaultMbtJSONValue(p2, prop.RefCustomType)))
 			}
@@ -524,7 +750,7 @@ func requiredMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
 		return fmt.Sprintf("%q", prop.FirstEnumValue)
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
@@ -534,18 +760,24 @@ func requiredMbtJSONValue(prop *schema.Property, ct *schema.CustomType) string {
 	case "boolean":
 		return "true"
 	case "object":
-		return "{}" // TODO - what should this be?
+		_, jsonValue := mbtMapExampleValue(prop.AdditionalProperties)
+		return jsonValue
 	case "array":
-		return "[]" // TODO - what should this be?
+		_, jsonValue := mbtItemsExampleValue(prop.Items)
+		return jsonValue
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		_, jsonValue := mbtBufferExampleValue(prop.Name)
+		return jsonValue
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
 
 func requiredMbtValue(prop *schema.Property) string {
+	if !prop.IsRequired && prop.Default != nil {
+		return mbtDefaultLiteral(prop) // the value decoded from JSON without it.
+	}
 	if !prop.IsRequired {
 		return "None"
 	}
@@ -559,7 +791,7 @@ func requiredMbtValue(prop *schema.Property) string {
 		return uppercaseFirst(prop.FirstEnumValue)
 	}
 
-	switch prop.Type {
+	switch propType(prop) {
 	case "integer":
 		return "0"
 	case "string":
@@ -569,13 +801,16 @@ func requiredMbtValue(prop *schema.Property) string {
 	case "boolean":
 		return "true"
 	case "object":
-		return "{}" // TODO - what should this be?
+		mbtValue, _ := mbtMapExampleValue(prop.AdditionalProperties)
+		return mbtValue
 	case "array":
-		return "[]" // TODO - what should this be?
+		mbtValue, _ := mbtItemsExampleValue(prop.Items)
+		return mbtValue
 	case "buffer":
-		return "Buffer" // TODO - what should this be?
+		mbtValue, _ := mbtBufferExampleValue(prop.Name)
+		return mbtValue
 	default:
-		log.Printf("WARNING: unknown property type %q", prop.Type)
+		warnf(prop.Pos, "unknown property type %q", prop.Type)
 		return `""`
 	}
 }
@@ -1,8 +1,59 @@
 package codegen
 
-import "errors"
+import (
+	"bytes"
+	_ "embed"
+	"text/template"
+)
+
+var (
+	mbtHostHostFunctionsTemplate   = template.Must(template.New("code-gen-mbt-host.go:mbtHostHostFunctionsTemplateStr").Funcs(funcMap).Parse(mbtHostHostFunctionsTemplateStr))
+	mbtHostPluginFunctionsTemplate = template.Must(template.New("code-gen-mbt-host.go:mbtHostPluginFunctionsTemplateStr").Funcs(funcMap).Parse(mbtHostPluginFunctionsTemplateStr))
+	mbtHostTestTemplate            = template.Must(template.New("code-gen-mbt-host.go:mbtHostTestTemplateStr").Funcs(funcMap).Parse(mbtHostTestTemplateStr))
+)
 
 // genMbtHostSDK generates Host SDK code to call the extension plugin in Mbt.
+//
+// The generated code calls the plugin through the `Runtime` trait so that
+// it can be bound to any Extism runtime or to a stub runtime in unit tests.
 func (c *Client) genMbtHostSDK() (GeneratedFiles, error) {
-	return nil, errors.New("mbt host Extism SDK does not yet exist")
+	m, err := c.GenCustomTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var pluginFunctionsStr bytes.Buffer
+	if err := mbtHostPluginFunctionsTemplate.Execute(&pluginFunctionsStr, c); err != nil {
+		return nil, err
+	}
+	var testStr bytes.Buffer
+	if err := mbtHostTestTemplate.Execute(&testStr, c); err != nil {
+		return nil, err
+	}
+
+	m["runtime.mbt"] = mbtHostRuntimeTemplateStr
+	m["plugin-functions.mbt"] = pluginFunctionsStr.String()
+	m["host_bbtest.mbt"] = testStr.String()
+
+	if len(c.Plugin.Imports) > 0 {
+		var hostFunctionsStr bytes.Buffer
+		if err := mbtHostHostFunctionsTemplate.Execute(&hostFunctionsStr, c); err != nil {
+			return nil, err
+		}
+		m["host-functions.mbt"] = hostFunctionsStr.String()
+	}
+
+	return m, nil
 }
+
+//go:embed mbt-host-runtime-template.txt
+var mbtHostRuntimeTemplateStr string
+
+//go:embed mbt-host-host-functions-template.txt
+var mbtHostHostFunctionsTemplateStr string
+
+//go:embed mbt-host-plugin-functions-template.txt
+var mbtHostPluginFunctionsTemplateStr string
+
+//go:embed mbt-host-test-template.txt
+var mbtHostTestTemplateStr string
@@ -1,20 +1,222 @@
 package codegen
 
-import "testing"
+import (
+	"embed"
+	"testing"
+)
+
+//go:embed testdata/fruit/mbt-host/*
+var wantFruitMbtHostFS embed.FS
+
+//go:embed testdata/user/mbt-host/*
+var wantUserMbtHostFS embed.FS
+
+//go:embed testdata/arrays/mbt-host/*
+var wantArraysMbtHostFS embed.FS
+
+//go:embed testdata/maps/mbt-host/*
+var wantMapsMbtHostFS embed.FS
+
+//go:embed testdata/buffers/mbt-host/*
+var wantBuffersMbtHostFS embed.FS
+
+//go:embed testdata/primitives/mbt-host/*
+var wantPrimitivesMbtHostFS embed.FS
+
+//go:embed testdata/trees/mbt-host/*
+var wantTreesMbtHostFS embed.FS
+
+//go:embed testdata/formats/mbt-host/*
+var wantFormatsMbtHostFS embed.FS
+
+//go:embed testdata/defaults/mbt-host/*
+var wantDefaultsMbtHostFS embed.FS
+
+//go:embed testdata/unions/mbt-host/*
+var wantUnionsMbtHostFS embed.FS
 
 func TestGenMbtHostSDK(t *testing.T) {
 	t.Parallel()
-	tests := []struct {
-		name string
-	}{
+	tests := []*embedFSTest{
+		{
+			name:    "fruit",
+			lang:    "mbt",
+			pkgName: "fruit",
+			yamlStr: fruitYaml,
+			files: []string{
+				"fruit.mbt",
+				"fruit_bbtest.mbt",
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/fruit/mbt-host",
+			embedFS:     wantFruitMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "user",
+			lang:    "mbt",
+			pkgName: "user",
+			yamlStr: userYaml,
+			files: []string{
+				"user.mbt",
+				"user_bbtest.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/user/mbt-host",
+			embedFS:     wantUserMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "arrays",
+			lang:    "mbt",
+			pkgName: "arrays",
+			yamlStr: arraysYaml,
+			files: []string{
+				"arrays.mbt",
+				"arrays_bbtest.mbt",
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/arrays/mbt-host",
+			embedFS:     wantArraysMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "maps",
+			lang:    "mbt",
+			pkgName: "maps",
+			yamlStr: mapsYaml,
+			files: []string{
+				"maps.mbt",
+				"maps_bbtest.mbt",
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/maps/mbt-host",
+			embedFS:     wantMapsMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "buffers",
+			lang:    "mbt",
+			pkgName: "buffers",
+			yamlStr: buffersYaml,
+			files: []string{
+				"buffers.mbt",
+				"buffers_bbtest.mbt",
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/buffers/mbt-host",
+			embedFS:     wantBuffersMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "primitives",
+			lang:    "mbt",
+			pkgName: "primitives",
+			yamlStr: primitivesYaml,
+			files: []string{
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"primitives.mbt",
+				"primitives_bbtest.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/primitives/mbt-host",
+			embedFS:     wantPrimitivesMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
 		{
-			name: "",
+			name:    "trees",
+			lang:    "mbt",
+			pkgName: "trees",
+			yamlStr: treesYaml,
+			files: []string{
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+				"trees.mbt",
+				"trees_bbtest.mbt",
+			},
+			embedSubdir: "testdata/trees/mbt-host",
+			embedFS:     wantTreesMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "formats",
+			lang:    "mbt",
+			pkgName: "formats",
+			yamlStr: formatsYaml,
+			files: []string{
+				"formats.mbt",
+				"formats_bbtest.mbt",
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/formats/mbt-host",
+			embedFS:     wantFormatsMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "defaults",
+			lang:    "mbt",
+			pkgName: "defaults",
+			yamlStr: defaultsYaml,
+			files: []string{
+				"defaults.mbt",
+				"defaults_bbtest.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+			},
+			embedSubdir: "testdata/defaults/mbt-host",
+			embedFS:     wantDefaultsMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
+		},
+		{
+			name:    "unions",
+			lang:    "mbt",
+			pkgName: "unions",
+			yamlStr: unionsYaml,
+			files: []string{
+				"host-functions.mbt",
+				"host_bbtest.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"runtime.mbt",
+				"unions.mbt",
+				"unions_bbtest.mbt",
+			},
+			embedSubdir: "testdata/unions/mbt-host",
+			embedFS:     wantUnionsMbtHostFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtHostSDK() },
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-		})
-	}
+	runEmbedFSTest(t, tests)
 }
@@ -11,6 +11,24 @@ var wantFruitMbtPluginFS embed.FS
 //go:embed testdata/user/mbt-plugin/*
 var wantUserMbtPluginFS embed.FS
 
+//go:embed testdata/buffers/mbt-plugin/*
+var wantBuffersMbtPluginFS embed.FS
+
+//go:embed testdata/primitives/mbt-plugin/*
+var wantPrimitivesMbtPluginFS embed.FS
+
+//go:embed testdata/trees/mbt-plugin/*
+var wantTreesMbtPluginFS embed.FS
+
+//go:embed testdata/formats/mbt-plugin/*
+var wantFormatsMbtPluginFS embed.FS
+
+//go:embed testdata/defaults/mbt-plugin/*
+var wantDefaultsMbtPluginFS embed.FS
+
+//go:embed testdata/unions/mbt-plugin/*
+var wantUnionsMbtPluginFS embed.FS
+
 func TestGenMbtPluginPDK(t *testing.T) {
 	t.Parallel()
 	tests := []*embedFSTest{
@@ -49,6 +67,113 @@ func TestGenMbtPluginPDK(t *testing.T) {
 			embedFS:     wantUserMbtPluginFS,
 			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
 		},
+		{
+			name:    "buffers",
+			lang:    "mbt",
+			pkgName: "buffers",
+			yamlStr: buffersYaml,
+			files: []string{
+				"buffers.mbt",
+				"build.sh",
+				"host-functions.mbt",
+				"main.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/buffers/mbt-plugin",
+			embedFS:     wantBuffersMbtPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
+		},
+		{
+			name:    "primitives",
+			lang:    "mbt",
+			pkgName: "primitives",
+			yamlStr: primitivesYaml,
+			files: []string{
+				"build.sh",
+				"host-functions.mbt",
+				"main.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"primitives.mbt",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/primitives/mbt-plugin",
+			embedFS:     wantPrimitivesMbtPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
+		},
+		{
+			name:    "trees",
+			lang:    "mbt",
+			pkgName: "trees",
+			yamlStr: treesYaml,
+			files: []string{
+				"build.sh",
+				"host-functions.mbt",
+				"main.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"trees.mbt",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/trees/mbt-plugin",
+			embedFS:     wantTreesMbtPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
+		},
+		{
+			name:    "formats",
+			lang:    "mbt",
+			pkgName: "formats",
+			yamlStr: formatsYaml,
+			files: []string{
+				"build.sh",
+				"formats.mbt",
+				"host-functions.mbt",
+				"main.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/formats/mbt-plugin",
+			embedFS:     wantFormatsMbtPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
+		},
+		{
+			name:    "defaults",
+			lang:    "mbt",
+			pkgName: "defaults",
+			yamlStr: defaultsYaml,
+			files: []string{
+				"build.sh",
+				"defaults.mbt",
+				"main.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/defaults/mbt-plugin",
+			embedFS:     wantDefaultsMbtPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
+		},
+		{
+			name:    "unions",
+			lang:    "mbt",
+			pkgName: "unions",
+			yamlStr: unionsYaml,
+			files: []string{
+				"build.sh",
+				"host-functions.mbt",
+				"main.mbt",
+				"moon.pkg.json",
+				"plugin-functions.mbt",
+				"unions.mbt",
+				"xtp.toml",
+			},
+			embedSubdir: "testdata/unions/mbt-plugin",
+			embedFS:     wantUnionsMbtPluginFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.genMbtPluginPDK() },
+		},
 	}
 
 	runEmbedFSTest(t, tests)
@@ -16,10 +16,16 @@ var (
 	enumTestMbtTemplate   = template.Must(template.New("code-gen-mbt-types.go:enumTestMbtTemplateStr").Funcs(funcMap).Parse(enumTestMbtTemplateStr))
 	structMbtTemplate     = template.Must(template.New("code-gen-mbt-types.go:structMbtTemplateStr").Funcs(funcMap).Parse(structMbtTemplateStr))
 	structTestMbtTemplate = template.Must(template.New("code-gen-mbt-types.go:structTestMbtTemplateStr").Funcs(funcMap).Parse(structTestMbtTemplateStr))
+	unionMbtTemplate      = template.Must(template.New("code-gen-mbt-types.go:unionMbtTemplateStr").Funcs(funcMap).Parse(unionMbtTemplateStr))
+	unionTestMbtTemplate  = template.Must(template.New("code-gen-mbt-types.go:unionTestMbtTemplateStr").Funcs(funcMap).Parse(unionTestMbtTemplateStr))
 )
 
 // genMbtCustomTypes generates custom types with tests for the plugin in Go.
 func (c *Client) genMbtCustomTypes() error {
+	if err := checkMbtBufferElems(c.Plugin); err != nil {
+		return err
+	}
+
 	srcBlocks, testBlocks := make([]string, 0, len(c.Plugin.CustomTypes)+1), make([]string, 0, len(c.Plugin.CustomTypes))
 
 	for _, ct := range c.Plugin.CustomTypes {
@@ -41,6 +47,16 @@ func (c *Client) genMbtCustomTypes() error {
 	if c.numStructs > 0 {
 		srcBlocks = append(srcBlocks, mbtXTPSchemaMap)
 	}
+	buffers, arrays, maps := customTypesUseBuffers(c.Plugin)
+	if buffers || arrays || maps {
+		srcBlocks = append(srcBlocks, mbtBase64Funcs)
+	}
+	if arrays {
+		srcBlocks = append(srcBlocks, mbtBase64ArrayFuncs)
+	}
+	if maps {
+		srcBlocks = append(srcBlocks, mbtBase64MapFuncs)
+	}
 
 	src := strings.Join(srcBlocks, "\n")
 	c.CustTypesFilename = fmt.Sprintf("%v.%v", c.PkgName, c.Lang)
@@ -61,6 +77,8 @@ func (c *Client) genMbtCustomType(ct *schema.CustomType) (string, error) {
 	switch {
 	case len(ct.Enum) > 0:
 		return c.genMbtEnum(ct)
+	case len(ct.OneOf) > 0:
+		return c.genMbtUnion(ct)
 	case len(ct.Properties) > 0:
 		c.numStructs++
 		return c.genMbtStruct(ct)
@@ -78,6 +96,8 @@ func (c *Client) genTestMbtCustomType(ct *schema.CustomType) (string, error) {
 	switch {
 	case len(ct.Enum) > 0:
 		return c.getTestMbtEnum(ct)
+	case len(ct.OneOf) > 0:
+		return c.genTestMbtUnion(ct)
 	case len(ct.Properties) > 0:
 		return c.genTestMbtStruct(ct)
 	default:
@@ -131,11 +151,120 @@ func (c *Client) genTestMbtStruct(ct *schema.CustomType) (string, error) {
 	return buf.String(), nil
 }
 
+// genMbtUnion generates MoonBit source code for a single oneOf custom datatype.
+func (c *Client) genMbtUnion(ct *schema.CustomType) (string, error) {
+	var buf bytes.Buffer
+	if err := unionMbtTemplate.Execute(&buf, newUnion(c.Plugin, ct, false)); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// genTestMbtUnion generates MoonBit test source code for a single oneOf custom datatype.
+func (c *Client) genTestMbtUnion(ct *schema.CustomType) (string, error) {
+	var buf bytes.Buffer
+	if err := unionTestMbtTemplate.Execute(&buf, newUnion(c.Plugin, ct, false)); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+//go:embed union-mbt-template.txt
+var unionMbtTemplateStr string
+
+//go:embed union-test-mbt-template.txt
+var unionTestMbtTemplateStr string
+
 var mbtXTPSchemaMap = "/// `XTPSchema` describes the values and types of an XTP object" + `
 /// in a language-agnostic format.
 type XTPSchema Map[String, String]
 `
 
+// customTypesUseBuffers reports whether any struct has a `buffer` property,
+// an array of buffers or a map of buffers, all of which are base64 encoded
+// in JSON.
+func customTypesUseBuffers(plugin *schema.Plugin) (buffers, arrays, maps bool) {
+	for _, ct := range plugin.CustomTypes {
+		for _, prop := range ct.Properties {
+			switch {
+			case prop.Ref == "" && propType(prop) == "buffer":
+				buffers = true
+			case mbtBufferElems(prop) == "array":
+				arrays = true
+			case mbtBufferElems(prop) == "map":
+				maps = true
+			}
+		}
+	}
+	return buffers, arrays, maps
+}
+
+// checkMbtBufferElems returns an error if `buffer` elements are nested in
+// an array or map other than directly in a struct property, since MoonBit
+// core would not encode them as base64 in JSON like Go does.
+func checkMbtBufferElems(plugin *schema.Plugin) error {
+	// hasBuffers reports whether elem is or contains buffer elements.
+	var hasBuffers func(elem *schema.Property) bool
+	hasBuffers = func(elem *schema.Property) bool {
+		switch {
+		case elem == nil || elem.Ref != "":
+			return false
+		case propType(elem) == "buffer":
+			return true
+		}
+		return hasBuffers(elem.Items) || hasBuffers(elem.AdditionalProperties)
+	}
+	unsupported := func(pos schema.Position, what string) error {
+		if pos.IsValid() {
+			return fmt.Errorf("%v: %v: buffer elements are only supported in MoonBit directly within an array or map property", pos, what)
+		}
+		return fmt.Errorf("%v: buffer elements are only supported in MoonBit directly within an array or map property", what)
+	}
+
+	for _, ct := range plugin.CustomTypes {
+		for _, prop := range ct.Properties {
+			if prop.Ref != "" || mbtBufferElems(prop) != "" {
+				continue
+			}
+			if hasBuffers(prop.Items) || hasBuffers(prop.AdditionalProperties) {
+				return unsupported(prop.Pos, fmt.Sprintf("schema %q property %q", ct.Name, prop.Name))
+			}
+		}
+	}
+
+	check := func(kind, name string, in *schema.Input, out *schema.Output) error {
+		if in != nil && in.Ref == "" && (hasBuffers(in.Items) || hasBuffers(in.AdditionalProperties)) {
+			return unsupported(in.Pos, fmt.Sprintf("%v %q input", kind, name))
+		}
+		if out != nil && out.Ref == "" && (hasBuffers(out.Items) || hasBuffers(out.AdditionalProperties)) {
+			return unsupported(out.Pos, fmt.Sprintf("%v %q output", kind, name))
+		}
+		return nil
+	}
+	for _, export := range plugin.Exports {
+		if err := check("export", export.Name, export.Input, export.Output); err != nil {
+			return err
+		}
+	}
+	for _, imp := range plugin.Imports {
+		if err := check("import", imp.Name, imp.Input, imp.Output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//go:embed mbt-base64-template.txt
+var mbtBase64Funcs string
+
+//go:embed mbt-base64-array-template.txt
+var mbtBase64ArrayFuncs string
+
+//go:embed mbt-base64-map-template.txt
+var mbtBase64MapFuncs string
+
 //go:embed struct-mbt-template.txt
 var structMbtTemplateStr string
 
@@ -3,6 +3,8 @@ package codegen
 import (
 	"embed"
 	"testing"
+
+	"github.com/gmlewis/go-xtp/schema"
 )
 
 //go:embed testdata/fruit/mbt-types/*
@@ -11,6 +13,30 @@ var wantFruitMbtTypesFS embed.FS
 //go:embed testdata/user/mbt-types/*
 var wantUserMbtTypesFS embed.FS
 
+//go:embed testdata/arrays/mbt-types/*
+var wantArraysMbtTypesFS embed.FS
+
+//go:embed testdata/maps/mbt-types/*
+var wantMapsMbtTypesFS embed.FS
+
+//go:embed testdata/buffers/mbt-types/*
+var wantBuffersMbtTypesFS embed.FS
+
+//go:embed testdata/primitives/mbt-types/*
+var wantPrimitivesMbtTypesFS embed.FS
+
+//go:embed testdata/trees/mbt-types/*
+var wantTreesMbtTypesFS embed.FS
+
+//go:embed testdata/formats/mbt-types/*
+var wantFormatsMbtTypesFS embed.FS
+
+//go:embed testdata/defaults/mbt-types/*
+var wantDefaultsMbtTypesFS embed.FS
+
+//go:embed testdata/unions/mbt-types/*
+var wantUnionsMbtTypesFS embed.FS
+
 func TestGenMbtCustomTypes(t *testing.T) {
 	t.Parallel()
 
@@ -43,7 +69,178 @@ func TestGenMbtCustomTypes(t *testing.T) {
 			embedFS:     wantUserMbtTypesFS,
 			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
 		},
+		{
+			name:    "arrays",
+			lang:    "mbt",
+			pkgName: "arrays",
+			yamlStr: arraysYaml,
+			files: []string{
+				"arrays.mbt",
+				"arrays_bbtest.mbt",
+				"moon.pkg.json",
+			},
+			embedSubdir: "testdata/arrays/mbt-types",
+			embedFS:     wantArraysMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "maps",
+			lang:    "mbt",
+			pkgName: "maps",
+			yamlStr: mapsYaml,
+			files: []string{
+				"maps.mbt",
+				"maps_bbtest.mbt",
+				"moon.pkg.json",
+			},
+			embedSubdir: "testdata/maps/mbt-types",
+			embedFS:     wantMapsMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "buffers",
+			lang:    "mbt",
+			pkgName: "buffers",
+			yamlStr: buffersYaml,
+			files: []string{
+				"buffers.mbt",
+				"buffers_bbtest.mbt",
+				"moon.pkg.json",
+			},
+			embedSubdir: "testdata/buffers/mbt-types",
+			embedFS:     wantBuffersMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "primitives",
+			lang:    "mbt",
+			pkgName: "primitives",
+			yamlStr: primitivesYaml,
+			files: []string{
+				"moon.pkg.json",
+				"primitives.mbt",
+				"primitives_bbtest.mbt",
+			},
+			embedSubdir: "testdata/primitives/mbt-types",
+			embedFS:     wantPrimitivesMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "trees",
+			lang:    "mbt",
+			pkgName: "trees",
+			yamlStr: treesYaml,
+			files: []string{
+				"moon.pkg.json",
+				"trees.mbt",
+				"trees_bbtest.mbt",
+			},
+			embedSubdir: "testdata/trees/mbt-types",
+			embedFS:     wantTreesMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "formats",
+			lang:    "mbt",
+			pkgName: "formats",
+			yamlStr: formatsYaml,
+			files: []string{
+				"formats.mbt",
+				"formats_bbtest.mbt",
+				"moon.pkg.json",
+			},
+			embedSubdir: "testdata/formats/mbt-types",
+			embedFS:     wantFormatsMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "defaults",
+			lang:    "mbt",
+			pkgName: "defaults",
+			yamlStr: defaultsYaml,
+			files: []string{
+				"defaults.mbt",
+				"defaults_bbtest.mbt",
+				"moon.pkg.json",
+			},
+			embedSubdir: "testdata/defaults/mbt-types",
+			embedFS:     wantDefaultsMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
+		{
+			name:    "unions",
+			lang:    "mbt",
+			pkgName: "unions",
+			yamlStr: unionsYaml,
+			files: []string{
+				"moon.pkg.json",
+				"unions.mbt",
+				"unions_bbtest.mbt",
+			},
+			embedSubdir: "testdata/unions/mbt-types",
+			embedFS:     wantUnionsMbtTypesFS,
+			genFunc:     func(c *Client) (GeneratedFiles, error) { return c.GenCustomTypes() },
+		},
 	}
 
 	runEmbedFSTest(t, tests)
 }
+
+func TestNewRejectsNestedMbtBufferElems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yamlStr string
+		want    string
+	}{
+		{
+			name: "array of arrays of buffers",
+			yamlStr: `version: v1-draft
+exports:
+  - name: noop
+schemas:
+  - name: Frames
+    properties:
+      - name: tiles
+        type: array
+        items:
+          type: array
+          items:
+            type: buffer
+`,
+			want: `nested.yaml:7:9: schema "Frames" property "tiles": buffer elements are only supported in MoonBit directly within an array or map property`,
+		},
+		{
+			name: "export output",
+			yamlStr: `version: v1-draft
+exports:
+  - name: frames
+    output:
+      type: array
+      items:
+        type: buffer
+      contentType: application/json
+`,
+			want: `nested.yaml:5:7: export "frames" output: buffer elements are only supported in MoonBit directly within an array or map property`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := schema.ParseNamedStr("nested.yaml", tt.yamlStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plugin.PkgName = "nested"
+
+			if _, err := New("go", plugin, nil); err != nil {
+				t.Errorf("New(go) = %v, want nil", err)
+			}
+			_, err = New("mbt", plugin, nil)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("New(mbt) err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// mergeTODO starts the comments that flag an export function whose export
+// was removed or changed in the schema. They are recomputed by every merge.
+const mergeTODO = "TODO(xtp2code):"
+
+// mergeRemovedTODO is the comment that flags an export function whose export
+// was removed from the schema. Since the plugin-functions file no longer
+// calls it after the first merge, the comment itself is what keeps the
+// function flagged by the next merges.
+const mergeRemovedTODO = "// " + mergeTODO + " this export is no longer in the schema."
+
+// userOwnedFiles are the generated scaffolding files that the user is
+// expected to edit, and which are therefore never silently overwritten.
+var userOwnedFiles = map[string]bool{
+	"build.sh":      true,
+	"main.go":       true,
+	"main.mbt":      true,
+	"moon.pkg.json": true,
+	"xtp.toml":      true,
+}
+
+// mergeFunc merges a newly generated user-owned file into the existing one,
+// given the names of its functions that implement an export of the previous
+// schema, and returns a warning for every function that it flags.
+type mergeFunc func(filename, existing, generated string, exports map[string]bool) (string, []string, error)
+
+// mergeFuncs are the mergeFunc of each user-owned file that can be merged.
+var mergeFuncs = map[string]mergeFunc{
+	"main.go":  mergeGoMain,
+	"main.mbt": mergeMbtMain,
+}
+
+var (
+	// goExportRE matches the export wrappers of plugin-functions.go.
+	goExportRE = regexp.MustCompile(`(?m)^//export (\w+)$`)
+	// mbtExportRE matches the export wrappers of plugin-functions.mbt.
+	mbtExportRE = regexp.MustCompile(`(?m)^pub fn exported_(\w+)\(`)
+)
+
+// previousExports returns the names of the functions of the main.go or
+// main.mbt at path that implement an export, as called by the export
+// wrappers of the plugin-functions file that was generated next to it for
+// the previous schema. It must be read before that file is overwritten.
+func previousExports(path string) (map[string]bool, error) {
+	var wrappers string
+	re, implName := goExportRE, uppercaseFirst
+	switch filepath.Base(path) {
+	case "main.go":
+		wrappers = "plugin-functions.go"
+	case "main.mbt":
+		wrappers, re, implName = "plugin-functions.mbt", mbtExportRE, func(name string) string { return name }
+	default:
+		return nil, nil
+	}
+
+	buf, err := os.ReadFile(filepath.Join(filepath.Dir(path), wrappers))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	exports := map[string]bool{}
+	for _, m := range re.FindAllStringSubmatch(string(buf), -1) {
+		exports[implName(m[1])] = true
+	}
+	return exports, nil
+}
+
+// isUserOwned reports whether the generated file is scaffolding that the
+// user is expected to edit, such as main.go.
+func isUserOwned(filename string) bool {
+	return userOwnedFiles[filename]
+}
+
+// textEdit replaces src[start:end] with text.
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// applyEdits applies non-overlapping edits to src.
+func applyEdits(src string, edits []textEdit) string {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, e := range edits {
+		src = src[:e.start] + e.text + src[e.end:]
+	}
+	return src
+}
+
+// mergeGoMain merges the newly generated main.go of a Go plugin into the
+// existing one: the existing functions are kept as they are, a stub for
+// each new export is added before `func main`, and an exported function
+// whose export was removed from the schema, i.e. one of the previous
+// exports or one that is already flagged as removed, or changed in the
+// schema is flagged with a TODO comment. Other
+// exported functions, such as the user's helpers, are left alone. It also
+// returns a warning for every flagged function.
+func mergeGoMain(filename, existing, generated string, exports map[string]bool) (string, []string, error) {
+	fset := token.NewFileSet()
+	oldFile, err := parser.ParseFile(fset, filename, existing, parser.ParseComments)
+	if err != nil {
+		return "", nil, err
+	}
+	newFile, err := parser.ParseFile(fset, "generated "+filename, generated, parser.ParseComments)
+	if err != nil {
+		return "", nil, err
+	}
+	offset := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	lineStart := func(src string, pos token.Pos) int { return strings.LastIndex(src[:offset(pos)], "\n") + 1 }
+
+	oldFuncs, newFuncs := goFuncDecls(oldFile), goFuncDecls(newFile)
+	var edits []textEdit
+	var warnings []string
+
+	for _, fn := range oldFuncs {
+		if !fn.Name.IsExported() {
+			continue
+		}
+		var removed bool
+		if fn.Doc != nil {
+			for _, comment := range fn.Doc.List {
+				if strings.HasPrefix(comment.Text, "// "+mergeTODO) {
+					removed = removed || comment.Text == mergeRemovedTODO
+					edits = append(edits, textEdit{start: lineStart(existing, comment.Pos()), end: offset(comment.End()) + 1})
+				}
+			}
+		}
+
+		var todo string
+		newFn := findGoFuncDecl(newFuncs, fn.Name.Name)
+		switch {
+		case newFn == nil && (exports[fn.Name.Name] || removed):
+			todo = "this export is no longer in the schema."
+		case newFn != nil && goSignature(fset, fn) != goSignature(fset, newFn):
+			todo = fmt.Sprintf("the schema now declares this export as `func %v%v`.", fn.Name.Name, goSignature(fset, newFn))
+		default:
+			continue
+		}
+		edits = append(edits, textEdit{start: lineStart(existing, fn.Pos()), end: lineStart(existing, fn.Pos()), text: "// " + mergeTODO + " " + todo + "\n"})
+		warnings = append(warnings, fmt.Sprintf("%v: func %v: %v", fset.Position(fn.Pos()), fn.Name.Name, todo))
+	}
+
+	var stubs []string
+	for _, fn := range newFuncs {
+		if fn.Name.Name == "main" || findGoFuncDecl(oldFuncs, fn.Name.Name) != nil {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		stubs = append(stubs, generated[offset(start):offset(fn.End())]+"\n\n")
+	}
+	if len(stubs) > 0 {
+		at := len(existing)
+		if main := findGoFuncDecl(oldFuncs, "main"); main != nil {
+			at = lineStart(existing, main.Pos())
+			if main.Doc != nil {
+				at = lineStart(existing, main.Doc.Pos())
+			}
+		} else {
+			stubs[0] = "\n" + stubs[0]
+		}
+		edits = append(edits, textEdit{start: at, end: at, text: strings.Join(stubs, "")})
+		edits = append(edits, missingGoImports(fset, oldFile, newFile)...)
+	}
+
+	merged, err := format.Source([]byte(applyEdits(existing, edits)))
+	if err != nil {
+		return "", nil, fmt.Errorf("%v: merge: %v", filename, err)
+	}
+	return string(merged), warnings, nil
+}
+
+// goFuncDecls returns the functions of the file that are not methods.
+func goFuncDecls(file *ast.File) []*ast.FuncDecl {
+	var funcs []*ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			funcs = append(funcs, fn)
+		}
+	}
+	return funcs
+}
+
+func findGoFuncDecl(funcs []*ast.FuncDecl, name string) *ast.FuncDecl {
+	for _, fn := range funcs {
+		if fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// goSignature returns the parameter and result types of the function,
+// e.g. "(string) bool", ignoring the names of its parameters.
+func goSignature(fset *token.FileSet, fn *ast.FuncDecl) string {
+	types := func(fields *ast.FieldList) []string {
+		var types []string
+		if fields == nil {
+			return nil
+		}
+		for _, field := range fields.List {
+			var buf bytes.Buffer
+			printer.Fprint(&buf, fset, field.Type)
+			for i := 0; i < len(field.Names) || i == 0; i++ {
+				types = append(types, buf.String())
+			}
+		}
+		return types
+	}
+
+	sig := "(" + strings.Join(types(fn.Type.Params), ", ") + ")"
+	switch results := types(fn.Type.Results); len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// missingGoImports returns the edits that add the imports of newFile that
+// oldFile lacks, after the imports of oldFile.
+func missingGoImports(fset *token.FileSet, oldFile, newFile *ast.File) []textEdit {
+	have := map[string]bool{}
+	for _, imp := range oldFile.Imports {
+		have[imp.Path.Value] = true
+	}
+	var lines string
+	for _, imp := range newFile.Imports {
+		if !have[imp.Path.Value] {
+			lines += "\nimport " + imp.Path.Value
+		}
+	}
+	if lines == "" {
+		return nil
+	}
+
+	at := oldFile.Name.End()
+	for _, decl := range oldFile.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			at = gen.End()
+		}
+	}
+	offset := fset.Position(at).Offset
+	return []textEdit{{start: offset, end: offset, text: "\n" + lines}}
+}
+
+// mbtFuncRE matches the first line of a top-level MoonBit function.
+var mbtFuncRE = regexp.MustCompile(`^(pub\s+)?fn\s+(\w+)`)
+
+// mbtFunc is a top-level function of a MoonBit source file, as line numbers.
+type mbtFunc struct {
+	name   string
+	public bool
+	// doc is the first line of the comments before the function, if any.
+	doc int
+	// header is the line that starts with "fn" or "pub fn".
+	header int
+	// end is the line of the closing brace at the start of a line.
+	end int
+	// signature is the declaration up to the opening brace, with
+	// normalized whitespace.
+	signature string
+}
+
+// mbtFuncs returns the top-level functions of the MoonBit source lines as
+// formatted by `moon fmt`.
+func mbtFuncs(lines []string) []*mbtFunc {
+	var funcs []*mbtFunc
+	for i := 0; i < len(lines); i++ {
+		m := mbtFuncRE.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		fn := &mbtFunc{name: m[2], public: m[1] != "", doc: i, header: i, end: len(lines) - 1}
+		for fn.doc > 0 && strings.HasPrefix(lines[fn.doc-1], "//") {
+			fn.doc--
+		}
+		for j := i; j < len(lines); j++ {
+			if strings.HasPrefix(lines[j], "}") || j == i && strings.HasSuffix(strings.TrimSpace(lines[j]), "}") {
+				fn.end = j
+				break
+			}
+		}
+		header := strings.Join(lines[i:fn.end+1], " ")
+		if brace := strings.Index(header, "{"); brace >= 0 {
+			header = header[:brace]
+		}
+		fn.signature = strings.Join(strings.Fields(header), " ")
+		funcs = append(funcs, fn)
+		i = fn.end
+	}
+	return funcs
+}
+
+func findMbtFunc(funcs []*mbtFunc, name string) *mbtFunc {
+	for _, fn := range funcs {
+		if fn.name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// mergeMbtMain merges the newly generated main.mbt of a MoonBit plugin into
+// the existing one, like mergeGoMain does for Go.
+func mergeMbtMain(filename, existing, generated string, exports map[string]bool) (string, []string, error) {
+	oldLines, newLines := strings.Split(existing, "\n"), strings.Split(generated, "\n")
+	oldFuncs, newFuncs := mbtFuncs(oldLines), mbtFuncs(newLines)
+
+	drop := map[int]bool{}
+	insertBefore := map[int][]string{}
+	var warnings []string
+
+	for _, fn := range oldFuncs {
+		if !fn.public {
+			continue
+		}
+		var removed bool
+		for i := fn.doc; i < fn.header; i++ {
+			if strings.HasPrefix(oldLines[i], "// "+mergeTODO) {
+				removed = removed || oldLines[i] == mergeRemovedTODO
+				drop[i] = true
+			}
+		}
+
+		var todo string
+		newFn := findMbtFunc(newFuncs, fn.name)
+		switch {
+		case newFn == nil && (exports[fn.name] || removed):
+			todo = "this export is no longer in the schema."
+		case newFn != nil && fn.signature != newFn.signature:
+			todo = fmt.Sprintf("the schema now declares this export as `%v`.", newFn.signature)
+		default:
+			continue
+		}
+		insertBefore[fn.header] = append(insertBefore[fn.header], "// "+mergeTODO+" "+todo)
+		warnings = append(warnings, fmt.Sprintf("%v:%v: fn %v: %v", filename, fn.header+1, fn.name, todo))
+	}
+
+	var stubs []string
+	for _, fn := range newFuncs {
+		if fn.name == "main" || findMbtFunc(oldFuncs, fn.name) != nil {
+			continue
+		}
+		stubs = append(stubs, newLines[fn.doc:fn.end+1]...)
+		stubs = append(stubs, "")
+	}
+	at := len(oldLines)
+	if main := findMbtFunc(oldFuncs, "main"); main != nil {
+		at = main.doc
+	} else if len(stubs) > 0 && oldLines[len(oldLines)-1] == "" {
+		at = len(oldLines) - 1
+		stubs = append([]string{""}, stubs[:len(stubs)-1]...)
+	}
+	insertBefore[at] = append(stubs, insertBefore[at]...)
+
+	var merged []string
+	for i := 0; i <= len(oldLines); i++ {
+		merged = append(merged, insertBefore[i]...)
+		if i < len(oldLines) && !drop[i] {
+			merged = append(merged, oldLines[i])
+		}
+	}
+	return strings.Join(merged, "\n"), warnings, nil
+}
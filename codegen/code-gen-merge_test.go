@@ -0,0 +1,379 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeGoMain(t *testing.T) {
+	t.Parallel()
+
+	generated := `//go:build tinygo
+
+package main
+
+import "github.com/extism/go-pdk"
+
+// Greet says hello.
+func Greet(input string) string {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Greet")
+	// TODO: fill out your implementation here
+	return ""
+}
+
+// Count counts things.
+func Count(input Fruit) int {
+	// TODO: fill out your implementation here
+	return 0
+}
+
+// Shout shouts.
+func Shout(input string) string {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Shout")
+	// TODO: fill out your implementation here
+	return ""
+}
+
+func main() {}
+`
+
+	tests := []struct {
+		name         string
+		existing     string
+		exports      map[string]bool
+		want         string
+		wantWarnings []string
+	}{
+		{
+			name:    "new, removed and changed exports",
+			exports: map[string]bool{"Greet": true, "Count": true, "Wave": true},
+			existing: `//go:build tinygo
+
+package main
+
+import "strings"
+
+// Greet says hello.
+func Greet(name string) string {
+	return "Hello, " + strings.TrimSpace(name)
+}
+
+// Count counts things.
+func Count(input string) int {
+	return len(input)
+}
+
+// Wave waves.
+func Wave() {}
+
+// Format is a helper that is not an export.
+func Format(s string) string { return s }
+
+func helper() {}
+
+func main() {}
+`,
+			want: `//go:build tinygo
+
+package main
+
+import "strings"
+
+import "github.com/extism/go-pdk"
+
+// Greet says hello.
+func Greet(name string) string {
+	return "Hello, " + strings.TrimSpace(name)
+}
+
+// Count counts things.
+// TODO(xtp2code): the schema now declares this export as ` + "`func Count(Fruit) int`" + `.
+func Count(input string) int {
+	return len(input)
+}
+
+// Wave waves.
+// TODO(xtp2code): this export is no longer in the schema.
+func Wave() {}
+
+// Format is a helper that is not an export.
+func Format(s string) string { return s }
+
+func helper() {}
+
+// Shout shouts.
+func Shout(input string) string {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Shout")
+	// TODO: fill out your implementation here
+	return ""
+}
+
+func main() {}
+`,
+			wantWarnings: []string{
+				"main.go:13:1: func Count: the schema now declares this export as `func Count(Fruit) int`.",
+				"main.go:18:1: func Wave: this export is no longer in the schema.",
+			},
+		},
+		{
+			name: "exported helpers without previous exports",
+			existing: `package main
+
+func Greet(input string) string { return input }
+
+func Count(input Fruit) int { return 1 }
+
+func Shout(s string) string { return s }
+
+// Wave is a helper that is not an export.
+func Wave() {}
+`,
+			want: `package main
+
+func Greet(input string) string { return input }
+
+func Count(input Fruit) int { return 1 }
+
+func Shout(s string) string { return s }
+
+// Wave is a helper that is not an export.
+func Wave() {}
+`,
+		},
+		{
+			name:    "stale TODO comments are removed",
+			exports: map[string]bool{"Greet": true, "Count": true, "Shout": true},
+			existing: `package main
+
+import "github.com/extism/go-pdk"
+
+// Greet says hello.
+// TODO(xtp2code): this export is no longer in the schema.
+func Greet(input string) string {
+	pdk.Log(pdk.LogInfo, input)
+	return input
+}
+
+// TODO(xtp2code): the schema now declares this export as ` + "`func Count(Fruit) int`" + `.
+func Count(input Fruit) int { return 1 }
+
+func Shout(s string) string { return s }
+`,
+			want: `package main
+
+import "github.com/extism/go-pdk"
+
+// Greet says hello.
+func Greet(input string) string {
+	pdk.Log(pdk.LogInfo, input)
+	return input
+}
+
+func Count(input Fruit) int { return 1 }
+
+func Shout(s string) string { return s }
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings, err := mergeGoMain("main.go", tt.existing, generated, tt.exports)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mergeGoMain mismatch (-want +got):\n%v", diff)
+			}
+			if diff := cmp.Diff(tt.wantWarnings, warnings); diff != "" {
+				t.Errorf("mergeGoMain warnings mismatch (-want +got):\n%v", diff)
+			}
+
+			// The next merge only sees the exports of the plugin-functions.go
+			// that was generated with the merged main.go.
+			again, _, err := mergeGoMain("main.go", got, generated, map[string]bool{"Greet": true, "Count": true, "Shout": true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, again); diff != "" {
+				t.Errorf("mergeGoMain is not idempotent (-first +second):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestMergeMbtMain(t *testing.T) {
+	t.Parallel()
+
+	generated := `/// ` + "`greet`" + ` - Says hello.
+pub fn greet(input : String) -> String {
+  // TODO: fill out your implementation here
+  ""
+}
+
+/// ` + "`shout`" + ` - Shouts.
+pub fn shout(input : String) -> String {
+  // TODO: fill out your implementation here
+  ""
+}
+
+fn main {
+
+}
+`
+
+	existing := `/// ` + "`greet`" + ` - Says hello.
+pub fn greet(name : String) -> String {
+  "Hello, " + name
+}
+
+/// ` + "`wave`" + ` - Waves.
+pub fn wave() -> Unit {
+  println("wave")
+}
+
+fn helper() -> Unit {
+
+}
+
+/// A helper that is not an export.
+pub fn format(s : String) -> String {
+  s
+}
+
+fn main {
+
+}
+`
+
+	want := `/// ` + "`greet`" + ` - Says hello.
+// TODO(xtp2code): the schema now declares this export as ` + "`pub fn greet(input : String) -> String`" + `.
+pub fn greet(name : String) -> String {
+  "Hello, " + name
+}
+
+/// ` + "`wave`" + ` - Waves.
+// TODO(xtp2code): this export is no longer in the schema.
+pub fn wave() -> Unit {
+  println("wave")
+}
+
+fn helper() -> Unit {
+
+}
+
+/// A helper that is not an export.
+pub fn format(s : String) -> String {
+  s
+}
+
+/// ` + "`shout`" + ` - Shouts.
+pub fn shout(input : String) -> String {
+  // TODO: fill out your implementation here
+  ""
+}
+
+fn main {
+
+}
+`
+
+	exports := map[string]bool{"greet": true, "wave": true}
+	got, warnings, err := mergeMbtMain("main.mbt", existing, generated, exports)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeMbtMain mismatch (-want +got):\n%v", diff)
+	}
+	wantWarnings := []string{
+		"main.mbt:2: fn greet: the schema now declares this export as `pub fn greet(input : String) -> String`.",
+		"main.mbt:7: fn wave: this export is no longer in the schema.",
+	}
+	if diff := cmp.Diff(wantWarnings, warnings); diff != "" {
+		t.Errorf("mergeMbtMain warnings mismatch (-want +got):\n%v", diff)
+	}
+
+	again, _, err := mergeMbtMain("main.mbt", got, generated, map[string]bool{"greet": true, "shout": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, again); diff != "" {
+		t.Errorf("mergeMbtMain is not idempotent (-first +second):\n%v", diff)
+	}
+}
+
+func TestWriteSrcFilesMerge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"main.go":             "package main\n\nfunc Greet(input string) string { return input }\n\nfunc Shout(input string) string { return input }\n\nfunc Format(s string) string { return s }\n\nfunc main() {}\n",
+		"plugin-functions.go": "package main\n\n//export greet\nfunc greet() int { return 0 }\n\n//export shout\nfunc shout() int { return 0 }\n",
+		"xtp.toml":            "app_id = \"app_123\"\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &Client{opts: ClientOpts{Merge: true, Quiet: true}}
+	if err := c.writeSrcFiles(dir, GeneratedFiles{
+		"main.go":             "package main\n\nfunc Greet(input string) string {\n\treturn \"\"\n}\n\nfunc Wave() {}\n\nfunc main() {}\n",
+		"plugin-functions.go": "package main\n\n// new\n",
+		"xtp.toml":            "app_id = \"\"\n",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"main.go":             "package main\n\nfunc Greet(input string) string { return input }\n\n// TODO(xtp2code): this export is no longer in the schema.\nfunc Shout(input string) string { return input }\n\nfunc Format(s string) string { return s }\n\nfunc Wave() {}\n\nfunc main() {}\n",
+		"plugin-functions.go": "package main\n\n// new\n",
+		"xtp.toml":            "app_id = \"app_123\"\n",
+	}
+	for name, want := range want {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("%v mismatch (-want +got):\n%v", name, diff)
+		}
+	}
+}
+
+func TestWriteSrcFilesMergeTwice(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"main.go":             "package main\n\nfunc Greet(input string) string { return input }\n\nfunc VoidFunc() {}\n\nfunc main() {}\n",
+		"plugin-functions.go": "package main\n\n//export greet\nfunc greet() int { return 0 }\n\n//export voidFunc\nfunc voidFunc() int { return 0 }\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &Client{opts: ClientOpts{Merge: true, Quiet: true}}
+	generated := GeneratedFiles{
+		"main.go":             "package main\n\nfunc Greet(input string) string {\n\treturn \"\"\n}\n\nfunc main() {}\n",
+		"plugin-functions.go": "package main\n\n//export greet\nfunc greet() int { return 0 }\n",
+	}
+	want := "package main\n\nfunc Greet(input string) string { return input }\n\n// TODO(xtp2code): this export is no longer in the schema.\nfunc VoidFunc() {}\n\nfunc main() {}\n"
+	for i := 1; i <= 2; i++ {
+		if err := c.writeSrcFiles(dir, generated); err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, "main.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("main.go after merge %v mismatch (-want +got):\n%v", i, diff)
+		}
+	}
+}
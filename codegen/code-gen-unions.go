@@ -0,0 +1,187 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+// union is the data of the templates of a `oneOf` custom type.
+type union struct {
+	*schema.CustomType
+	Variants []*unionVariant
+	// ValidateOnParse causes the generated Go Parse<Name> to call Validate.
+	ValidateOnParse bool
+	// FastJSON causes the generated Go code to encode and decode the union
+	// without reflection.
+	FastJSON bool
+}
+
+// unionVariant is a variant of a `oneOf` custom type.
+type unionVariant struct {
+	*schema.CustomType
+	// Tag is the value of the discriminator property that selects the variant.
+	Tag string
+}
+
+// newUnion resolves the variants of the `oneOf` custom type,
+// which have been checked by checkUnions.
+func newUnion(plugin *schema.Plugin, ct *schema.CustomType, validateOnParse bool) *union {
+	u := &union{CustomType: ct, ValidateOnParse: validateOnParse}
+	for _, variant := range ct.OneOf {
+		u.Variants = append(u.Variants, &unionVariant{
+			CustomType: findCustomType(plugin, variant.Ref),
+			Tag:        ct.Discriminator.Value(variant.Ref),
+		})
+	}
+	return u
+}
+
+// checkUnions returns an error if a `oneOf` custom type cannot be generated,
+// or if it is referenced other than by the input or output of an export or
+// import, which is not yet supported.
+func checkUnions(plugin *schema.Plugin) error {
+	isUnion := map[string]bool{}
+	for _, ct := range plugin.CustomTypes {
+		if len(ct.OneOf) == 0 {
+			continue
+		}
+		isUnion[ct.Name] = true
+
+		if ct.Discriminator == nil {
+			return positionedErrorf(ct.Pos, "schema %q: a oneOf schema must have a discriminator", ct.Name)
+		}
+		for _, variant := range ct.OneOf {
+			if vt := findCustomType(plugin, variant.Ref); vt == nil || len(vt.Properties) == 0 {
+				return positionedErrorf(variant.Pos, "schema %q: oneOf variant %q is not a schema with properties", ct.Name, variant.Ref)
+			}
+		}
+	}
+	if len(isUnion) == 0 {
+		return nil
+	}
+
+	var check func(prop *schema.Property, where string) error
+	check = func(prop *schema.Property, where string) error {
+		if prop == nil {
+			return nil
+		}
+		if name := refName(prop.Ref); prop.Ref != "" && isUnion[name] {
+			return positionedErrorf(prop.Pos, "%v: the oneOf schema %q can only be the input or output of an export or import", where, name)
+		}
+		if err := check(prop.Items, where); err != nil {
+			return err
+		}
+		return check(prop.AdditionalProperties, where)
+	}
+
+	for _, ct := range plugin.CustomTypes {
+		for _, prop := range ct.Properties {
+			if err := check(prop, fmt.Sprintf("schema %q property %q", ct.Name, prop.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, export := range plugin.Exports {
+		if in := export.Input; in != nil {
+			if err := check(&schema.Property{Items: in.Items, AdditionalProperties: in.AdditionalProperties}, fmt.Sprintf("export %q input", export.Name)); err != nil {
+				return err
+			}
+		}
+		if out := export.Output; out != nil {
+			if err := check(&schema.Property{Items: out.Items, AdditionalProperties: out.AdditionalProperties}, fmt.Sprintf("export %q output", export.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, imp := range plugin.Imports {
+		if in := imp.Input; in != nil {
+			if err := check(&schema.Property{Items: in.Items, AdditionalProperties: in.AdditionalProperties}, fmt.Sprintf("import %q input", imp.Name)); err != nil {
+				return err
+			}
+		}
+		if out := imp.Output; out != nil {
+			if err := check(&schema.Property{Items: out.Items, AdditionalProperties: out.AdditionalProperties}, fmt.Sprintf("import %q output", imp.Name)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// positionedErrorf returns an error prefixed by the position, if known.
+func positionedErrorf(pos schema.Position, format string, args ...any) error {
+	if pos.IsValid() {
+		return fmt.Errorf("%v: %v", pos, fmt.Sprintf(format, args...))
+	}
+	return fmt.Errorf(format, args...)
+}
+
+func refName(ref string) string {
+	parts := strings.Split(ref, "/")
+	return parts[len(parts)-1]
+}
+
+// unionJSONPrefix returns the start of the JSON object of the variant,
+// which holds its discriminator property, without the closing brace.
+func unionJSONPrefix(u *union, v *unionVariant) string {
+	name, _ := json.Marshal(u.Discriminator.PropertyName)
+	tag, _ := json.Marshal(v.Tag)
+	return fmt.Sprintf("{%s:%s", name, tag)
+}
+
+// withUnionJSONPrefix returns the JSON object of the variant with its
+// discriminator property added before its other properties.
+func withUnionJSONPrefix(u *union, v *unionVariant, obj string) string {
+	if obj == "{}" {
+		return unionJSONPrefix(u, v) + "}"
+	}
+	return unionJSONPrefix(u, v) + "," + strings.TrimPrefix(obj, "{")
+}
+
+// goUnionJSONPrefix returns unionJSONPrefix as a Go string literal.
+func goUnionJSONPrefix(u *union, v *unionVariant) string {
+	return goStringLiteral(unionJSONPrefix(u, v))
+}
+
+// goUnionVariantList returns the Go types of the variants of the union
+// for use in doc comments, e.g. "`*A`, `*B` or `*C`".
+func goUnionVariantList(u *union) string {
+	names := make([]string, 0, len(u.Variants))
+	for _, v := range u.Variants {
+		names = append(names, "`*"+v.Name+"`")
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// goUnionExampleValue returns a Go literal of the union holding the zero
+// value of the variant for use in generated tests.
+func goUnionExampleValue(u *union, v *unionVariant) string {
+	return fmt.Sprintf("&%v{Value: %v}", u.Name, zeroGoStructPtr(v.CustomType))
+}
+
+// goUnionExampleJSON returns the JSON encoding of goUnionExampleValue.
+func goUnionExampleJSON(u *union, v *unionVariant) string {
+	return withUnionJSONPrefix(u, v, zeroGoStructJSONValue(v.CustomType))
+}
+
+// mbtUnionExampleValue returns a MoonBit literal of the union holding the
+// default value of the variant for use in generated tests.
+func mbtUnionExampleValue(u *union, v *unionVariant) string {
+	return fmt.Sprintf("%v::%v(%v::new())", u.Name, v.Name, v.Name)
+}
+
+// mbtUnionExampleJSON returns the JSON encoding of mbtUnionExampleValue
+// as stringified by MoonBit.
+func mbtUnionExampleJSON(u *union, v *unionVariant) string {
+	var fields []string
+	for _, prop := range mbtPropsSetByNew(v.CustomType) {
+		fields = append(fields, fmt.Sprintf("%q:%v", prop.Name, defaultMbtJSONValue(prop, v.CustomType)))
+	}
+	return withUnionJSONPrefix(u, v, "{"+strings.Join(fields, ",")+"}")
+}
@@ -0,0 +1,71 @@
+package codegen
+
+import (
+	"testing"
+
+	"github.com/gmlewis/go-xtp/schema"
+)
+
+func TestNewRejectsNestedUnion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		export string
+		prop   string
+		want   string
+	}{
+		{
+			name:   "property",
+			export: "input: {$ref: '#/schemas/S'}",
+			prop:   "{name: p, $ref: '#/schemas/U'}",
+			want:   `schema.yaml:18:9: schema "S" property "p": the oneOf schema "U" can only be the input or output of an export or import`,
+		},
+		{
+			name:   "array property",
+			export: "input: {$ref: '#/schemas/S'}",
+			prop:   "{name: p, type: array, items: {$ref: '#/schemas/U'}}",
+			want:   `schema.yaml:18:39: schema "S" property "p": the oneOf schema "U" can only be the input or output of an export or import`,
+		},
+		{
+			name:   "array output",
+			export: "output: {type: array, items: {$ref: '#/schemas/U'}}",
+			prop:   "{name: p, type: string}",
+			want:   `schema.yaml:4:34: export "run" output: the oneOf schema "U" can only be the input or output of an export or import`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := schema.ParseNamedStr("schema.yaml", `version: v1-draft
+exports:
+  - name: run
+    `+tt.export+`
+schemas:
+  - name: A
+    properties:
+      - {name: a, type: string}
+  - name: U
+    oneOf:
+      - $ref: '#/schemas/A'
+    discriminator:
+      propertyName: kind
+  - name: S
+    properties:
+      - name: q
+        type: string
+      - `+tt.prop+`
+`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			plugin.PkgName = "unions"
+
+			for _, lang := range []string{"go", "mbt"} {
+				if _, err := New(lang, plugin, nil); err == nil || err.Error() != tt.want {
+					t.Errorf("New(%q) err = %v, want %q", lang, err, tt.want)
+				}
+			}
+		})
+	}
+}
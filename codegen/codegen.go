@@ -4,6 +4,8 @@ package codegen
 
 import (
 	"errors"
+	"fmt"
+	"io"
 
 	"github.com/gmlewis/go-xtp/schema"
 )
@@ -16,8 +18,37 @@ var (
 type ClientOpts struct {
 	// Force causes existing file to be overwritten.
 	Force bool
+	// Merge causes the generated files to be refreshed while keeping the
+	// user's code: main.go and main.mbt keep their functions, get stubs for
+	// new exports and have a TODO comment added to the functions of removed
+	// or changed exports, and other user-owned scaffolding (such as build.sh)
+	// is kept unless Force is also set.
+	Merge bool
 	// Quiet prevents warning messages from being printed
 	Quiet bool
+	// Validate causes the generated Go code to call the `Validate` method of
+	// every struct that it decodes, so that both plugins and hosts reject
+	// values that violate the constraints of the schema.
+	Validate bool
+	// FastJSON causes the generated Go types to implement json.Marshaler and
+	// json.Unmarshaler without reflection, which is faster and makes smaller
+	// TinyGo binaries than encoding/json.
+	FastJSON bool
+	// DryRun causes no file to be written: instead, every generated file is
+	// listed as new, changed, unchanged or skipped, followed by the unified
+	// diff of each new, changed or skipped file, and Client.Differs reports
+	// whether any generated file differs from the file on disk.
+	DryRun bool
+	// Check causes no file to be written: instead, every generated file
+	// that is missing or stale is reported, and Client.Differs reports
+	// whether any was found. A purely generated file is stale unless it is
+	// identical to the generated code, while user-owned scaffolding only
+	// has to exist, and main.go or main.mbt has to declare the exports of
+	// the schema.
+	Check bool
+	// DryRunOutput receives the report of DryRun or Check. It defaults to
+	// os.Stdout.
+	DryRunOutput io.Writer
 }
 
 // Client represents a codegen client.
@@ -35,6 +66,7 @@ type Client struct {
 	// internal fields used by the code generator:
 	opts       ClientOpts
 	numStructs int
+	differs    bool
 }
 
 // New returns a new codegen `Client` for either "go" or "mbt" and the
@@ -53,6 +85,19 @@ func New(language string, plugin *schema.Plugin, opts *ClientOpts) (*Client, err
 		return nil, errors.New("plugin.PkgName must be provided")
 	}
 
+	if err := checkContentTypes(plugin); err != nil {
+		return nil, err
+	}
+	if err := checkRefCycles(plugin); err != nil {
+		return nil, err
+	}
+	if err := checkDefaults(plugin); err != nil {
+		return nil, err
+	}
+	if err := checkUnions(plugin); err != nil {
+		return nil, err
+	}
+
 	c := &Client{
 		PkgName: plugin.PkgName,
 		Lang:    language,
@@ -75,3 +120,19 @@ func New(language string, plugin *schema.Plugin, opts *ClientOpts) (*Client, err
 
 	return c, nil
 }
+
+// checkRefCycles returns an error if any custom type is part of a cycle of
+// required references, since it has no finite value to generate.
+func checkRefCycles(plugin *schema.Plugin) error {
+	for _, ct := range plugin.CustomTypes {
+		cycle := ct.RequiredRefCycle()
+		if cycle == nil {
+			continue
+		}
+		if pos := cycle[0].Pos; pos.IsValid() {
+			return fmt.Errorf("%v: schema %q has a required reference cycle %v; make one of these properties optional", pos, ct.Name, cycle)
+		}
+		return fmt.Errorf("schema %q has a required reference cycle %v; make one of these properties optional", ct.Name, cycle)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gmlewis/go-xtp/schema"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewRejectsRequiredRefCycle(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := schema.ParseNamedStr("schema.yaml", `version: v1-draft
+exports:
+  - name: walk
+    input:
+      $ref: '#/schemas/Node'
+schemas:
+  - name: Node
+    required:
+      - parent
+    properties:
+      - name: parent
+        $ref: '#/schemas/Node'
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin.PkgName = "nodes"
+
+	want := `schema.yaml:11:9: schema "Node" has a required reference cycle Node.parent -> Node; make one of these properties optional`
+	for _, lang := range []string{"go", "mbt"} {
+		if _, err := New(lang, plugin, nil); err == nil || err.Error() != want {
+			t.Errorf("New(%q) err = %v, want %q", lang, err, want)
+		}
+	}
+}
+
+func TestWarningsArePositioned(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	plugin, err := schema.ParseNamedStr("schema.yaml", `version: v1-draft
+exports:
+  - name: tally
+    input:
+      type: array
+      contentType: application/json
+    output:
+      type: decimal
+      contentType: application/json
+schemas:
+  - name: Box
+    properties:
+      - name: size
+        type: decimal
+      - name: tags
+        type: array
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin.PkgName = "box"
+
+	for _, lang := range []string{"go", "mbt"} {
+		c, err := New(lang, plugin, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GenHostSDK(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.GenPluginPDK(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		_, warning, _ := strings.Cut(line, "WARNING: ")
+		got[warning] = true
+	}
+	want := map[string]bool{
+		`schema.yaml:5:7: array is missing its items`:       true,
+		`schema.yaml:8:7: unknown property type "decimal"`:  true,
+		`schema.yaml:13:9: unknown property type "decimal"`: true,
+		`schema.yaml:15:9: array is missing its items`:      true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("warnings mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestGoldenGoFilesAreFormatted(t *testing.T) {
+	t.Parallel()
+
+	err := filepath.WalkDir("testdata", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		got, err := format.Source(src)
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+		if !bytes.Equal(got, src) {
+			t.Errorf("%v is not gofmt-formatted", path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenGoCustomTypesWithoutCustomTypes(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := schema.ParseNamedStr("schema.yaml", "version: v1-draft\nexports:\n  - name: noop\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin.PkgName = "noop"
+
+	c, err := New("go", plugin, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "// The schema has no custom types.\n"; c.CustTypes != want {
+		t.Errorf("CustTypes = %q, want %q", c.CustTypes, want)
+	}
+}
@@ -0,0 +1,188 @@
+// Package arrays represents the custom datatypes for an XTP Extension Plugin.
+package arrays
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// Color represents a color.
+type Color string
+
+const (
+	ColorEnumRed   Color = "red"
+	ColorEnumGreen Color = "green"
+	ColorEnumBlue  Color = "blue"
+)
+
+// AllColor returns all the values of `Color` in the order of its schema.
+func AllColor() []Color {
+	return []Color{
+		ColorEnumRed,
+		ColorEnumGreen,
+		ColorEnumBlue,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Color`.
+func (v Color) IsValid() bool {
+	switch v {
+	case ColorEnumRed, ColorEnumGreen, ColorEnumBlue:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Color) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Color) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Color) UnmarshalText(text []byte) error {
+	value, err := ColorFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Color) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// ColorFromString returns the `Color` with the given (unquoted) value.
+func ColorFromString(s string) (Color, error) {
+	if v := Color(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Color: %q", s)
+}
+
+// ParseColor parses a JSON string and returns the value.
+func ParseColor(s string) (value Color, err error) {
+	switch s {
+	case `"red"`:
+		return ColorEnumRed, nil
+	case `"green"`:
+		return ColorEnumGreen, nil
+	case `"blue"`:
+		return ColorEnumBlue, nil
+	default:
+		return value, fmt.Errorf("not a Color: %v", s)
+	}
+}
+
+// Point represents a point in 2D space.
+type Point struct {
+	// The X coordinate
+	X int `json:"x"`
+	// The Y coordinate
+	Y int `json:"y"`
+}
+
+// NewPoint returns a new `Point` with the default values of its schema.
+func NewPoint() *Point {
+	return &Point{}
+}
+
+// ParsePoint parses a JSON string and returns the value.
+func ParsePoint(s string) (value Point, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Point` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Point) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Point`.
+func (c *Point) GetSchema() XTPSchema {
+	return XTPSchema{
+		"x": "integer",
+		"y": "integer",
+	}
+}
+
+// Shape represents a shape made of points.
+type Shape struct {
+	// The name of the shape
+	Name string `json:"name"`
+	// The vertices of the shape
+	Points []Point `json:"points"`
+	// The colors of the shape
+	Colors []Color  `json:"colors,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+	// A transformation matrix
+	Matrix [][]float64 `json:"matrix,omitempty"`
+	// The rendered thumbnails of the shape
+	Thumbnails [][]byte `json:"thumbnails,omitempty"`
+}
+
+// NewShape returns a new `Shape` with the default values of its schema.
+func NewShape() *Shape {
+	return &Shape{}
+}
+
+// ParseShape parses a JSON string and returns the value.
+func ParseShape(s string) (value Shape, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Shape` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Shape) Validate() error {
+	if c.Points == nil {
+		return fmt.Errorf("points: required")
+	}
+	for i, v := range c.Points {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("points[%v].%w", i, err)
+		}
+	}
+	for i, v := range c.Colors {
+		if !v.IsValid() {
+			return fmt.Errorf("colors[%v]: %q is not a valid Color", i, v)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Shape`.
+func (c *Shape) GetSchema() XTPSchema {
+	return XTPSchema{
+		"name":       "string",
+		"points":     "Array<Point>",
+		"colors":     "?Array<Color>",
+		"tags":       "?Array<string>",
+		"matrix":     "?Array<Array<number>>",
+		"thumbnails": "?Array<buffer>",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -0,0 +1,64 @@
+package arrays
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// SortTags - Sorts a list of tags.
+func (p *Plugin) SortTags(ctx context.Context, input []string) (output []string, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("sortTags: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "sortTags", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("sortTags: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("sortTags: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("sortTags: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// ShapesByColor - Returns all the shapes having the given color.
+func (p *Plugin) ShapesByColor(ctx context.Context, input Color) (output []Shape, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("shapesByColor: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "shapesByColor", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("shapesByColor: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("shapesByColor: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("shapesByColor: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,250 @@
+package arrays
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+
+	color := ColorEnumRed
+	buf, err := jsoncomp.Marshal(color)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"red"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseColor(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != color {
+		t.Errorf("ParseColor = '%v', want '%v'", got, color)
+	}
+}
+
+func TestColorValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllColor() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := ColorFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("ColorFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Color
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllColor()), 3; got != want {
+		t.Errorf("len(AllColor()) = %v, want %v", got, want)
+	}
+}
+
+func TestColorRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Color("not-a-color")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := ColorFromString(unknown.String()); err == nil {
+		t.Errorf("ColorFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Color
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-color"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
+func TestPointMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Point
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Point{
+				X: 0,
+				Y: 0,
+			},
+			want: `{"x":0,"y":0}`,
+		},
+		{
+			name: "optional fields",
+			obj:  &Point{},
+			want: `{"x":0,"y":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Point
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestPointValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Point)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Point) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Point{
+				X: 0,
+				Y: 0,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShapeMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Shape
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Shape{
+				Name:   "name",
+				Points: []Point{Point{}},
+			},
+			want: `{"name":"name","points":[{"x":0,"y":0}]}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Shape{
+				Colors:     []Color{ColorEnumRed},
+				Tags:       []string{"item"},
+				Matrix:     [][]float64{[]float64{1.5}},
+				Thumbnails: [][]byte{[]byte("item")},
+			},
+			want: `{"name":"","points":null,"colors":["red"],"tags":["item"],"matrix":[[1.5]],"thumbnails":["aXRlbQ=="]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Shape
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestShapeValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Shape)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Shape) {}},
+		{name: "missing points", modify: func(v *Shape) { v.Points = nil }, wantErr: "points: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Shape{
+				Name:   "name",
+				Points: []Point{},
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
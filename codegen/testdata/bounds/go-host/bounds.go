@@ -0,0 +1,152 @@
+// Package bounds represents the custom datatypes for an XTP Extension Plugin.
+package bounds
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// Size represents the size of an image.
+type Size struct {
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Scale  *float64 `json:"scale,omitempty"`
+}
+
+// NewSize returns a new `Size` with the default values of its schema.
+func NewSize() *Size {
+	return &Size{}
+}
+
+// ParseSize parses a JSON string and returns the value.
+func ParseSize(s string) (value Size, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+	if err := value.Validate(); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Size` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Size) Validate() error {
+	if c.Width < 1 {
+		return fmt.Errorf("width: %v is less than minimum 1", c.Width)
+	}
+	if c.Width > 10000 {
+		return fmt.Errorf("width: %v is greater than maximum 10000", c.Width)
+	}
+	if c.Height < 1 {
+		return fmt.Errorf("height: %v is less than minimum 1", c.Height)
+	}
+	if c.Height > 10000 {
+		return fmt.Errorf("height: %v is greater than maximum 10000", c.Height)
+	}
+	if c.Scale != nil && *c.Scale < 0.5 {
+		return fmt.Errorf("scale: %v is less than minimum 0.5", *c.Scale)
+	}
+	if c.Scale != nil && *c.Scale > 4 {
+		return fmt.Errorf("scale: %v is greater than maximum 4", *c.Scale)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Size`.
+func (c *Size) GetSchema() XTPSchema {
+	return XTPSchema{
+		"width":  "integer",
+		"height": "integer",
+		"scale":  "?number",
+	}
+}
+
+// Image represents an image.
+type Image struct {
+	Name       string             `json:"name"`
+	Size       *Size              `json:"size"`
+	Quality    *int               `json:"quality,omitempty"`
+	Thumbnails []Size             `json:"thumbnails,omitempty"`
+	Histogram  []int              `json:"histogram,omitempty"`
+	Weights    map[string]float64 `json:"weights,omitempty"`
+	Pixels     [][]int            `json:"pixels,omitempty"`
+}
+
+// NewImage returns a new `Image` with the default values of its schema.
+func NewImage() *Image {
+	return &Image{}
+}
+
+// ParseImage parses a JSON string and returns the value.
+func ParseImage(s string) (value Image, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+	if err := value.Validate(); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Image` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Image) Validate() error {
+	if c.Size == nil {
+		return fmt.Errorf("size: required")
+	}
+	if err := c.Size.Validate(); err != nil {
+		return fmt.Errorf("size.%w", err)
+	}
+	if c.Quality != nil && *c.Quality < 1 {
+		return fmt.Errorf("quality: %v is less than minimum 0.5", *c.Quality)
+	}
+	if c.Quality != nil && *c.Quality > 100 {
+		return fmt.Errorf("quality: %v is greater than maximum 100.5", *c.Quality)
+	}
+	for i, v := range c.Thumbnails {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("thumbnails[%v].%w", i, err)
+		}
+	}
+	for i, v := range c.Histogram {
+		if v < 0 {
+			return fmt.Errorf("histogram[%v]: %v is less than minimum 0", i, v)
+		}
+	}
+	for k, v := range c.Weights {
+		if v < 0 {
+			return fmt.Errorf("weights[%q]: %v is less than minimum 0", k, v)
+		}
+		if v > 1 {
+			return fmt.Errorf("weights[%q]: %v is greater than maximum 1", k, v)
+		}
+	}
+	for i, v := range c.Pixels {
+		for i2, v2 := range v {
+			if v2 > 255 {
+				return fmt.Errorf("pixels[%v][%v]: %v is greater than maximum 255", i, i2, v2)
+			}
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Image`.
+func (c *Image) GetSchema() XTPSchema {
+	return XTPSchema{
+		"name":       "string",
+		"size":       "Size",
+		"quality":    "?integer",
+		"thumbnails": "?Array<Size>",
+		"histogram":  "?Array<integer>",
+		"weights":    "?Map<string, number>",
+		"pixels":     "?Array<Array<integer>>",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
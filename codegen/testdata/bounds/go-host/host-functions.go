@@ -0,0 +1,86 @@
+package bounds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// HostErrorVar is the name of the Extism var used to report an error
+// from a host function back to the calling plugin.
+const HostErrorVar = "xtp-host-error"
+
+// HostFunctions represents the functions imported by the XTP Extension Plugin
+// which must be implemented by the host.
+type HostFunctions interface {
+	// Measure - Measures the size of an image.
+	Measure(ctx context.Context, input Image) (Size, error)
+}
+
+// NewHostFunctions returns the `extism.HostFunction`s that call impl
+// for each of the functions imported by the XTP Extension Plugin.
+func NewHostFunctions(impl HostFunctions) []extism.HostFunction {
+	return []extism.HostFunction{
+		NewMeasureHostFunction(impl.Measure),
+	}
+}
+
+// reportHostError logs the error and reports it back to the calling plugin
+// through `HostErrorVar`.
+func reportHostError(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64, name string, err error) {
+	msg := fmt.Sprintf("%v: %v", name, err)
+	plugin.Log(extism.LogLevelError, msg)
+	if p, ok := ctx.Value(extism.PluginCtxKey("plugin")).(*extism.Plugin); ok {
+		p.Var[HostErrorVar] = []byte(msg)
+	}
+	stack[0] = 0
+}
+
+// NewMeasureHostFunction returns an `extism.HostFunction` that
+// implements the "measure" import by calling fn.
+func NewMeasureHostFunction(fn func(ctx context.Context, input Image) (Size, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"measure",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			buf, err := plugin.ReadBytes(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "measure", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			var input Image
+			if err := json.Unmarshal(buf, &input); err != nil {
+				reportHostError(ctx, plugin, stack, "measure", fmt.Errorf("unable to json.Unmarshal input: %w", err))
+				return
+			}
+			if err := input.Validate(); err != nil {
+				reportHostError(ctx, plugin, stack, "measure", fmt.Errorf("invalid input: %w", err))
+				return
+			}
+
+			output, err := fn(ctx, input)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "measure", err)
+				return
+			}
+
+			outBuf, err := json.Marshal(output)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "measure", fmt.Errorf("unable to json.Marshal output: %w", err))
+				return
+			}
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "measure", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
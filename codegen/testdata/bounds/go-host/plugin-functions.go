@@ -0,0 +1,45 @@
+package bounds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// Resize - Resizes an image.
+func (p *Plugin) Resize(ctx context.Context, input Image) (output Image, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("resize: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "resize", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("resize: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("resize: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("resize: unable to json.Unmarshal output: %w", err)
+	}
+	if err := output.Validate(); err != nil {
+		return output, fmt.Errorf("resize: invalid output: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,16 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// Resize - Resizes an image.
+func Resize(input Image) Image {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Resize")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin Resize")
+	return Image{}
+}
+
+func main() {}
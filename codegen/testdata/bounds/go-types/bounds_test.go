@@ -0,0 +1,200 @@
+package bounds
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+
+func TestSizeMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Size
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Size{
+				Width:  0,
+				Height: 0,
+			},
+			want: `{"width":0,"height":0}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Size{
+				Scale: float64Ptr(0),
+			},
+			want: `{"width":0,"height":0,"scale":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Size
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestSizeValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Size)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Size) {}},
+		{name: "width at minimum", modify: func(v *Size) { v.Width = 1 }},
+		{name: "width below minimum", modify: func(v *Size) { v.Width = 0 }, wantErr: "width: 0 is less than minimum 1"},
+		{name: "width at maximum", modify: func(v *Size) { v.Width = 10000 }},
+		{name: "width above maximum", modify: func(v *Size) { v.Width = 10001 }, wantErr: "width: 10001 is greater than maximum 10000"},
+		{name: "height at minimum", modify: func(v *Size) { v.Height = 1 }},
+		{name: "height below minimum", modify: func(v *Size) { v.Height = 0 }, wantErr: "height: 0 is less than minimum 1"},
+		{name: "height at maximum", modify: func(v *Size) { v.Height = 10000 }},
+		{name: "height above maximum", modify: func(v *Size) { v.Height = 10001 }, wantErr: "height: 10001 is greater than maximum 10000"},
+		{name: "scale at minimum", modify: func(v *Size) { x := float64(0.5); v.Scale = &x }},
+		{name: "scale below minimum", modify: func(v *Size) { x := float64(-0.5); v.Scale = &x }, wantErr: "scale: -0.5 is less than minimum 0.5"},
+		{name: "scale at maximum", modify: func(v *Size) { x := float64(4); v.Scale = &x }},
+		{name: "scale above maximum", modify: func(v *Size) { x := float64(5); v.Scale = &x }, wantErr: "scale: 5 is greater than maximum 4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Size{
+				Width:  1,
+				Height: 1,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Image
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Image{
+				Name: "name",
+				Size: &Size{},
+			},
+			want: `{"name":"name","size":{"width":0,"height":0}}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Image{
+				Quality:    intPtr(0),
+				Thumbnails: []Size{Size{}},
+				Histogram:  []int{1},
+				Weights:    map[string]float64{"key": 1.5},
+				Pixels:     [][]int{[]int{1}},
+			},
+			want: `{"name":"","size":null,"quality":0,"thumbnails":[{"width":0,"height":0}],"histogram":[1],"weights":{"key":1.5},"pixels":[[1]]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Image
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestImageValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Image)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Image) {}},
+		{name: "missing size", modify: func(v *Image) { v.Size = nil }, wantErr: "size: required"},
+		{name: "invalid size", modify: func(v *Image) { v.Size.Width = 0 }, wantErr: "size.width: 0 is less than minimum 1"},
+		{name: "quality at minimum", modify: func(v *Image) { x := 1; v.Quality = &x }},
+		{name: "quality below minimum", modify: func(v *Image) { x := 0; v.Quality = &x }, wantErr: "quality: 0 is less than minimum 0.5"},
+		{name: "quality at maximum", modify: func(v *Image) { x := 100; v.Quality = &x }},
+		{name: "quality above maximum", modify: func(v *Image) { x := 101; v.Quality = &x }, wantErr: "quality: 101 is greater than maximum 100.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Image{
+				Name: "name",
+				Size: &Size{
+					Width:  1,
+					Height: 1,
+				},
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
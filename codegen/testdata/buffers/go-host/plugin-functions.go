@@ -0,0 +1,72 @@
+package buffers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// ResizeImage - Resizes the image to half its size.
+func (p *Plugin) ResizeImage(ctx context.Context, input []byte) (output []byte, err error) {
+	inBuf := input
+
+	rc, outBuf, err := p.CallWithContext(ctx, "resizeImage", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("resizeImage: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("resizeImage: plugin returned exit code %v", rc)
+	}
+
+	return outBuf, nil
+}
+
+// DescribeImage - Describes the image.
+func (p *Plugin) DescribeImage(ctx context.Context, input []byte) (output ImageInfo, err error) {
+	inBuf := input
+
+	rc, outBuf, err := p.CallWithContext(ctx, "describeImage", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("describeImage: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("describeImage: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("describeImage: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// RenderThumbnail - Renders the thumbnail as an image.
+func (p *Plugin) RenderThumbnail(ctx context.Context, input Thumbnail) (output []byte, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("renderThumbnail: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "renderThumbnail", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("renderThumbnail: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("renderThumbnail: plugin returned exit code %v", rc)
+	}
+
+	return outBuf, nil
+}
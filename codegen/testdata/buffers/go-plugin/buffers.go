@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// ImageFormat represents an image format.
+type ImageFormat string
+
+const (
+	ImageFormatEnumPng  ImageFormat = "png"
+	ImageFormatEnumJpeg ImageFormat = "jpeg"
+)
+
+// AllImageFormat returns all the values of `ImageFormat` in the order of its schema.
+func AllImageFormat() []ImageFormat {
+	return []ImageFormat{
+		ImageFormatEnumPng,
+		ImageFormatEnumJpeg,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `ImageFormat`.
+func (v ImageFormat) IsValid() bool {
+	switch v {
+	case ImageFormatEnumPng, ImageFormatEnumJpeg:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v ImageFormat) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ImageFormat) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *ImageFormat) UnmarshalText(text []byte) error {
+	value, err := ImageFormatFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *ImageFormat) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// ImageFormatFromString returns the `ImageFormat` with the given (unquoted) value.
+func ImageFormatFromString(s string) (ImageFormat, error) {
+	if v := ImageFormat(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a ImageFormat: %q", s)
+}
+
+// ParseImageFormat parses a JSON string and returns the value.
+func ParseImageFormat(s string) (value ImageFormat, err error) {
+	switch s {
+	case `"png"`:
+		return ImageFormatEnumPng, nil
+	case `"jpeg"`:
+		return ImageFormatEnumJpeg, nil
+	default:
+		return value, fmt.Errorf("not a ImageFormat: %v", s)
+	}
+}
+
+// Thumbnail represents a small preview of an image.
+type Thumbnail struct {
+	// The raw thumbnail
+	Data []byte `json:"data"`
+	// The width in pixels
+	Width int `json:"width"`
+}
+
+// NewThumbnail returns a new `Thumbnail` with the default values of its schema.
+func NewThumbnail() *Thumbnail {
+	return &Thumbnail{}
+}
+
+// ParseThumbnail parses a JSON string and returns the value.
+func ParseThumbnail(s string) (value Thumbnail, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Thumbnail` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Thumbnail) Validate() error {
+	if c.Data == nil {
+		return fmt.Errorf("data: required")
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Thumbnail`.
+func (c *Thumbnail) GetSchema() XTPSchema {
+	return XTPSchema{
+		"data":  "buffer",
+		"width": "integer",
+	}
+}
+
+// ImageInfo represents information about an image.
+type ImageInfo struct {
+	// The format of the image
+	Format ImageFormat `json:"format"`
+	// The width in pixels
+	Width int `json:"width"`
+	// The height in pixels
+	Height int `json:"height"`
+	// The SHA-256 checksum of the image
+	Checksum []byte `json:"checksum,omitempty"`
+}
+
+// NewImageInfo returns a new `ImageInfo` with the default values of its schema.
+func NewImageInfo() *ImageInfo {
+	return &ImageInfo{}
+}
+
+// ParseImageInfo parses a JSON string and returns the value.
+func ParseImageInfo(s string) (value ImageInfo, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `ImageInfo` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *ImageInfo) Validate() error {
+	if !c.Format.IsValid() {
+		return fmt.Errorf("format: %q is not a valid ImageFormat", c.Format)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `ImageInfo`.
+func (c *ImageInfo) GetSchema() XTPSchema {
+	return XTPSchema{
+		"format":   "ImageFormat",
+		"width":    "integer",
+		"height":   "integer",
+		"checksum": "?buffer",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+
+func TestParseImageFormat(t *testing.T) {
+	t.Parallel()
+
+	imageFormat := ImageFormatEnumPng
+	buf, err := jsoncomp.Marshal(imageFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"png"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseImageFormat(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != imageFormat {
+		t.Errorf("ParseImageFormat = '%v', want '%v'", got, imageFormat)
+	}
+}
+
+func TestImageFormatValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllImageFormat() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := ImageFormatFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("ImageFormatFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj ImageFormat
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllImageFormat()), 2; got != want {
+		t.Errorf("len(AllImageFormat()) = %v, want %v", got, want)
+	}
+}
+
+func TestImageFormatRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := ImageFormat("not-a-imageFormat")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := ImageFormatFromString(unknown.String()); err == nil {
+		t.Errorf("ImageFormatFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj ImageFormat
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-imageFormat"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
+func TestThumbnailMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Thumbnail
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Thumbnail{
+				Data:  []byte("data"),
+				Width: 0,
+			},
+			want: `{"data":"ZGF0YQ==","width":0}`,
+		},
+		{
+			name: "optional fields",
+			obj:  &Thumbnail{},
+			want: `{"data":null,"width":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Thumbnail
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestThumbnailValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Thumbnail)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Thumbnail) {}},
+		{name: "missing data", modify: func(v *Thumbnail) { v.Data = nil }, wantErr: "data: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Thumbnail{
+				Data:  []byte{},
+				Width: 0,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImageInfoMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *ImageInfo
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &ImageInfo{
+				Format: ImageFormatEnumPng,
+				Width:  0,
+				Height: 0,
+			},
+			want: `{"format":"png","width":0,"height":0}`,
+		},
+		{
+			name: "optional fields",
+			obj: &ImageInfo{
+				Format:   ImageFormatEnumPng,
+				Checksum: []byte("checksum"),
+			},
+			want: `{"format":"png","width":0,"height":0,"checksum":"Y2hlY2tzdW0="}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj ImageInfo
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestImageInfoValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *ImageInfo)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *ImageInfo) {}},
+		{name: "invalid format", modify: func(v *ImageInfo) { v.Format = "invalid" }, wantErr: "format: \"invalid\" is not a valid ImageFormat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &ImageInfo{
+				Format: ImageFormatEnumPng,
+				Width:  0,
+				Height: 0,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
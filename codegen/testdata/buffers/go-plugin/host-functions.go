@@ -0,0 +1,54 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/extism/go-pdk"
+)
+
+// hostErrorVar is the name of the Extism var used by the host to report
+// an error from a host function.
+const hostErrorVar = "xtp-host-error"
+
+//go:wasmimport extism:host/user storeImage
+func hostStoreImage(uint64) uint64
+
+// StoreImage - Stores the image on the host.
+func StoreImage(input []byte) (result ImageInfo, err error) {
+	mem := pdk.AllocateBytes(input)
+	ptr := hostStoreImage(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	if err := json.Unmarshal(rmem.ReadBytes(), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+//go:wasmimport extism:host/user fetchImage
+func hostFetchImage(uint64) uint64
+
+// FetchImage - Fetches the image from the host.
+func FetchImage(input ImageInfo) (result []byte, err error) {
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return result, err
+	}
+
+	mem := pdk.AllocateBytes(buf)
+	ptr := hostFetchImage(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	return rmem.ReadBytes(), nil
+}
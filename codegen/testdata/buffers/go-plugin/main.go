@@ -0,0 +1,35 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// ResizeImage - Resizes the image to half its size.
+//
+// `input` - The raw image
+// Returns The resized image
+func ResizeImage(input []byte) []byte {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin ResizeImage")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin ResizeImage")
+	return nil
+}
+
+// DescribeImage - Describes the image.
+func DescribeImage(input []byte) ImageInfo {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin DescribeImage")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin DescribeImage")
+	return ImageInfo{}
+}
+
+// RenderThumbnail - Renders the thumbnail as an image.
+func RenderThumbnail(input Thumbnail) []byte {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin RenderThumbnail")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin RenderThumbnail")
+	return nil
+}
+
+func main() {}
@@ -0,0 +1,51 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/extism/go-pdk"
+)
+
+//export resizeImage
+func resizeImage() int {
+	input := pdk.Input()
+
+	output := ResizeImage(input)
+
+	pdk.Output(output)
+	return 0 // success
+}
+
+//export describeImage
+func describeImage() int {
+	input := pdk.Input()
+
+	output := DescribeImage(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export renderThumbnail
+func renderThumbnail() int {
+	in := pdk.InputString()
+	input, err := ParseThumbnail(in)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseThumbnail input: %v, input:\n%v\n", err, in))
+		return 1 // failure
+	}
+
+	output := RenderThumbnail(input)
+
+	pdk.Output(output)
+	return 0 // success
+}
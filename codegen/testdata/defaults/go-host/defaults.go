@@ -0,0 +1,243 @@
+// Package defaults represents the custom datatypes for an XTP Extension Plugin.
+package defaults
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+	"time"
+)
+
+// Theme represents a color theme.
+type Theme string
+
+const (
+	ThemeEnumLight Theme = "light"
+	ThemeEnumDark  Theme = "dark"
+)
+
+// AllTheme returns all the values of `Theme` in the order of its schema.
+func AllTheme() []Theme {
+	return []Theme{
+		ThemeEnumLight,
+		ThemeEnumDark,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Theme`.
+func (v Theme) IsValid() bool {
+	switch v {
+	case ThemeEnumLight, ThemeEnumDark:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Theme) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Theme) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Theme) UnmarshalText(text []byte) error {
+	value, err := ThemeFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Theme) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// ThemeFromString returns the `Theme` with the given (unquoted) value.
+func ThemeFromString(s string) (Theme, error) {
+	if v := Theme(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Theme: %q", s)
+}
+
+// ParseTheme parses a JSON string and returns the value.
+func ParseTheme(s string) (value Theme, err error) {
+	switch s {
+	case `"light"`:
+		return ThemeEnumLight, nil
+	case `"dark"`:
+		return ThemeEnumDark, nil
+	default:
+		return value, fmt.Errorf("not a Theme: %v", s)
+	}
+}
+
+// Settings represents the settings of a renderer.
+type Settings struct {
+	// The title of the page
+	Title string `json:"title"`
+	// The width of the page in pixels
+	Width int32 `json:"width"`
+	// The color theme
+	Theme Theme `json:"theme,omitempty"`
+	// The scale of the page
+	Scale *float64 `json:"scale,omitempty"`
+	// The opacity of the background
+	Opacity *float32 `json:"opacity,omitempty"`
+	// The seed of the random noise
+	Seed *int64 `json:"seed,omitempty"`
+	// The number of times to retry rendering
+	Retries *int `json:"retries,omitempty"`
+	// Whether to antialias the page
+	Antialias *bool `json:"antialias,omitempty"`
+	// When the settings took effect
+	Since *time.Time `json:"since,omitempty"`
+	// The watermark of the page
+	Watermark []byte `json:"watermark,omitempty"`
+	// The salt of the page hash
+	Salt []byte `json:"salt,omitempty"`
+	// The author of the page
+	Author *string `json:"author,omitempty"`
+}
+
+// NewSettings returns a new `Settings` with the default values of its schema.
+func NewSettings() *Settings {
+	defaultScale := 1.5
+	defaultOpacity := float32(0.25)
+	defaultSeed := int64(4294967296)
+	defaultRetries := 3
+	defaultAntialias := true
+	defaultSince := time.Date(2024, time.June, 1, 10, 30, 0, 0, time.UTC)
+	return &Settings{
+		Title:     "Untitled",
+		Width:     800,
+		Theme:     ThemeEnumDark,
+		Scale:     &defaultScale,
+		Opacity:   &defaultOpacity,
+		Seed:      &defaultSeed,
+		Retries:   &defaultRetries,
+		Antialias: &defaultAntialias,
+		Since:     &defaultSince,
+		Watermark: []byte("hi"),
+		Salt:      []byte("salt"),
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding the JSON object over
+// the default values of its schema, so that missing properties keep them,
+// including in the `Settings` values nested in other types.
+func (c *Settings) UnmarshalJSON(data []byte) error {
+	// plainSettings has no methods, so json.Unmarshal does not call this method again.
+	type plainSettings Settings
+	value := plainSettings(*NewSettings())
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*c = Settings(value)
+	return nil
+}
+
+// ParseSettings parses a JSON string and returns the value.
+// Properties that are missing from the JSON keep their default values.
+func ParseSettings(s string) (value Settings, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Settings` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Settings) Validate() error {
+	if c.Width < 1 {
+		return fmt.Errorf("width: %v is less than minimum 1", c.Width)
+	}
+	if c.Theme != "" && !c.Theme.IsValid() {
+		return fmt.Errorf("theme: %q is not a valid Theme", c.Theme)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Settings`.
+func (c *Settings) GetSchema() XTPSchema {
+	return XTPSchema{
+		"title":     "string",
+		"width":     "integer",
+		"theme":     "?Theme",
+		"scale":     "?number",
+		"opacity":   "?number",
+		"seed":      "?integer",
+		"retries":   "?integer",
+		"antialias": "?boolean",
+		"since":     "?Date",
+		"watermark": "?buffer",
+		"salt":      "?string",
+		"author":    "?string",
+	}
+}
+
+// Page represents a page to render.
+type Page struct {
+	// The body of the page
+	Body string `json:"body"`
+	// The settings of the page
+	Settings *Settings `json:"settings,omitempty"`
+	// The settings of each section
+	Sections []Settings `json:"sections,omitempty"`
+}
+
+// NewPage returns a new `Page` with the default values of its schema.
+func NewPage() *Page {
+	return &Page{}
+}
+
+// ParsePage parses a JSON string and returns the value.
+func ParsePage(s string) (value Page, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Page` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Page) Validate() error {
+	if c.Settings != nil {
+		if err := c.Settings.Validate(); err != nil {
+			return fmt.Errorf("settings.%w", err)
+		}
+	}
+	for i, v := range c.Sections {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("sections[%v].%w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Page`.
+func (c *Page) GetSchema() XTPSchema {
+	return XTPSchema{
+		"body":     "string",
+		"settings": "?Settings",
+		"sections": "?Array<Settings>",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -0,0 +1,38 @@
+package defaults
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// Render - Renders the page with the given settings.
+func (p *Plugin) Render(ctx context.Context, input Page) (output []byte, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("render: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "render", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("render: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("render: plugin returned exit code %v", rc)
+	}
+
+	return outBuf, nil
+}
@@ -0,0 +1,16 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// Render - Renders the page with the given settings.
+func Render(input Page) []byte {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Render")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin Render")
+	return nil
+}
+
+func main() {}
@@ -0,0 +1,24 @@
+//go:build tinygo
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/extism/go-pdk"
+)
+
+//export render
+func render() int {
+	in := pdk.InputString()
+	input, err := ParsePage(in)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParsePage input: %v, input:\n%v\n", err, in))
+		return 1 // failure
+	}
+
+	output := Render(input)
+
+	pdk.Output(output)
+	return 0 // success
+}
@@ -0,0 +1,303 @@
+package defaults
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool           { return &b }
+func float64Ptr(f float64) *float64  { return &f }
+func intPtr(i int) *int              { return &i }
+func stringPtr(s string) *string     { return &s }
+func float32Ptr(f float32) *float32  { return &f }
+func int64Ptr(i int64) *int64        { return &i }
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestParseTheme(t *testing.T) {
+	t.Parallel()
+
+	theme := ThemeEnumLight
+	buf, err := jsoncomp.Marshal(theme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"light"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseTheme(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != theme {
+		t.Errorf("ParseTheme = '%v', want '%v'", got, theme)
+	}
+}
+
+func TestThemeValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllTheme() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := ThemeFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("ThemeFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Theme
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllTheme()), 2; got != want {
+		t.Errorf("len(AllTheme()) = %v, want %v", got, want)
+	}
+}
+
+func TestThemeRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Theme("not-a-theme")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := ThemeFromString(unknown.String()); err == nil {
+		t.Errorf("ThemeFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Theme
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-theme"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
+func TestSettingsMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Settings
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Settings{
+				Title:     "title",
+				Width:     0,
+				Theme:     ThemeEnumDark,
+				Scale:     float64Ptr(1.5),
+				Opacity:   float32Ptr(0.25),
+				Seed:      int64Ptr(4294967296),
+				Retries:   intPtr(3),
+				Antialias: boolPtr(true),
+				Since:     timePtr(time.Date(2024, time.June, 1, 10, 30, 0, 0, time.UTC)),
+				Watermark: []byte("hi"),
+				Salt:      []byte("salt"),
+			},
+			want: `{"title":"title","width":0,"theme":"dark","scale":1.5,"opacity":0.25,"seed":4294967296,"retries":3,"antialias":true,"since":"2024-06-01T10:30:00Z","watermark":"aGk=","salt":"c2FsdA=="}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Settings{
+				Theme:     ThemeEnumLight,
+				Scale:     float64Ptr(0),
+				Opacity:   float32Ptr(0),
+				Seed:      int64Ptr(0),
+				Retries:   intPtr(0),
+				Antialias: boolPtr(false),
+				Since:     timePtr(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+				Watermark: []byte("watermark"),
+				Salt:      []byte("salt"),
+				Author:    stringPtr("author"),
+			},
+			want: `{"title":"","width":0,"theme":"light","scale":0,"opacity":0,"seed":0,"retries":0,"antialias":false,"since":"2024-01-02T03:04:05Z","watermark":"d2F0ZXJtYXJr","salt":"c2FsdA==","author":"author"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Settings
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestParseSettingsDefaults(t *testing.T) {
+	t.Parallel()
+	got, err := ParseSettings(`{"title":"title","width":0}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := NewSettings()
+	want.Title = "title"
+	want.Width = 0
+	if diff := cmp.Diff(want, &got); diff != "" {
+		t.Errorf("ParseSettings mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Settings)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Settings) {}},
+		{name: "width at minimum", modify: func(v *Settings) { v.Width = 1 }},
+		{name: "width below minimum", modify: func(v *Settings) { v.Width = 0 }, wantErr: "width: 0 is less than minimum 1"},
+		{name: "invalid theme", modify: func(v *Settings) { v.Theme = "invalid" }, wantErr: "theme: \"invalid\" is not a valid Theme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Settings{
+				Title: "title",
+				Width: 1,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPageMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Page
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Page{
+				Body: "body",
+			},
+			want: `{"body":"body"}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Page{
+				Settings: NewSettings(),
+				Sections: []Settings{*NewSettings()},
+			},
+			want: `{"body":"","settings":{"title":"Untitled","width":800,"theme":"dark","scale":1.5,"opacity":0.25,"seed":4294967296,"retries":3,"antialias":true,"since":"2024-06-01T10:30:00Z","watermark":"aGk=","salt":"c2FsdA=="},"sections":[{"title":"Untitled","width":800,"theme":"dark","scale":1.5,"opacity":0.25,"seed":4294967296,"retries":3,"antialias":true,"since":"2024-06-01T10:30:00Z","watermark":"aGk=","salt":"c2FsdA=="}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Page
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestParsePageNestedDefaults(t *testing.T) {
+	t.Parallel()
+	got, err := ParsePage(`{"body":"body","settings":{},"sections":[{}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := NewPage()
+	want.Body = "body"
+	want.Settings = NewSettings()
+	want.Sections = []Settings{*NewSettings()}
+	if diff := cmp.Diff(want, &got); diff != "" {
+		t.Errorf("ParsePage mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestPageValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Page)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Page) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Page{
+				Body: "body",
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
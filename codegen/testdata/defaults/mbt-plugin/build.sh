@@ -0,0 +1,2 @@
+#!/bin/bash -e
+xtp plugin build
@@ -0,0 +1,958 @@
+package fastjson
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool           { return &b }
+func float64Ptr(f float64) *float64  { return &f }
+func intPtr(i int) *int              { return &i }
+func stringPtr(s string) *string     { return &s }
+func float32Ptr(f float32) *float32  { return &f }
+func int32Ptr(i int32) *int32        { return &i }
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestParseUnit(t *testing.T) {
+	t.Parallel()
+
+	unit := UnitEnumCelsius
+	buf, err := jsoncomp.Marshal(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"celsius"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseUnit(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != unit {
+		t.Errorf("ParseUnit = '%v', want '%v'", got, unit)
+	}
+}
+
+func TestUnitValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllUnit() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := UnitFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("UnitFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Unit
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllUnit()), 2; got != want {
+		t.Errorf("len(AllUnit()) = %v, want %v", got, want)
+	}
+}
+
+func TestUnitRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Unit("not-a-unit")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := UnitFromString(unknown.String()); err == nil {
+		t.Errorf("UnitFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Unit
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-unit"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
+func FuzzUnitMarshalJSON(f *testing.F) {
+	f.Add("celsius")
+	f.Add("<not-a-unit>")
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			t.Skip() // encoding/json escapes invalid UTF-8 differently in different Go versions.
+		}
+		want, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Unit(s).MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+
+func TestStationMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Station
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Station{
+				Name: "name",
+			},
+			want: `{"name":"name"}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Station{
+				Altitude: float32Ptr(0),
+			},
+			want: `{"name":"","altitude":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Station
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestStationValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Station)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Station) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Station{
+				Name: "name",
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func FuzzStationJSON(f *testing.F) {
+	f.Add(`{"name":"name"}`)
+	f.Add(`{"name":"","altitude":0}`)
+	f.Add("null")
+
+	// reflectedStation has no methods, so encoding/json uses reflection.
+	type reflectedStation Station
+
+	f.Fuzz(func(t *testing.T, data string) {
+		var want Station
+		wantErr := json.Unmarshal([]byte(data), (*reflectedStation)(&want))
+		var got Station
+		gotErr := got.UnmarshalJSON([]byte(data))
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("UnmarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("UnmarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+
+		wantBuf, wantErr := json.Marshal((*reflectedStation)(&want))
+		gotBuf, gotErr := got.MarshalJSON()
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("MarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if diff := cmp.Diff(string(wantBuf), string(gotBuf)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+
+func BenchmarkStationJSON(b *testing.B) {
+	data := []byte(`{"name":"","altitude":0}`)
+	var obj Station
+	if err := obj.UnmarshalJSON(data); err != nil {
+		b.Fatal(err)
+	}
+
+	// reflectedStation has no methods, so encoding/json uses reflection.
+	type reflectedStation Station
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := obj.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal((*reflectedStation)(&obj)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v Station
+			if err := v.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v reflectedStation
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestMeasurementMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Measurement
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Measurement{
+				Sequence:    0,
+				Temperature: 0,
+				Unit:        UnitEnumCelsius,
+				TakenAt:     time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+				Station:     &Station{},
+				Samples:     []float32{1.5},
+			},
+			want: `{"sequence":0,"temperature":0,"unit":"celsius","takenAt":"2024-01-02T03:04:05Z","station":{"name":""},"samples":[1.5]}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Measurement{
+				Channel:      int32Ptr(0),
+				Unit:         UnitEnumCelsius,
+				CalibratedAt: timePtr(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+				Backup:       &Station{},
+				Grid:         [][]int{[]int{1}},
+				Units:        []Unit{UnitEnumCelsius},
+				Neighbors:    []Station{Station{}},
+				Labels:       map[string]string{"key": "item"},
+				Series:       map[string][]float64{"key": []float64{1.5}},
+				Extra:        map[string]any{"key": "item"},
+				Raw:          []byte("raw"),
+				Checksum:     []byte("checksum"),
+				Verified:     boolPtr(false),
+				Note:         stringPtr("note"),
+			},
+			want: `{"sequence":0,"channel":0,"temperature":0,"unit":"celsius","takenAt":"0001-01-01T00:00:00Z","calibratedAt":"2024-01-02T03:04:05Z","station":null,"backup":{"name":""},"samples":null,"grid":[[1]],"units":["celsius"],"neighbors":[{"name":""}],"labels":{"key":"item"},"series":{"key":[1.5]},"extra":{"key":"item"},"raw":"cmF3","checksum":"Y2hlY2tzdW0=","verified":false,"note":"note"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Measurement
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestMeasurementValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Measurement)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Measurement) {}},
+		{name: "invalid unit", modify: func(v *Measurement) { v.Unit = "invalid" }, wantErr: "unit: \"invalid\" is not a valid Unit"},
+		{name: "missing station", modify: func(v *Measurement) { v.Station = nil }, wantErr: "station: required"},
+		{name: "missing samples", modify: func(v *Measurement) { v.Samples = nil }, wantErr: "samples: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Measurement{
+				Sequence:    0,
+				Temperature: 0,
+				Unit:        UnitEnumCelsius,
+				TakenAt:     time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+				Station: &Station{
+					Name: "name",
+				},
+				Samples: []float32{},
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func FuzzMeasurementJSON(f *testing.F) {
+	f.Add(`{"sequence":0,"temperature":0,"unit":"celsius","takenAt":"2024-01-02T03:04:05Z","station":{"name":""},"samples":[1.5]}`)
+	f.Add(`{"sequence":0,"channel":0,"temperature":0,"unit":"celsius","takenAt":"0001-01-01T00:00:00Z","calibratedAt":"2024-01-02T03:04:05Z","station":null,"backup":{"name":""},"samples":null,"grid":[[1]],"units":["celsius"],"neighbors":[{"name":""}],"labels":{"key":"item"},"series":{"key":[1.5]},"extra":{"key":"item"},"raw":"cmF3","checksum":"Y2hlY2tzdW0=","verified":false,"note":"note"}`)
+	f.Add("null")
+
+	// reflectedMeasurement has no methods, so encoding/json uses reflection.
+	type reflectedMeasurement Measurement
+
+	f.Fuzz(func(t *testing.T, data string) {
+		var want Measurement
+		wantErr := json.Unmarshal([]byte(data), (*reflectedMeasurement)(&want))
+		var got Measurement
+		gotErr := got.UnmarshalJSON([]byte(data))
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("UnmarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("UnmarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+
+		wantBuf, wantErr := json.Marshal((*reflectedMeasurement)(&want))
+		gotBuf, gotErr := got.MarshalJSON()
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("MarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if diff := cmp.Diff(string(wantBuf), string(gotBuf)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+
+func BenchmarkMeasurementJSON(b *testing.B) {
+	data := []byte(`{"sequence":0,"channel":0,"temperature":0,"unit":"celsius","takenAt":"0001-01-01T00:00:00Z","calibratedAt":"2024-01-02T03:04:05Z","station":null,"backup":{"name":""},"samples":null,"grid":[[1]],"units":["celsius"],"neighbors":[{"name":""}],"labels":{"key":"item"},"series":{"key":[1.5]},"extra":{"key":"item"},"raw":"cmF3","checksum":"Y2hlY2tzdW0=","verified":false,"note":"note"}`)
+	var obj Measurement
+	if err := obj.UnmarshalJSON(data); err != nil {
+		b.Fatal(err)
+	}
+
+	// reflectedMeasurement has no methods, so encoding/json uses reflection.
+	type reflectedMeasurement Measurement
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := obj.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal((*reflectedMeasurement)(&obj)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v Measurement
+			if err := v.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v reflectedMeasurement
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestAcceptedMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Accepted
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Accepted{
+				Id: 0,
+			},
+			want: `{"id":0}`,
+		},
+		{
+			name: "optional fields",
+			obj:  &Accepted{},
+			want: `{"id":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Accepted
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestAcceptedValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Accepted)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Accepted) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Accepted{
+				Id: 0,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func FuzzAcceptedJSON(f *testing.F) {
+	f.Add(`{"id":0}`)
+	f.Add(`{"id":0}`)
+	f.Add("null")
+
+	// reflectedAccepted has no methods, so encoding/json uses reflection.
+	type reflectedAccepted Accepted
+
+	f.Fuzz(func(t *testing.T, data string) {
+		var want Accepted
+		wantErr := json.Unmarshal([]byte(data), (*reflectedAccepted)(&want))
+		var got Accepted
+		gotErr := got.UnmarshalJSON([]byte(data))
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("UnmarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("UnmarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+
+		wantBuf, wantErr := json.Marshal((*reflectedAccepted)(&want))
+		gotBuf, gotErr := got.MarshalJSON()
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("MarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if diff := cmp.Diff(string(wantBuf), string(gotBuf)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+
+func BenchmarkAcceptedJSON(b *testing.B) {
+	data := []byte(`{"id":0}`)
+	var obj Accepted
+	if err := obj.UnmarshalJSON(data); err != nil {
+		b.Fatal(err)
+	}
+
+	// reflectedAccepted has no methods, so encoding/json uses reflection.
+	type reflectedAccepted Accepted
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := obj.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal((*reflectedAccepted)(&obj)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v Accepted
+			if err := v.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v reflectedAccepted
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestRejectedMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Rejected
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Rejected{
+				Reason: "reason",
+			},
+			want: `{"reason":"reason"}`,
+		},
+		{
+			name: "optional fields",
+			obj:  &Rejected{},
+			want: `{"reason":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Rejected
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRejectedValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Rejected)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Rejected) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Rejected{
+				Reason: "reason",
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func FuzzRejectedJSON(f *testing.F) {
+	f.Add(`{"reason":"reason"}`)
+	f.Add(`{"reason":""}`)
+	f.Add("null")
+
+	// reflectedRejected has no methods, so encoding/json uses reflection.
+	type reflectedRejected Rejected
+
+	f.Fuzz(func(t *testing.T, data string) {
+		var want Rejected
+		wantErr := json.Unmarshal([]byte(data), (*reflectedRejected)(&want))
+		var got Rejected
+		gotErr := got.UnmarshalJSON([]byte(data))
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("UnmarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("UnmarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+
+		wantBuf, wantErr := json.Marshal((*reflectedRejected)(&want))
+		gotBuf, gotErr := got.MarshalJSON()
+		if (gotErr != nil) != (wantErr != nil) {
+			t.Fatalf("MarshalJSON = %v, encoding/json = %v", gotErr, wantErr)
+		}
+		if diff := cmp.Diff(string(wantBuf), string(gotBuf)); diff != "" {
+			t.Errorf("MarshalJSON mismatch (-encoding/json +got):\n%v", diff)
+		}
+	})
+}
+
+func BenchmarkRejectedJSON(b *testing.B) {
+	data := []byte(`{"reason":""}`)
+	var obj Rejected
+	if err := obj.UnmarshalJSON(data); err != nil {
+		b.Fatal(err)
+	}
+
+	// reflectedRejected has no methods, so encoding/json uses reflection.
+	type reflectedRejected Rejected
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := obj.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal((*reflectedRejected)(&obj)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v Rejected
+			if err := v.UnmarshalJSON(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var v reflectedRejected
+			if err := json.Unmarshal(data, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestOutcomeMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Outcome
+		want string
+	}{
+		{
+			name: "Accepted",
+			obj:  &Outcome{Value: &Accepted{}},
+			want: `{"status":"accepted","id":0}`,
+		},
+		{
+			name: "Rejected",
+			obj:  &Outcome{Value: &Rejected{}},
+			want: `{"status":"rejected","reason":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Outcome
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestParseOutcomeRejectsUnknownVariants(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name:    "missing status",
+			json:    `{}`,
+			wantErr: `Outcome: missing "status"`,
+		},
+		{
+			name:    "unknown status",
+			json:    `{"status":"not-a-outcome"}`,
+			wantErr: `Outcome: unknown status "not-a-outcome"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseOutcome(tt.json); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParseOutcome = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutcomeValidate(t *testing.T) {
+	t.Parallel()
+
+	var obj Outcome
+	if err := obj.Validate(); err == nil || err.Error() != "Outcome: missing value" {
+		t.Errorf("Validate = %v, want Outcome: missing value", err)
+	}
+
+	obj.Value = &Accepted{
+		Id: 0,
+	}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(Accepted) = %v, want nil", err)
+	}
+
+	obj.Value = &Rejected{
+		Reason: "reason",
+	}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(Rejected) = %v, want nil", err)
+	}
+}
+
+// TestBinarySize builds the same WebAssembly program, which decodes and
+// encodes a `Station`, with the generated JSON codecs and with encoding/json,
+// and checks that avoiding the reflection of encoding/json makes it smaller.
+func TestBinarySize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the builds in short mode")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	src, err := os.ReadFile("fastjson.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both programs include the custom types, which only use the standard library.
+	_, types, _ := strings.Cut(string(src), "\npackage ")
+	types = "package main\n" + types[strings.Index(types, "\n"):]
+
+	programs := map[string]string{
+		"fastjson": `package main
+
+import "os"
+
+func main() {
+	var v Station
+	if err := v.UnmarshalJSON([]byte(os.Args[1])); err != nil {
+		panic(err)
+	}
+	buf, err := v.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(buf)
+}
+`,
+		"encoding/json": `package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// reflected has no methods, so encoding/json uses reflection.
+type reflected Station
+
+func main() {
+	var v reflected
+	if err := json.Unmarshal([]byte(os.Args[1]), &v); err != nil {
+		panic(err)
+	}
+	buf, err := json.Marshal(&v)
+	if err != nil {
+		panic(err)
+	}
+	os.Stdout.Write(buf)
+}
+`,
+	}
+
+	sizes := map[string]int64{}
+	for name, program := range programs {
+		dir := t.TempDir()
+		for filename, src := range map[string]string{"main.go": program, "types.go": types} {
+			if err := os.WriteFile(filepath.Join(dir, filename), []byte(src), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		cmd := exec.Command(goCmd, "build", "-o", "plugin.wasm", "main.go", "types.go")
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "GOFLAGS=")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("go build with %v: %v\n%s", name, err, out)
+		}
+		fi, err := os.Stat(filepath.Join(dir, "plugin.wasm"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes[name] = fi.Size()
+	}
+
+	t.Logf("binary size: %v bytes with the generated JSON codecs, %v bytes with encoding/json", sizes["fastjson"], sizes["encoding/json"])
+	if sizes["fastjson"] >= sizes["encoding/json"] {
+		t.Errorf("binary size with the generated JSON codecs = %v, want less than %v with encoding/json", sizes["fastjson"], sizes["encoding/json"])
+	}
+}
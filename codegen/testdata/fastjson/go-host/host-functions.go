@@ -0,0 +1,75 @@
+package fastjson
+
+import (
+	"context"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// HostErrorVar is the name of the Extism var used to report an error
+// from a host function back to the calling plugin.
+const HostErrorVar = "xtp-host-error"
+
+// HostFunctions represents the functions imported by the XTP Extension Plugin
+// which must be implemented by the host.
+type HostFunctions interface {
+	// LastMeasurement - Returns the last measurement of the station.
+	LastMeasurement(ctx context.Context, input string) (Measurement, error)
+}
+
+// NewHostFunctions returns the `extism.HostFunction`s that call impl
+// for each of the functions imported by the XTP Extension Plugin.
+func NewHostFunctions(impl HostFunctions) []extism.HostFunction {
+	return []extism.HostFunction{
+		NewLastMeasurementHostFunction(impl.LastMeasurement),
+	}
+}
+
+// reportHostError logs the error and reports it back to the calling plugin
+// through `HostErrorVar`.
+func reportHostError(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64, name string, err error) {
+	msg := fmt.Sprintf("%v: %v", name, err)
+	plugin.Log(extism.LogLevelError, msg)
+	if p, ok := ctx.Value(extism.PluginCtxKey("plugin")).(*extism.Plugin); ok {
+		p.Var[HostErrorVar] = []byte(msg)
+	}
+	stack[0] = 0
+}
+
+// NewLastMeasurementHostFunction returns an `extism.HostFunction` that
+// implements the "lastMeasurement" import by calling fn.
+func NewLastMeasurementHostFunction(fn func(ctx context.Context, input string) (Measurement, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"lastMeasurement",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			input, err := plugin.ReadString(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "lastMeasurement", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			output, err := fn(ctx, input)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "lastMeasurement", err)
+				return
+			}
+
+			outBuf, err := output.MarshalJSON()
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "lastMeasurement", fmt.Errorf("unable to encode JSON output: %w", err))
+				return
+			}
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "lastMeasurement", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
@@ -0,0 +1,63 @@
+package fastjson
+
+import (
+	"context"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// RecordMeasurement - Records a measurement and returns the outcome.
+func (p *Plugin) RecordMeasurement(ctx context.Context, input Measurement) (output Outcome, err error) {
+	inBuf, err := input.MarshalJSON()
+	if err != nil {
+		return output, fmt.Errorf("recordMeasurement: unable to encode JSON input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "recordMeasurement", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("recordMeasurement: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("recordMeasurement: plugin returned exit code %v", rc)
+	}
+
+	if err := output.UnmarshalJSON(outBuf); err != nil {
+		return output, fmt.Errorf("recordMeasurement: unable to decode JSON output: %w", err)
+	}
+
+	return output, nil
+}
+
+// CountSamples - Counts the samples of the measurements.
+func (p *Plugin) CountSamples(ctx context.Context, input []Measurement) (output int, err error) {
+	inBuf, err := appendJSONArrayOf(appendJSONValue[Measurement])(nil, &input)
+	if err != nil {
+		return output, fmt.Errorf("countSamples: unable to encode JSON input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "countSamples", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("countSamples: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("countSamples: plugin returned exit code %v", rc)
+	}
+
+	if err := unmarshalJSON(outBuf, func(d *jsonDecoder) error { return decodeJSONInt[int](d, &output) }); err != nil {
+		return output, fmt.Errorf("countSamples: unable to decode JSON output: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,1535 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Unit represents a unit of temperature.
+type Unit string
+
+const (
+	UnitEnumCelsius    Unit = "celsius"
+	UnitEnumFahrenheit Unit = "fahrenheit"
+)
+
+// AllUnit returns all the values of `Unit` in the order of its schema.
+func AllUnit() []Unit {
+	return []Unit{
+		UnitEnumCelsius,
+		UnitEnumFahrenheit,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Unit`.
+func (v Unit) IsValid() bool {
+	switch v {
+	case UnitEnumCelsius, UnitEnumFahrenheit:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Unit) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Unit) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Unit) UnmarshalText(text []byte) error {
+	value, err := UnitFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Unit) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, v.decodeJSON)
+}
+
+// UnitFromString returns the `Unit` with the given (unquoted) value.
+func UnitFromString(s string) (Unit, error) {
+	if v := Unit(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Unit: %q", s)
+}
+
+// ParseUnit parses a JSON string and returns the value.
+func ParseUnit(s string) (value Unit, err error) {
+	switch s {
+	case `"celsius"`:
+		return UnitEnumCelsius, nil
+	case `"fahrenheit"`:
+		return UnitEnumFahrenheit, nil
+	default:
+		return value, fmt.Errorf("not a Unit: %v", s)
+	}
+}
+
+// MarshalJSON implements json.Marshaler without reflection.
+func (v Unit) MarshalJSON() ([]byte, error) {
+	return v.appendJSON(nil)
+}
+
+// appendJSON appends the JSON encoding of the `Unit` to buf.
+func (v Unit) appendJSON(buf []byte) ([]byte, error) {
+	return appendJSONQuoted(buf, string(v)), nil
+}
+
+// decodeJSON decodes the `Unit` from d and rejects unknown values.
+func (v *Unit) decodeJSON(d *jsonDecoder) error {
+	if d.null() {
+		return nil
+	}
+	s, err := d.string()
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// Station represents a weather station.
+type Station struct {
+	// The name of the station
+	Name string `json:"name"`
+	// The altitude of the station in meters
+	Altitude *float32 `json:"altitude,omitempty"`
+}
+
+// NewStation returns a new `Station` with the default values of its schema.
+func NewStation() *Station {
+	return &Station{}
+}
+
+// ParseStation parses a JSON string and returns the value.
+func ParseStation(s string) (value Station, err error) {
+	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Station` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Station) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Station`.
+func (c *Station) GetSchema() XTPSchema {
+	return XTPSchema{
+		"name":     "string",
+		"altitude": "?number",
+	}
+}
+
+// MarshalJSON implements json.Marshaler without reflection.
+func (c Station) MarshalJSON() ([]byte, error) {
+	return c.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection.
+func (c *Station) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, c.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the `Station` to buf.
+func (c *Station) appendJSON(buf []byte) ([]byte, error) {
+	var err error
+	start := len(buf)
+	buf = append(buf, '{')
+	buf = appendJSONField(buf, start, `"name":`)
+	if buf, err = appendJSONString(buf, &c.Name); err != nil {
+		return buf, err
+	}
+	if c.Altitude != nil {
+		buf = appendJSONField(buf, start, `"altitude":`)
+		if buf, err = appendJSONFloat(buf, c.Altitude); err != nil {
+			return buf, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// decodeJSON decodes the `Station` from d, skipping unknown properties.
+func (c *Station) decodeJSON(d *jsonDecoder) error {
+	return d.object(func(key []byte) error {
+		switch d.field(key, "name", "altitude") {
+		case "name":
+			return decodeJSONString(d, &c.Name)
+		case "altitude":
+			return decodeJSONPointer(d, &c.Altitude, decodeJSONFloat[float32])
+		}
+		return d.skip()
+	})
+}
+
+// Measurement represents a measurement of a weather station.
+type Measurement struct {
+	// The sequence number of the measurement
+	Sequence int64 `json:"sequence"`
+	// The channel of the sensor
+	Channel *int32 `json:"channel,omitempty"`
+	// The temperature
+	Temperature float64 `json:"temperature"`
+	// The unit of the temperature
+	Unit Unit `json:"unit"`
+	// When the measurement was taken
+	TakenAt time.Time `json:"takenAt"`
+	// When the sensor was last calibrated
+	CalibratedAt *time.Time `json:"calibratedAt,omitempty"`
+	// The station that took the measurement
+	Station *Station `json:"station"`
+	// The station that took over if the primary one failed
+	Backup *Station `json:"backup,omitempty"`
+	// The raw samples of the measurement
+	Samples []float32 `json:"samples"`
+	// The samples of a sensor grid
+	Grid [][]int `json:"grid,omitempty"`
+	// The units that the station supports
+	Units []Unit `json:"units,omitempty"`
+	// The neighboring stations
+	Neighbors []Station `json:"neighbors,omitempty"`
+	// Labels of the measurement
+	Labels map[string]string `json:"labels,omitempty"`
+	// Named series of samples
+	Series map[string][]float64 `json:"series,omitempty"`
+	// Free-form attributes of the measurement
+	Extra map[string]any `json:"extra,omitempty"`
+	// The raw payload of the sensor
+	Raw []byte `json:"raw,omitempty"`
+	// The checksum of the raw payload
+	Checksum []byte `json:"checksum,omitempty"`
+	// Whether the measurement has been verified
+	Verified *bool `json:"verified,omitempty"`
+	// A note about the measurement
+	Note *string `json:"note,omitempty"`
+}
+
+// NewMeasurement returns a new `Measurement` with the default values of its schema.
+func NewMeasurement() *Measurement {
+	return &Measurement{}
+}
+
+// ParseMeasurement parses a JSON string and returns the value.
+func ParseMeasurement(s string) (value Measurement, err error) {
+	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Measurement` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Measurement) Validate() error {
+	if !c.Unit.IsValid() {
+		return fmt.Errorf("unit: %q is not a valid Unit", c.Unit)
+	}
+	if c.Station == nil {
+		return fmt.Errorf("station: required")
+	}
+	if err := c.Station.Validate(); err != nil {
+		return fmt.Errorf("station.%w", err)
+	}
+	if c.Backup != nil {
+		if err := c.Backup.Validate(); err != nil {
+			return fmt.Errorf("backup.%w", err)
+		}
+	}
+	if c.Samples == nil {
+		return fmt.Errorf("samples: required")
+	}
+	for i, v := range c.Units {
+		if !v.IsValid() {
+			return fmt.Errorf("units[%v]: %q is not a valid Unit", i, v)
+		}
+	}
+	for i, v := range c.Neighbors {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("neighbors[%v].%w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Measurement`.
+func (c *Measurement) GetSchema() XTPSchema {
+	return XTPSchema{
+		"sequence":     "integer",
+		"channel":      "?integer",
+		"temperature":  "number",
+		"unit":         "Unit",
+		"takenAt":      "Date",
+		"calibratedAt": "?Date",
+		"station":      "Station",
+		"backup":       "?Station",
+		"samples":      "Array<number>",
+		"grid":         "?Array<Array<integer>>",
+		"units":        "?Array<Unit>",
+		"neighbors":    "?Array<Station>",
+		"labels":       "?Map<string, string>",
+		"series":       "?Map<string, Array<number>>",
+		"extra":        "?Map<string, any>",
+		"raw":          "?buffer",
+		"checksum":     "?string",
+		"verified":     "?boolean",
+		"note":         "?string",
+	}
+}
+
+// MarshalJSON implements json.Marshaler without reflection.
+func (c Measurement) MarshalJSON() ([]byte, error) {
+	return c.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection.
+func (c *Measurement) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, c.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the `Measurement` to buf.
+func (c *Measurement) appendJSON(buf []byte) ([]byte, error) {
+	var err error
+	start := len(buf)
+	buf = append(buf, '{')
+	buf = appendJSONField(buf, start, `"sequence":`)
+	if buf, err = appendJSONInt(buf, &c.Sequence); err != nil {
+		return buf, err
+	}
+	if c.Channel != nil {
+		buf = appendJSONField(buf, start, `"channel":`)
+		if buf, err = appendJSONInt(buf, c.Channel); err != nil {
+			return buf, err
+		}
+	}
+	buf = appendJSONField(buf, start, `"temperature":`)
+	if buf, err = appendJSONFloat(buf, &c.Temperature); err != nil {
+		return buf, err
+	}
+	buf = appendJSONField(buf, start, `"unit":`)
+	if buf, err = c.Unit.appendJSON(buf); err != nil {
+		return buf, err
+	}
+	buf = appendJSONField(buf, start, `"takenAt":`)
+	if buf, err = appendJSONTime(buf, &c.TakenAt); err != nil {
+		return buf, err
+	}
+	if c.CalibratedAt != nil {
+		buf = appendJSONField(buf, start, `"calibratedAt":`)
+		if buf, err = appendJSONTime(buf, c.CalibratedAt); err != nil {
+			return buf, err
+		}
+	}
+	buf = appendJSONField(buf, start, `"station":`)
+	if buf, err = appendJSONPointer(buf, c.Station, appendJSONValue[Station]); err != nil {
+		return buf, err
+	}
+	if c.Backup != nil {
+		buf = appendJSONField(buf, start, `"backup":`)
+		if buf, err = c.Backup.appendJSON(buf); err != nil {
+			return buf, err
+		}
+	}
+	buf = appendJSONField(buf, start, `"samples":`)
+	if buf, err = appendJSONArray(buf, c.Samples, appendJSONFloat[float32]); err != nil {
+		return buf, err
+	}
+	if len(c.Grid) > 0 {
+		buf = appendJSONField(buf, start, `"grid":`)
+		if buf, err = appendJSONArray(buf, c.Grid, appendJSONArrayOf(appendJSONInt[int])); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Units) > 0 {
+		buf = appendJSONField(buf, start, `"units":`)
+		if buf, err = appendJSONArray(buf, c.Units, appendJSONValue[Unit]); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Neighbors) > 0 {
+		buf = appendJSONField(buf, start, `"neighbors":`)
+		if buf, err = appendJSONArray(buf, c.Neighbors, appendJSONValue[Station]); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Labels) > 0 {
+		buf = appendJSONField(buf, start, `"labels":`)
+		if buf, err = appendJSONMap(buf, c.Labels, appendJSONString); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Series) > 0 {
+		buf = appendJSONField(buf, start, `"series":`)
+		if buf, err = appendJSONMap(buf, c.Series, appendJSONArrayOf(appendJSONFloat[float64])); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Extra) > 0 {
+		buf = appendJSONField(buf, start, `"extra":`)
+		if buf, err = appendJSONMap(buf, c.Extra, appendJSONAny); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Raw) > 0 {
+		buf = appendJSONField(buf, start, `"raw":`)
+		if buf, err = appendJSONBytes(buf, &c.Raw); err != nil {
+			return buf, err
+		}
+	}
+	if len(c.Checksum) > 0 {
+		buf = appendJSONField(buf, start, `"checksum":`)
+		if buf, err = appendJSONBytes(buf, &c.Checksum); err != nil {
+			return buf, err
+		}
+	}
+	if c.Verified != nil {
+		buf = appendJSONField(buf, start, `"verified":`)
+		if buf, err = appendJSONBool(buf, c.Verified); err != nil {
+			return buf, err
+		}
+	}
+	if c.Note != nil {
+		buf = appendJSONField(buf, start, `"note":`)
+		if buf, err = appendJSONString(buf, c.Note); err != nil {
+			return buf, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// decodeJSON decodes the `Measurement` from d, skipping unknown properties.
+func (c *Measurement) decodeJSON(d *jsonDecoder) error {
+	return d.object(func(key []byte) error {
+		switch d.field(key, "sequence", "channel", "temperature", "unit", "takenAt", "calibratedAt", "station", "backup", "samples", "grid", "units", "neighbors", "labels", "series", "extra", "raw", "checksum", "verified", "note") {
+		case "sequence":
+			return decodeJSONInt(d, &c.Sequence)
+		case "channel":
+			return decodeJSONPointer(d, &c.Channel, decodeJSONInt[int32])
+		case "temperature":
+			return decodeJSONFloat(d, &c.Temperature)
+		case "unit":
+			return c.Unit.decodeJSON(d)
+		case "takenAt":
+			return decodeJSONTime(d, &c.TakenAt)
+		case "calibratedAt":
+			return decodeJSONPointer(d, &c.CalibratedAt, decodeJSONTime)
+		case "station":
+			return decodeJSONPointer(d, &c.Station, decodeJSONValue[Station])
+		case "backup":
+			return decodeJSONPointer(d, &c.Backup, decodeJSONValue[Station])
+		case "samples":
+			return decodeJSONArray(d, &c.Samples, decodeJSONFloat[float32])
+		case "grid":
+			return decodeJSONArray(d, &c.Grid, decodeJSONArrayOf(decodeJSONInt[int]))
+		case "units":
+			return decodeJSONArray(d, &c.Units, decodeJSONValue[Unit])
+		case "neighbors":
+			return decodeJSONArray(d, &c.Neighbors, decodeJSONValue[Station])
+		case "labels":
+			return decodeJSONMap(d, &c.Labels, decodeJSONString)
+		case "series":
+			return decodeJSONMap(d, &c.Series, decodeJSONArrayOf(decodeJSONFloat[float64]))
+		case "extra":
+			return decodeJSONMap(d, &c.Extra, decodeJSONAny)
+		case "raw":
+			return decodeJSONBytes(d, &c.Raw)
+		case "checksum":
+			return decodeJSONBytes(d, &c.Checksum)
+		case "verified":
+			return decodeJSONPointer(d, &c.Verified, decodeJSONBool)
+		case "note":
+			return decodeJSONPointer(d, &c.Note, decodeJSONString)
+		}
+		return d.skip()
+	})
+}
+
+// Accepted represents a measurement that was accepted.
+type Accepted struct {
+	// The id of the stored measurement
+	Id int `json:"id"`
+}
+
+// NewAccepted returns a new `Accepted` with the default values of its schema.
+func NewAccepted() *Accepted {
+	return &Accepted{}
+}
+
+// ParseAccepted parses a JSON string and returns the value.
+func ParseAccepted(s string) (value Accepted, err error) {
+	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Accepted` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Accepted) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Accepted`.
+func (c *Accepted) GetSchema() XTPSchema {
+	return XTPSchema{
+		"id": "integer",
+	}
+}
+
+// MarshalJSON implements json.Marshaler without reflection.
+func (c Accepted) MarshalJSON() ([]byte, error) {
+	return c.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection.
+func (c *Accepted) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, c.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the `Accepted` to buf.
+func (c *Accepted) appendJSON(buf []byte) ([]byte, error) {
+	var err error
+	start := len(buf)
+	buf = append(buf, '{')
+	buf = appendJSONField(buf, start, `"id":`)
+	if buf, err = appendJSONInt(buf, &c.Id); err != nil {
+		return buf, err
+	}
+	return append(buf, '}'), nil
+}
+
+// decodeJSON decodes the `Accepted` from d, skipping unknown properties.
+func (c *Accepted) decodeJSON(d *jsonDecoder) error {
+	return d.object(func(key []byte) error {
+		switch d.field(key, "id") {
+		case "id":
+			return decodeJSONInt(d, &c.Id)
+		}
+		return d.skip()
+	})
+}
+
+// Rejected represents a measurement that was rejected.
+type Rejected struct {
+	// Why the measurement was rejected
+	Reason string `json:"reason"`
+}
+
+// NewRejected returns a new `Rejected` with the default values of its schema.
+func NewRejected() *Rejected {
+	return &Rejected{}
+}
+
+// ParseRejected parses a JSON string and returns the value.
+func ParseRejected(s string) (value Rejected, err error) {
+	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Rejected` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Rejected) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Rejected`.
+func (c *Rejected) GetSchema() XTPSchema {
+	return XTPSchema{
+		"reason": "string",
+	}
+}
+
+// MarshalJSON implements json.Marshaler without reflection.
+func (c Rejected) MarshalJSON() ([]byte, error) {
+	return c.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection.
+func (c *Rejected) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, c.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the `Rejected` to buf.
+func (c *Rejected) appendJSON(buf []byte) ([]byte, error) {
+	var err error
+	start := len(buf)
+	buf = append(buf, '{')
+	buf = appendJSONField(buf, start, `"reason":`)
+	if buf, err = appendJSONString(buf, &c.Reason); err != nil {
+		return buf, err
+	}
+	return append(buf, '}'), nil
+}
+
+// decodeJSON decodes the `Rejected` from d, skipping unknown properties.
+func (c *Rejected) decodeJSON(d *jsonDecoder) error {
+	return d.object(func(key []byte) error {
+		switch d.field(key, "reason") {
+		case "reason":
+			return decodeJSONString(d, &c.Reason)
+		}
+		return d.skip()
+	})
+}
+
+// Outcome represents the outcome of recording a measurement.
+// Its `Value` is one of `*Accepted` or `*Rejected`, selected in JSON by
+// its "status" property.
+type Outcome struct {
+	Value OutcomeValue
+}
+
+// OutcomeValue is implemented by the variants of `Outcome`.
+type OutcomeValue interface {
+	isOutcome()
+}
+
+func (*Accepted) isOutcome() {}
+
+func (*Rejected) isOutcome() {}
+
+// ParseOutcome parses a JSON string and returns the value.
+func ParseOutcome(s string) (value Outcome, err error) {
+	if err := value.UnmarshalJSON([]byte(s)); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Outcome` has no value
+// or its value violates a constraint of its schema.
+func (v *Outcome) Validate() error {
+	switch value := v.Value.(type) {
+	case *Accepted:
+		if value != nil {
+			return value.Validate()
+		}
+	case *Rejected:
+		if value != nil {
+			return value.Validate()
+		}
+	}
+	return errors.New("Outcome: missing value")
+}
+
+// MarshalJSON implements json.Marshaler without reflection by adding the
+// "status" of the variant to its properties.
+func (v Outcome) MarshalJSON() ([]byte, error) {
+	return v.appendJSON(nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler without reflection by decoding
+// the variant that is selected by the "status" property.
+func (v *Outcome) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, v.decodeJSON)
+}
+
+// appendJSON appends the JSON encoding of the `Outcome` to buf.
+func (v *Outcome) appendJSON(buf []byte) ([]byte, error) {
+	var prefix string
+	var fields []byte
+	var err error
+	switch value := v.Value.(type) {
+	case *Accepted:
+		prefix = `{"status":"accepted"`
+		fields, err = appendJSONPointer(nil, value, appendJSONValue[Accepted])
+	case *Rejected:
+		prefix = `{"status":"rejected"`
+		fields, err = appendJSONPointer(nil, value, appendJSONValue[Rejected])
+	default:
+		return append(buf, "null"...), nil
+	}
+	if err != nil || string(fields) == "null" {
+		return append(buf, fields...), err
+	}
+
+	buf = append(buf, prefix...)
+	if len(fields) > 2 {
+		buf = append(buf, ',')
+	}
+	return append(buf, fields[1:]...), nil
+}
+
+// decodeJSON decodes the `Outcome` from d by decoding the variant that is
+// selected by the "status" property.
+func (v *Outcome) decodeJSON(d *jsonDecoder) error {
+	if d.null() {
+		return nil
+	}
+	start := d.pos
+	var discriminator *string
+	if err := d.object(func(key []byte) error {
+		if d.field(key, "status") != "status" {
+			return d.skip()
+		}
+		return decodeJSONPointer(d, &discriminator, decodeJSONString)
+	}); err != nil {
+		return err
+	}
+	if discriminator == nil {
+		return errors.New(`Outcome: missing "status"`)
+	}
+	d.pos = start
+
+	switch *discriminator {
+	case "accepted":
+		value := &Accepted{}
+		if err := value.decodeJSON(d); err != nil {
+			return err
+		}
+		v.Value = value
+	case "rejected":
+		value := &Rejected{}
+		if err := value.decodeJSON(d); err != nil {
+			return err
+		}
+		v.Value = value
+	default:
+		return fmt.Errorf("Outcome: unknown status %q", *discriminator)
+	}
+	return nil
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
+
+// appendJSONAny appends a value decoded by decodeJSONAny, or a number,
+// to buf as JSON.
+func appendJSONAny(buf []byte, v *any) ([]byte, error) {
+	switch v := (*v).(type) {
+	case nil:
+		return append(buf, "null"...), nil
+	case bool:
+		return strconv.AppendBool(buf, v), nil
+	case string:
+		return appendJSONQuoted(buf, v), nil
+	case float64:
+		return appendJSONNumber(buf, v, 64)
+	case float32:
+		return appendJSONNumber(buf, float64(v), 32)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(buf, v, 10), nil
+	case []any:
+		return appendJSONArray(buf, v, appendJSONAny)
+	case map[string]any:
+		return appendJSONMap(buf, v, appendJSONAny)
+	}
+	return buf, errors.New("json: unsupported type in a free-form value")
+}
+
+// decodeJSONAny decodes any JSON value like encoding/json does into an interface value.
+func decodeJSONAny(d *jsonDecoder, v *any) error {
+	switch d.peek() {
+	case 'n':
+		if !d.null() {
+			return d.error("invalid literal")
+		}
+		*v = nil
+	case 't', 'f':
+		b, err := d.bool()
+		if err != nil {
+			return err
+		}
+		*v = b
+	case '"':
+		s, err := d.string()
+		if err != nil {
+			return err
+		}
+		*v = s
+	case '[':
+		var values []any
+		if err := decodeJSONArray(d, &values, decodeJSONAny); err != nil {
+			return err
+		}
+		*v = values
+	case '{':
+		var values map[string]any
+		if err := decodeJSONMap(d, &values, decodeJSONAny); err != nil {
+			return err
+		}
+		*v = values
+	default:
+		f, err := d.float(64)
+		if err != nil {
+			return err
+		}
+		*v = f
+	}
+	return nil
+}
+
+// appendJSONBytes appends a buffer to buf as a base64-encoded JSON string.
+func appendJSONBytes(buf []byte, v *[]byte) ([]byte, error) {
+	if *v == nil {
+		return append(buf, "null"...), nil
+	}
+	buf = append(buf, '"')
+	buf = base64.StdEncoding.AppendEncode(buf, *v)
+	return append(buf, '"'), nil
+}
+
+// decodeJSONBytes decodes a base64-encoded JSON string, or an array of bytes.
+func decodeJSONBytes(d *jsonDecoder, v *[]byte) error {
+	switch d.peek() {
+	case 'n':
+		if d.null() {
+			*v = nil
+			return nil
+		}
+	case '[':
+		return decodeJSONArray(d, v, decodeJSONInt[byte])
+	}
+	s, err := d.string()
+	if err != nil {
+		return err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*v = b
+	return nil
+}
+
+// appendJSONTime appends a date-time to buf as an RFC 3339 JSON string.
+func appendJSONTime(buf []byte, v *time.Time) ([]byte, error) {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return buf, err
+	}
+	return append(buf, b...), nil
+}
+
+// decodeJSONTime decodes an RFC 3339 JSON string like time.Time.UnmarshalJSON.
+func decodeJSONTime(d *jsonDecoder, v *time.Time) error {
+	raw, err := d.raw()
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalJSON(raw)
+}
+
+// appendJSONString appends a string to buf as JSON.
+func appendJSONString(buf []byte, v *string) ([]byte, error) {
+	return appendJSONQuoted(buf, *v), nil
+}
+
+// decodeJSONString decodes a JSON string. A null is ignored.
+func decodeJSONString(d *jsonDecoder, v *string) error {
+	if d.null() {
+		return nil
+	}
+	s, err := d.string()
+	if err != nil {
+		return err
+	}
+	*v = s
+	return nil
+}
+
+// appendJSONBool appends a boolean to buf as JSON.
+func appendJSONBool(buf []byte, v *bool) ([]byte, error) {
+	return strconv.AppendBool(buf, *v), nil
+}
+
+// decodeJSONBool decodes a JSON boolean. A null is ignored.
+func decodeJSONBool(d *jsonDecoder, v *bool) error {
+	if d.null() {
+		return nil
+	}
+	b, err := d.bool()
+	if err != nil {
+		return err
+	}
+	*v = b
+	return nil
+}
+
+// appendJSONFloat appends a number to buf as JSON.
+func appendJSONFloat[T float32 | float64](buf []byte, v *T) ([]byte, error) {
+	if _, ok := any(v).(*float32); ok {
+		return appendJSONNumber(buf, float64(*v), 32)
+	}
+	return appendJSONNumber(buf, float64(*v), 64)
+}
+
+// appendJSONNumber appends f to buf like encoding/json does: without an
+// exponent unless f is very small or very large.
+func appendJSONNumber(buf []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return buf, errors.New("json: unsupported value: " + strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	buf = strconv.AppendFloat(buf, f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		if n := len(buf); n >= 4 && buf[n-4] == 'e' && buf[n-3] == '-' && buf[n-2] == '0' {
+			buf[n-2] = buf[n-1]
+			buf = buf[:n-1]
+		}
+	}
+	return buf, nil
+}
+
+// decodeJSONFloat decodes a JSON number. A null is ignored.
+func decodeJSONFloat[T float32 | float64](d *jsonDecoder, v *T) error {
+	if d.null() {
+		return nil
+	}
+	bits := 64
+	if _, ok := any(v).(*float32); ok {
+		bits = 32
+	}
+	f, err := d.float(bits)
+	if err != nil {
+		return err
+	}
+	*v = T(f)
+	return nil
+}
+
+// appendJSONInt appends an integer to buf as JSON.
+func appendJSONInt[T int | int32 | int64 | byte](buf []byte, v *T) ([]byte, error) {
+	return strconv.AppendInt(buf, int64(*v), 10), nil
+}
+
+// decodeJSONInt decodes a JSON integer. A null is ignored.
+func decodeJSONInt[T int | int32 | int64 | byte](d *jsonDecoder, v *T) error {
+	if d.null() {
+		return nil
+	}
+	s, err := d.number()
+	if err != nil {
+		return err
+	}
+	var n int64
+	switch any(v).(type) {
+	case *byte:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, 8)
+		n = int64(u)
+	case *int32:
+		n, err = strconv.ParseInt(s, 10, 32)
+	case *int64:
+		n, err = strconv.ParseInt(s, 10, 64)
+	default:
+		n, err = strconv.ParseInt(s, 10, strconv.IntSize)
+	}
+	if err != nil {
+		return d.error("cannot unmarshal number " + s + " into an integer")
+	}
+	*v = T(n)
+	return nil
+}
+
+// appendJSONMap appends a map to buf as a JSON object with sorted keys.
+func appendJSONMap[V any](buf []byte, m map[string]V, elem func([]byte, *V) ([]byte, error)) ([]byte, error) {
+	if m == nil {
+		return append(buf, "null"...), nil
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	buf = append(buf, '{')
+	for i, key := range keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONQuoted(buf, key)
+		buf = append(buf, ':')
+		value := m[key]
+		var err error
+		if buf, err = elem(buf, &value); err != nil {
+			return buf, err
+		}
+	}
+	return append(buf, '}'), nil
+}
+
+// appendJSONMapOf returns the appender of a map with elements appended by elem.
+func appendJSONMapOf[V any](elem func([]byte, *V) ([]byte, error)) func([]byte, *map[string]V) ([]byte, error) {
+	return func(buf []byte, m *map[string]V) ([]byte, error) {
+		return appendJSONMap(buf, *m, elem)
+	}
+}
+
+// decodeJSONMap decodes a JSON object into a map, adding to its existing
+// entries like encoding/json does. A null sets the map to nil.
+func decodeJSONMap[V any](d *jsonDecoder, m *map[string]V, elem func(*jsonDecoder, *V) error) error {
+	if d.null() {
+		*m = nil
+		return nil
+	}
+	if d.peek() != '{' {
+		return d.error("expected an object")
+	}
+	if *m == nil {
+		*m = map[string]V{}
+	}
+	return d.object(func(key []byte) error {
+		var value V
+		if err := elem(d, &value); err != nil {
+			return err
+		}
+		(*m)[string(key)] = value
+		return nil
+	})
+}
+
+// decodeJSONMapOf returns the decoder of a map with elements decoded by elem.
+func decodeJSONMapOf[V any](elem func(*jsonDecoder, *V) error) func(*jsonDecoder, *map[string]V) error {
+	return func(d *jsonDecoder, m *map[string]V) error {
+		return decodeJSONMap(d, m, elem)
+	}
+}
+
+// appendJSONArray appends a slice to buf as a JSON array.
+func appendJSONArray[T any](buf []byte, s []T, elem func([]byte, *T) ([]byte, error)) ([]byte, error) {
+	if s == nil {
+		return append(buf, "null"...), nil
+	}
+	buf = append(buf, '[')
+	for i := range s {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		var err error
+		if buf, err = elem(buf, &s[i]); err != nil {
+			return buf, err
+		}
+	}
+	return append(buf, ']'), nil
+}
+
+// appendJSONArrayOf returns the appender of a slice with elements appended by elem.
+func appendJSONArrayOf[T any](elem func([]byte, *T) ([]byte, error)) func([]byte, *[]T) ([]byte, error) {
+	return func(buf []byte, s *[]T) ([]byte, error) {
+		return appendJSONArray(buf, *s, elem)
+	}
+}
+
+// decodeJSONArray decodes a JSON array into a slice, reusing its elements
+// like encoding/json does. A null sets the slice to nil.
+func decodeJSONArray[T any](d *jsonDecoder, s *[]T, elem func(*jsonDecoder, *T) error) error {
+	if d.null() {
+		*s = nil
+		return nil
+	}
+	if d.peek() != '[' {
+		return d.error("expected an array")
+	}
+	values, i := *s, 0
+	err := d.array(func() error {
+		if i >= len(values) {
+			if i < cap(values) {
+				values = values[:i+1]
+			} else {
+				var zero T
+				values = append(values, zero)
+			}
+		}
+		i++
+		return elem(d, &values[i-1])
+	})
+	if i == 0 {
+		values = []T{}
+	}
+	*s = values[:i]
+	return err
+}
+
+// decodeJSONArrayOf returns the decoder of a slice with elements decoded by elem.
+func decodeJSONArrayOf[T any](elem func(*jsonDecoder, *T) error) func(*jsonDecoder, *[]T) error {
+	return func(d *jsonDecoder, s *[]T) error {
+		return decodeJSONArray(d, s, elem)
+	}
+}
+
+// jsonCodec is implemented by the generated structs and enums.
+type jsonCodec[T any] interface {
+	*T
+	appendJSON(buf []byte) ([]byte, error)
+	decodeJSON(d *jsonDecoder) error
+}
+
+// appendJSONValue appends a generated struct or enum to buf as JSON.
+func appendJSONValue[T any, P jsonCodec[T]](buf []byte, v *T) ([]byte, error) {
+	return P(v).appendJSON(buf)
+}
+
+// decodeJSONValue decodes a generated struct or enum.
+func decodeJSONValue[T any, P jsonCodec[T]](d *jsonDecoder, v *T) error {
+	return P(v).decodeJSON(d)
+}
+
+// appendJSONPointer appends the value that v points to, or null.
+func appendJSONPointer[T any](buf []byte, v *T, elem func([]byte, *T) ([]byte, error)) ([]byte, error) {
+	if v == nil {
+		return append(buf, "null"...), nil
+	}
+	return elem(buf, v)
+}
+
+// decodeJSONPointer decodes into the value that p points to, allocating it
+// if needed. A null sets p to nil.
+func decodeJSONPointer[T any](d *jsonDecoder, p **T, elem func(*jsonDecoder, *T) error) error {
+	if d.null() {
+		*p = nil
+		return nil
+	}
+	if *p == nil {
+		*p = new(T)
+	}
+	return elem(d, *p)
+}
+
+// appendJSONField appends the separator and the quoted key of the next
+// property of the object that starts at buf[start].
+func appendJSONField(buf []byte, start int, key string) []byte {
+	if len(buf) > start+1 {
+		buf = append(buf, ',')
+	}
+	return append(buf, key...)
+}
+
+// appendJSONQuoted appends s to buf as a JSON string, escaped like encoding/json
+// does, including the HTML characters <, > and &.
+func appendJSONQuoted(buf []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	buf = append(buf, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			buf = append(buf, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				buf = append(buf, '\\', b)
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(s[i:])
+		if c == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, "\\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if c == '\u2028' || c == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hex[c&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
+
+// maxJSONDepth is the maximum nesting of arrays and objects, as in encoding/json.
+const maxJSONDepth = 10000
+
+// jsonDecoder decodes JSON without reflection, following the rules of
+// encoding/json for the generated UnmarshalJSON methods.
+type jsonDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// unmarshalJSON decodes all of data with decode.
+func unmarshalJSON(data []byte, decode func(d *jsonDecoder) error) error {
+	d := &jsonDecoder{data: data}
+	if err := decode(d); err != nil {
+		return err
+	}
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return d.error("invalid character after top-level value")
+	}
+	return nil
+}
+
+// error returns an error that reports the current offset.
+func (d *jsonDecoder) error(msg string) error {
+	return errors.New("json: " + msg + " at offset " + strconv.Itoa(d.pos))
+}
+
+// skipSpace skips the whitespace before the next token.
+func (d *jsonDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the first byte of the next token, or 0 at the end of the data.
+func (d *jsonDecoder) peek() byte {
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return d.data[d.pos]
+	}
+	return 0
+}
+
+// literal consumes the next token if it is lit.
+func (d *jsonDecoder) literal(lit string) bool {
+	if d.skipSpace(); len(d.data)-d.pos >= len(lit) && string(d.data[d.pos:d.pos+len(lit)]) == lit {
+		d.pos += len(lit)
+		return true
+	}
+	return false
+}
+
+// null consumes the next token if it is null.
+func (d *jsonDecoder) null() bool {
+	return d.literal("null")
+}
+
+// bool decodes a boolean.
+func (d *jsonDecoder) bool() (bool, error) {
+	switch {
+	case d.literal("true"):
+		return true, nil
+	case d.literal("false"):
+		return false, nil
+	}
+	return false, d.error("expected a boolean")
+}
+
+// number returns the next number, which is checked against the JSON grammar.
+func (d *jsonDecoder) number() (string, error) {
+	d.skipSpace()
+	start := d.pos
+	if d.pos < len(d.data) && d.data[d.pos] == '-' {
+		d.pos++
+	}
+	switch {
+	case d.pos < len(d.data) && d.data[d.pos] == '0':
+		d.pos++
+	case !d.digits():
+		return "", d.error("expected a number")
+	}
+	if d.pos < len(d.data) && d.data[d.pos] == '.' {
+		d.pos++
+		if !d.digits() {
+			return "", d.error("invalid number")
+		}
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		d.pos++
+		if d.pos < len(d.data) && (d.data[d.pos] == '+' || d.data[d.pos] == '-') {
+			d.pos++
+		}
+		if !d.digits() {
+			return "", d.error("invalid number")
+		}
+	}
+	return string(d.data[start:d.pos]), nil
+}
+
+// digits consumes a run of digits and reports whether there were any.
+func (d *jsonDecoder) digits() bool {
+	start := d.pos
+	for d.pos < len(d.data) && '0' <= d.data[d.pos] && d.data[d.pos] <= '9' {
+		d.pos++
+	}
+	return d.pos > start
+}
+
+// float decodes a number with the given precision.
+func (d *jsonDecoder) float(bits int) (float64, error) {
+	s, err := d.number()
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		return 0, d.error("cannot unmarshal number " + s + " into a float" + strconv.Itoa(bits))
+	}
+	return f, nil
+}
+
+// string decodes a string, replacing invalid UTF-8 and unpaired surrogates
+// with U+FFFD like encoding/json does.
+func (d *jsonDecoder) string() (string, error) {
+	b, err := d.stringBytes()
+	return string(b), err
+}
+
+// stringBytes decodes a string like string does, without copying a string
+// that has nothing to unescape.
+func (d *jsonDecoder) stringBytes() ([]byte, error) {
+	if d.peek() != '"' {
+		return nil, d.error("expected a string")
+	}
+	d.pos++
+	start := d.pos
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		if c == '"' {
+			d.pos++
+			return d.data[start : d.pos-1], nil
+		}
+		if c == '\\' || c < ' ' {
+			break
+		}
+		if c < utf8.RuneSelf {
+			d.pos++
+			continue
+		}
+		r, size := utf8.DecodeRune(d.data[d.pos:])
+		if r == utf8.RuneError && size == 1 {
+			break
+		}
+		d.pos += size
+	}
+
+	buf := append([]byte(nil), d.data[start:d.pos]...)
+	for d.pos < len(d.data) {
+		switch c := d.data[d.pos]; {
+		case c == '"':
+			d.pos++
+			return buf, nil
+		case c < ' ':
+			return nil, d.error("invalid character in string")
+		case c == '\\':
+			if d.pos+1 >= len(d.data) {
+				return nil, d.error("unexpected end of string")
+			}
+			switch e := d.data[d.pos+1]; e {
+			case '"', '\\', '/':
+				buf = append(buf, e)
+			case 'b':
+				buf = append(buf, '\b')
+			case 'f':
+				buf = append(buf, '\f')
+			case 'n':
+				buf = append(buf, '\n')
+			case 'r':
+				buf = append(buf, '\r')
+			case 't':
+				buf = append(buf, '\t')
+			case 'u':
+				r := d.hex4(d.pos + 2)
+				if r < 0 {
+					return nil, d.error("invalid escape in string")
+				}
+				d.pos += 6
+				if utf16.IsSurrogate(r) {
+					r2 := rune(-1)
+					if d.pos+1 < len(d.data) && d.data[d.pos] == '\\' && d.data[d.pos+1] == 'u' {
+						r2 = d.hex4(d.pos + 2)
+					}
+					if r = utf16.DecodeRune(r, r2); r != utf8.RuneError {
+						d.pos += 6
+					}
+				}
+				buf = utf8.AppendRune(buf, r)
+				continue
+			default:
+				return nil, d.error("invalid escape in string")
+			}
+			d.pos += 2
+		case c < utf8.RuneSelf:
+			buf = append(buf, c)
+			d.pos++
+		default:
+			r, size := utf8.DecodeRune(d.data[d.pos:])
+			buf = utf8.AppendRune(buf, r)
+			d.pos += size
+		}
+	}
+	return nil, d.error("unexpected end of string")
+}
+
+// hex4 returns the value of the 4 hex digits at data[pos:], or -1.
+func (d *jsonDecoder) hex4(pos int) rune {
+	if pos+4 > len(d.data) {
+		return -1
+	}
+	var r rune
+	for _, c := range d.data[pos : pos+4] {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return -1
+		}
+		r = r*16 + rune(c)
+	}
+	return r
+}
+
+// object decodes an object, calling value to decode the value of each key.
+// A null is ignored like encoding/json does for structs.
+func (d *jsonDecoder) object(value func(key []byte) error) error {
+	if d.null() {
+		return nil
+	}
+	if d.peek() != '{' {
+		return d.error("expected an object")
+	}
+	return d.nested('}', func() error {
+		key, err := d.stringBytes()
+		if err != nil {
+			return err
+		}
+		if d.peek() != ':' {
+			return d.error("expected a colon after an object key")
+		}
+		d.pos++
+		return value(key)
+	})
+}
+
+// array decodes an array, calling value to decode each of its elements.
+func (d *jsonDecoder) array(value func() error) error {
+	if d.peek() != '[' {
+		return d.error("expected an array")
+	}
+	return d.nested(']', value)
+}
+
+// nested decodes the comma-separated items of the array or object that
+// starts at the current byte and ends with end.
+func (d *jsonDecoder) nested(end byte, item func() error) error {
+	if d.depth++; d.depth > maxJSONDepth {
+		return d.error("exceeded max depth")
+	}
+	d.pos++
+	if d.peek() == end {
+		d.pos++
+		d.depth--
+		return nil
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		switch d.peek() {
+		case ',':
+			d.pos++
+		case end:
+			d.pos++
+			d.depth--
+			return nil
+		default:
+			return d.error("expected a comma or the end of an array or object")
+		}
+	}
+}
+
+// field returns the one of names that matches key exactly, or else
+// case-insensitively like encoding/json does, or else "".
+func (d *jsonDecoder) field(key []byte, names ...string) string {
+	for _, name := range names {
+		if string(key) == name {
+			return name
+		}
+	}
+	for _, name := range names {
+		if strings.EqualFold(string(key), name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// raw returns the next value undecoded.
+func (d *jsonDecoder) raw() ([]byte, error) {
+	d.skipSpace()
+	start := d.pos
+	if err := d.skip(); err != nil {
+		return nil, err
+	}
+	return d.data[start:d.pos], nil
+}
+
+// skip skips the next value, checking it against the JSON grammar.
+func (d *jsonDecoder) skip() error {
+	switch d.peek() {
+	case '{':
+		return d.object(func([]byte) error { return d.skip() })
+	case '[':
+		return d.array(d.skip)
+	case '"':
+		_, err := d.string()
+		return err
+	case 't', 'f':
+		_, err := d.bool()
+		return err
+	case 'n':
+		if d.null() {
+			return nil
+		}
+		return d.error("invalid literal")
+	}
+	_, err := d.number()
+	return err
+}
@@ -0,0 +1,32 @@
+//go:build tinygo
+
+package main
+
+import (
+	"errors"
+
+	"github.com/extism/go-pdk"
+)
+
+// hostErrorVar is the name of the Extism var used by the host to report
+// an error from a host function.
+const hostErrorVar = "xtp-host-error"
+
+//go:wasmimport extism:host/user lastMeasurement
+func hostLastMeasurement(uint64) uint64
+
+// LastMeasurement - Returns the last measurement of the station.
+func LastMeasurement(input string) (result Measurement, err error) {
+	mem := pdk.AllocateString(input)
+	ptr := hostLastMeasurement(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	if err := result.UnmarshalJSON(rmem.ReadBytes()); err != nil {
+		return result, err
+	}
+	return result, nil
+}
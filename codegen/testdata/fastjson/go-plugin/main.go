@@ -0,0 +1,24 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// RecordMeasurement - Records a measurement and returns the outcome.
+func RecordMeasurement(input Measurement) Outcome {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin RecordMeasurement")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin RecordMeasurement")
+	return Outcome{}
+}
+
+// CountSamples - Counts the samples of the measurements.
+func CountSamples(input []Measurement) int {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin CountSamples")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin CountSamples")
+	return 0
+}
+
+func main() {}
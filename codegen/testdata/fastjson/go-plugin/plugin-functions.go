@@ -0,0 +1,50 @@
+//go:build tinygo
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/extism/go-pdk"
+)
+
+//export recordMeasurement
+func recordMeasurement() int {
+	in := pdk.InputString()
+	input, err := ParseMeasurement(in)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseMeasurement input: %v, input:\n%v\n", err, in))
+		return 1 // failure
+	}
+
+	output := RecordMeasurement(input)
+
+	buf, err := output.MarshalJSON()
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to encode JSON output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export countSamples
+func countSamples() int {
+	var input []Measurement
+	if err := unmarshalJSON(pdk.Input(), func(d *jsonDecoder) error { return decodeJSONArrayOf(decodeJSONValue[Measurement])(d, &input) }); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to decode JSON input: %v", err))
+		return 1 // failure
+	}
+
+	output := CountSamples(input)
+
+	buf, err := appendJSONInt[int](nil, &output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to encode JSON output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
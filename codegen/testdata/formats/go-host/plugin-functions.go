@@ -0,0 +1,42 @@
+package formats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// RecordReading - Records a sensor reading and returns its stored copy.
+func (p *Plugin) RecordReading(ctx context.Context, input Reading) (output Reading, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("recordReading: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "recordReading", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("recordReading: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("recordReading: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("recordReading: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
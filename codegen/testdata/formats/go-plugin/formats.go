@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+	"time"
+)
+
+// Calibration represents the calibration of a sensor.
+type Calibration struct {
+	// When the sensor was calibrated
+	At time.Time `json:"at"`
+	// The offset applied to every sample
+	Offset float32 `json:"offset"`
+	// The signature of the calibration certificate
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// NewCalibration returns a new `Calibration` with the default values of its schema.
+func NewCalibration() *Calibration {
+	return &Calibration{}
+}
+
+// ParseCalibration parses a JSON string and returns the value.
+func ParseCalibration(s string) (value Calibration, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Calibration` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Calibration) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Calibration`.
+func (c *Calibration) GetSchema() XTPSchema {
+	return XTPSchema{
+		"at":        "Date",
+		"offset":    "number",
+		"signature": "?string",
+	}
+}
+
+// Reading represents a reading of a sensor.
+type Reading struct {
+	// The ID of the sensor
+	SensorId int32 `json:"sensorId"`
+	// The sequence number of the reading
+	Sequence int64 `json:"sequence"`
+	// When the reading was taken
+	TakenAt time.Time `json:"takenAt"`
+	// The calibrated value
+	Value float64 `json:"value"`
+	// The raw bytes read from the sensor
+	Raw []byte `json:"raw"`
+	// The gain of the sensor
+	Gain *float32 `json:"gain,omitempty"`
+	// The channel of the sensor
+	Channel *int32 `json:"channel,omitempty"`
+	// The epoch of the sequence number
+	Epoch *int64 `json:"epoch,omitempty"`
+	// When the reading was received
+	ReceivedAt *time.Time `json:"receivedAt,omitempty"`
+	// The checksum of the raw bytes
+	Checksum []byte `json:"checksum,omitempty"`
+	// The samples averaged into the value
+	Samples []float32 `json:"samples,omitempty"`
+	// When the sensor raised events
+	Events []time.Time `json:"events,omitempty"`
+	// Counters by name
+	Counters map[string]int64 `json:"counters,omitempty"`
+}
+
+// NewReading returns a new `Reading` with the default values of its schema.
+func NewReading() *Reading {
+	return &Reading{}
+}
+
+// ParseReading parses a JSON string and returns the value.
+func ParseReading(s string) (value Reading, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Reading` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Reading) Validate() error {
+	if c.Raw == nil {
+		return fmt.Errorf("raw: required")
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Reading`.
+func (c *Reading) GetSchema() XTPSchema {
+	return XTPSchema{
+		"sensorId":   "integer",
+		"sequence":   "integer",
+		"takenAt":    "Date",
+		"value":      "number",
+		"raw":        "string",
+		"gain":       "?number",
+		"channel":    "?integer",
+		"epoch":      "?integer",
+		"receivedAt": "?Date",
+		"checksum":   "?string",
+		"samples":    "?Array<number>",
+		"events":     "?Array<Date>",
+		"counters":   "?Map<string, integer>",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool           { return &b }
+func float64Ptr(f float64) *float64  { return &f }
+func intPtr(i int) *int              { return &i }
+func stringPtr(s string) *string     { return &s }
+func float32Ptr(f float32) *float32  { return &f }
+func int32Ptr(i int32) *int32        { return &i }
+func int64Ptr(i int64) *int64        { return &i }
+func timePtr(t time.Time) *time.Time { return &t }
+
+func TestCalibrationMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Calibration
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Calibration{
+				At:     time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+				Offset: 0,
+			},
+			want: `{"at":"2024-01-02T03:04:05Z","offset":0}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Calibration{
+				Signature: []byte("signature"),
+			},
+			want: `{"at":"0001-01-01T00:00:00Z","offset":0,"signature":"c2lnbmF0dXJl"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Calibration
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestCalibrationValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Calibration)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Calibration) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Calibration{
+				At:     time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+				Offset: 0,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadingMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Reading
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Reading{
+				SensorId: 0,
+				Sequence: 0,
+				TakenAt:  time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+				Value:    0,
+				Raw:      []byte("raw"),
+			},
+			want: `{"sensorId":0,"sequence":0,"takenAt":"2024-01-02T03:04:05Z","value":0,"raw":"cmF3"}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Reading{
+				Gain:       float32Ptr(0),
+				Channel:    int32Ptr(0),
+				Epoch:      int64Ptr(0),
+				ReceivedAt: timePtr(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
+				Checksum:   []byte("checksum"),
+				Samples:    []float32{1.5},
+				Events:     []time.Time{time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)},
+				Counters:   map[string]int64{"key": 1},
+			},
+			want: `{"sensorId":0,"sequence":0,"takenAt":"0001-01-01T00:00:00Z","value":0,"raw":null,"gain":0,"channel":0,"epoch":0,"receivedAt":"2024-01-02T03:04:05Z","checksum":"Y2hlY2tzdW0=","samples":[1.5],"events":["2024-01-02T03:04:05Z"],"counters":{"key":1}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Reading
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReadingValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Reading)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Reading) {}},
+		{name: "missing raw", modify: func(v *Reading) { v.Raw = nil }, wantErr: "raw: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Reading{
+				SensorId: 0,
+				Sequence: 0,
+				TakenAt:  time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC),
+				Value:    0,
+				Raw:      []byte{},
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
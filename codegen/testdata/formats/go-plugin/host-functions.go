@@ -0,0 +1,38 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/extism/go-pdk"
+)
+
+// hostErrorVar is the name of the Extism var used by the host to report
+// an error from a host function.
+const hostErrorVar = "xtp-host-error"
+
+//go:wasmimport extism:host/user lastCalibration
+func hostLastCalibration(uint64) uint64
+
+// LastCalibration - Returns the time at which the sensor was last calibrated.
+func LastCalibration(input string) (result Calibration, err error) {
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return result, err
+	}
+
+	mem := pdk.AllocateBytes(buf)
+	ptr := hostLastCalibration(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	if err := json.Unmarshal(rmem.ReadBytes(), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
@@ -0,0 +1,16 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// RecordReading - Records a sensor reading and returns its stored copy.
+func RecordReading(input Reading) Reading {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin RecordReading")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin RecordReading")
+	return Reading{}
+}
+
+func main() {}
@@ -0,0 +1,31 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/extism/go-pdk"
+)
+
+//export recordReading
+func recordReading() int {
+	in := pdk.InputString()
+	input, err := ParseReading(in)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseReading input: %v, input:\n%v\n", err, in))
+		return 1 // failure
+	}
+
+	output := RecordReading(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
@@ -0,0 +1,231 @@
+// Package fruit represents the custom datatypes for an XTP Extension Plugin.
+package fruit
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+	"time"
+)
+
+// Fruit represents a set of available fruits you can consume.
+type Fruit string
+
+const (
+	FruitEnumApple      Fruit = "apple"
+	FruitEnumOrange     Fruit = "orange"
+	FruitEnumBanana     Fruit = "banana"
+	FruitEnumStrawberry Fruit = "strawberry"
+)
+
+// AllFruit returns all the values of `Fruit` in the order of its schema.
+func AllFruit() []Fruit {
+	return []Fruit{
+		FruitEnumApple,
+		FruitEnumOrange,
+		FruitEnumBanana,
+		FruitEnumStrawberry,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Fruit`.
+func (v Fruit) IsValid() bool {
+	switch v {
+	case FruitEnumApple, FruitEnumOrange, FruitEnumBanana, FruitEnumStrawberry:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Fruit) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Fruit) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Fruit) UnmarshalText(text []byte) error {
+	value, err := FruitFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Fruit) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// FruitFromString returns the `Fruit` with the given (unquoted) value.
+func FruitFromString(s string) (Fruit, error) {
+	if v := Fruit(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Fruit: %q", s)
+}
+
+// ParseFruit parses a JSON string and returns the value.
+func ParseFruit(s string) (value Fruit, err error) {
+	switch s {
+	case `"apple"`:
+		return FruitEnumApple, nil
+	case `"orange"`:
+		return FruitEnumOrange, nil
+	case `"banana"`:
+		return FruitEnumBanana, nil
+	case `"strawberry"`:
+		return FruitEnumStrawberry, nil
+	default:
+		return value, fmt.Errorf("not a Fruit: %v", s)
+	}
+}
+
+// GhostGang represents a set of all the enemies of pac-man.
+type GhostGang string
+
+const (
+	GhostGangEnumBlinky GhostGang = "blinky"
+	GhostGangEnumPinky  GhostGang = "pinky"
+	GhostGangEnumInky   GhostGang = "inky"
+	GhostGangEnumClyde  GhostGang = "clyde"
+)
+
+// AllGhostGang returns all the values of `GhostGang` in the order of its schema.
+func AllGhostGang() []GhostGang {
+	return []GhostGang{
+		GhostGangEnumBlinky,
+		GhostGangEnumPinky,
+		GhostGangEnumInky,
+		GhostGangEnumClyde,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `GhostGang`.
+func (v GhostGang) IsValid() bool {
+	switch v {
+	case GhostGangEnumBlinky, GhostGangEnumPinky, GhostGangEnumInky, GhostGangEnumClyde:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v GhostGang) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v GhostGang) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *GhostGang) UnmarshalText(text []byte) error {
+	value, err := GhostGangFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *GhostGang) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// GhostGangFromString returns the `GhostGang` with the given (unquoted) value.
+func GhostGangFromString(s string) (GhostGang, error) {
+	if v := GhostGang(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a GhostGang: %q", s)
+}
+
+// ParseGhostGang parses a JSON string and returns the value.
+func ParseGhostGang(s string) (value GhostGang, err error) {
+	switch s {
+	case `"blinky"`:
+		return GhostGangEnumBlinky, nil
+	case `"pinky"`:
+		return GhostGangEnumPinky, nil
+	case `"inky"`:
+		return GhostGangEnumInky, nil
+	case `"clyde"`:
+		return GhostGangEnumClyde, nil
+	default:
+		return value, fmt.Errorf("not a GhostGang: %v", s)
+	}
+}
+
+// ComplexObject represents a complex json object.
+type ComplexObject struct {
+	// I can override the description for the property here
+	Ghost GhostGang `json:"ghost"`
+	// A boolean prop
+	ABoolean bool `json:"aBoolean"`
+	// An string prop
+	AString string `json:"aString"`
+	// An int prop
+	AnInt int32 `json:"anInt"`
+	// A datetime object, we will automatically serialize and deserialize
+	// this for you.
+	AnOptionalDate *time.Time `json:"anOptionalDate,omitempty"`
+}
+
+// NewComplexObject returns a new `ComplexObject` with the default values of its schema.
+func NewComplexObject() *ComplexObject {
+	return &ComplexObject{}
+}
+
+// ParseComplexObject parses a JSON string and returns the value.
+func ParseComplexObject(s string) (value ComplexObject, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `ComplexObject` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *ComplexObject) Validate() error {
+	if !c.Ghost.IsValid() {
+		return fmt.Errorf("ghost: %q is not a valid GhostGang", c.Ghost)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `ComplexObject`.
+func (c *ComplexObject) GetSchema() XTPSchema {
+	return XTPSchema{
+		"ghost":          "GhostGang",
+		"aBoolean":       "boolean",
+		"aString":        "string",
+		"anInt":          "integer",
+		"anOptionalDate": "?Date",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -0,0 +1,83 @@
+package fruit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// HostErrorVar is the name of the Extism var used to report an error
+// from a host function back to the calling plugin.
+const HostErrorVar = "xtp-host-error"
+
+// HostFunctions represents the functions imported by the XTP Extension Plugin
+// which must be implemented by the host.
+type HostFunctions interface {
+	// EatAFruit - This is a host function. Right now host functions can only be the type (i64) -> i64.
+	// We will support more in the future. Much of the same rules as exports apply.
+	EatAFruit(ctx context.Context, input Fruit) (bool, error)
+}
+
+// NewHostFunctions returns the `extism.HostFunction`s that call impl
+// for each of the functions imported by the XTP Extension Plugin.
+func NewHostFunctions(impl HostFunctions) []extism.HostFunction {
+	return []extism.HostFunction{
+		NewEatAFruitHostFunction(impl.EatAFruit),
+	}
+}
+
+// reportHostError logs the error and reports it back to the calling plugin
+// through `HostErrorVar`.
+func reportHostError(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64, name string, err error) {
+	msg := fmt.Sprintf("%v: %v", name, err)
+	plugin.Log(extism.LogLevelError, msg)
+	if p, ok := ctx.Value(extism.PluginCtxKey("plugin")).(*extism.Plugin); ok {
+		p.Var[HostErrorVar] = []byte(msg)
+	}
+	stack[0] = 0
+}
+
+// NewEatAFruitHostFunction returns an `extism.HostFunction` that
+// implements the "eatAFruit" import by calling fn.
+func NewEatAFruitHostFunction(fn func(ctx context.Context, input Fruit) (bool, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"eatAFruit",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			buf, err := plugin.ReadBytes(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "eatAFruit", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			var input Fruit
+			if err := json.Unmarshal(buf, &input); err != nil {
+				reportHostError(ctx, plugin, stack, "eatAFruit", fmt.Errorf("unable to json.Unmarshal input: %w", err))
+				return
+			}
+
+			output, err := fn(ctx, input)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "eatAFruit", err)
+				return
+			}
+
+			outBuf, err := json.Marshal(output)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "eatAFruit", fmt.Errorf("unable to json.Marshal output: %w", err))
+				return
+			}
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "eatAFruit", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
@@ -4,30 +4,36 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/extism/go-pdk"
 )
 
+// hostErrorVar is the name of the Extism var used by the host to report
+// an error from a host function.
+const hostErrorVar = "xtp-host-error"
+
 //go:wasmimport extism:host/user eatAFruit
 func hostEatAFruit(uint64) uint64
 
 // EatAFruit - This is a host function. Right now host functions can only be the type (i64) -> i64.
 // We will support more in the future. Much of the same rules as exports apply.
-func EatAFruit(input Fruit) (bool, error) {
+func EatAFruit(input Fruit) (result bool, err error) {
 	buf, err := json.Marshal(input)
 	if err != nil {
-		return false, err
+		return result, err
 	}
 
 	mem := pdk.AllocateBytes(buf)
 	ptr := hostEatAFruit(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
 
 	rmem := pdk.FindMemory(ptr)
-	buf = rmem.ReadBytes()
-
-	var result bool
-	if err := json.Unmarshal(buf, &result); err != nil {
-		return false, err
+	if err := json.Unmarshal(rmem.ReadBytes(), &result); err != nil {
+		return result, err
 	}
 	return result, nil
 }
@@ -17,11 +17,7 @@ func voidFunc() int {
 
 //export primitiveTypeFunc
 func primitiveTypeFunc() int {
-	var input string
-	if err := json.Unmarshal([]byte(pdk.InputString()), &input); err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
-		return 1 // failure
-	}
+	input := pdk.InputString()
 
 	output := PrimitiveTypeFunc(input)
 
@@ -37,14 +33,14 @@ func primitiveTypeFunc() int {
 
 //export referenceTypeFunc
 func referenceTypeFunc() int {
-	input := pdk.InputString()
-	v, err := ParseFruit(input)
+	in := pdk.InputString()
+	input, err := ParseFruit(in)
 	if err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseFruit input: %v, input:\n%v\n", err, input))
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseFruit input: %v, input:\n%v\n", err, in))
 		return 1 // failure
 	}
 
-	output := ReferenceTypeFunc(v)
+	output := ReferenceTypeFunc(input)
 
 	buf, err := json.Marshal(output)
 	if err != nil {
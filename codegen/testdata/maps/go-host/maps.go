@@ -0,0 +1,146 @@
+// Package maps represents the custom datatypes for an XTP Extension Plugin.
+package maps
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// Severity represents a severity level.
+type Severity string
+
+const (
+	SeverityEnumLow  Severity = "low"
+	SeverityEnumHigh Severity = "high"
+)
+
+// AllSeverity returns all the values of `Severity` in the order of its schema.
+func AllSeverity() []Severity {
+	return []Severity{
+		SeverityEnumLow,
+		SeverityEnumHigh,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Severity`.
+func (v Severity) IsValid() bool {
+	switch v {
+	case SeverityEnumLow, SeverityEnumHigh:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Severity) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Severity) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Severity) UnmarshalText(text []byte) error {
+	value, err := SeverityFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Severity) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// SeverityFromString returns the `Severity` with the given (unquoted) value.
+func SeverityFromString(s string) (Severity, error) {
+	if v := Severity(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Severity: %q", s)
+}
+
+// ParseSeverity parses a JSON string and returns the value.
+func ParseSeverity(s string) (value Severity, err error) {
+	switch s {
+	case `"low"`:
+		return SeverityEnumLow, nil
+	case `"high"`:
+		return SeverityEnumHigh, nil
+	default:
+		return value, fmt.Errorf("not a Severity: %v", s)
+	}
+}
+
+// Metric represents a named measurement with free-form labels.
+type Metric struct {
+	// The name of the metric
+	Name string `json:"name"`
+	// The labels of the metric
+	Labels map[string]string `json:"labels"`
+	// The severity of each threshold
+	Thresholds map[string]Severity `json:"thresholds,omitempty"`
+	Counts     map[string]int      `json:"counts,omitempty"`
+	// The samples of each series
+	Series map[string][]float64 `json:"series,omitempty"`
+	// Arbitrary attributes
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// The raw payloads of each label
+	Blobs map[string][]byte `json:"blobs,omitempty"`
+}
+
+// NewMetric returns a new `Metric` with the default values of its schema.
+func NewMetric() *Metric {
+	return &Metric{}
+}
+
+// ParseMetric parses a JSON string and returns the value.
+func ParseMetric(s string) (value Metric, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Metric` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Metric) Validate() error {
+	if c.Labels == nil {
+		return fmt.Errorf("labels: required")
+	}
+	for k, v := range c.Thresholds {
+		if !v.IsValid() {
+			return fmt.Errorf("thresholds[%q]: %q is not a valid Severity", k, v)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Metric`.
+func (c *Metric) GetSchema() XTPSchema {
+	return XTPSchema{
+		"name":       "string",
+		"labels":     "Map<string, string>",
+		"thresholds": "?Map<string, Severity>",
+		"counts":     "?Map<string, integer>",
+		"series":     "?Map<string, Array<number>>",
+		"attributes": "?Map<string, any>",
+		"blobs":      "?Map<string, buffer>",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
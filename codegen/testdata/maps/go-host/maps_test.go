@@ -0,0 +1,173 @@
+package maps
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+
+	severity := SeverityEnumLow
+	buf, err := jsoncomp.Marshal(severity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"low"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseSeverity(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != severity {
+		t.Errorf("ParseSeverity = '%v', want '%v'", got, severity)
+	}
+}
+
+func TestSeverityValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllSeverity() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := SeverityFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("SeverityFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Severity
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllSeverity()), 2; got != want {
+		t.Errorf("len(AllSeverity()) = %v, want %v", got, want)
+	}
+}
+
+func TestSeverityRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Severity("not-a-severity")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := SeverityFromString(unknown.String()); err == nil {
+		t.Errorf("SeverityFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Severity
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-severity"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
+func TestMetricMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Metric
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Metric{
+				Name:   "name",
+				Labels: map[string]string{"key": "item"},
+			},
+			want: `{"name":"name","labels":{"key":"item"}}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Metric{
+				Thresholds: map[string]Severity{"key": SeverityEnumLow},
+				Counts:     map[string]int{"key": 1},
+				Series:     map[string][]float64{"key": []float64{1.5}},
+				Attributes: map[string]any{"key": "item"},
+				Blobs:      map[string][]byte{"key": []byte("item")},
+			},
+			want: `{"name":"","labels":null,"thresholds":{"key":"low"},"counts":{"key":1},"series":{"key":[1.5]},"attributes":{"key":"item"},"blobs":{"key":"aXRlbQ=="}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Metric
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestMetricValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Metric)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Metric) {}},
+		{name: "missing labels", modify: func(v *Metric) { v.Labels = nil }, wantErr: "labels: required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Metric{
+				Name:   "name",
+				Labels: map[string]string{},
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
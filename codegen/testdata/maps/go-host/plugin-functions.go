@@ -0,0 +1,42 @@
+package maps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// Summarize - Summarizes the metric by label.
+func (p *Plugin) Summarize(ctx context.Context, input Metric) (output map[string]float64, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("summarize: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "summarize", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("summarize: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("summarize: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("summarize: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
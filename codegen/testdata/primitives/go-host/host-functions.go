@@ -0,0 +1,240 @@
+package primitives
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// HostErrorVar is the name of the Extism var used to report an error
+// from a host function back to the calling plugin.
+const HostErrorVar = "xtp-host-error"
+
+// HostFunctions represents the functions imported by the XTP Extension Plugin
+// which must be implemented by the host.
+type HostFunctions interface {
+	// RandomNumber - Returns a random number from the host.
+	RandomNumber(ctx context.Context) (float64, error)
+	// Notify - Sends a notification to the host.
+	Notify(ctx context.Context, input string) error
+	// Ping - Pings the host.
+	Ping(ctx context.Context) error
+	// IsAllowed - Reports whether the user ID is allowed.
+	IsAllowed(ctx context.Context, input int) (bool, error)
+	// CurrentLevel - Returns the current log level from the host.
+	CurrentLevel(ctx context.Context) (Level, error)
+	// Translate - Translates the text on the host.
+	Translate(ctx context.Context, input string) (string, error)
+}
+
+// NewHostFunctions returns the `extism.HostFunction`s that call impl
+// for each of the functions imported by the XTP Extension Plugin.
+func NewHostFunctions(impl HostFunctions) []extism.HostFunction {
+	return []extism.HostFunction{
+		NewRandomNumberHostFunction(impl.RandomNumber),
+		NewNotifyHostFunction(impl.Notify),
+		NewPingHostFunction(impl.Ping),
+		NewIsAllowedHostFunction(impl.IsAllowed),
+		NewCurrentLevelHostFunction(impl.CurrentLevel),
+		NewTranslateHostFunction(impl.Translate),
+	}
+}
+
+// reportHostError logs the error and reports it back to the calling plugin
+// through `HostErrorVar`.
+func reportHostError(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64, name string, err error) {
+	msg := fmt.Sprintf("%v: %v", name, err)
+	plugin.Log(extism.LogLevelError, msg)
+	if p, ok := ctx.Value(extism.PluginCtxKey("plugin")).(*extism.Plugin); ok {
+		p.Var[HostErrorVar] = []byte(msg)
+	}
+	stack[0] = 0
+}
+
+// NewRandomNumberHostFunction returns an `extism.HostFunction` that
+// implements the "randomNumber" import by calling fn.
+func NewRandomNumberHostFunction(fn func(ctx context.Context) (float64, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"randomNumber",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			output, err := fn(ctx)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "randomNumber", err)
+				return
+			}
+
+			outBuf, err := json.Marshal(output)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "randomNumber", fmt.Errorf("unable to json.Marshal output: %w", err))
+				return
+			}
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "randomNumber", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
+
+// NewNotifyHostFunction returns an `extism.HostFunction` that
+// implements the "notify" import by calling fn.
+func NewNotifyHostFunction(fn func(ctx context.Context, input string) error) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"notify",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			buf, err := plugin.ReadBytes(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "notify", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			var input string
+			if err := json.Unmarshal(buf, &input); err != nil {
+				reportHostError(ctx, plugin, stack, "notify", fmt.Errorf("unable to json.Unmarshal input: %w", err))
+				return
+			}
+
+			if err := fn(ctx, input); err != nil {
+				reportHostError(ctx, plugin, stack, "notify", err)
+				return
+			}
+
+			stack[0] = 0
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
+
+// NewPingHostFunction returns an `extism.HostFunction` that
+// implements the "ping" import by calling fn.
+func NewPingHostFunction(fn func(ctx context.Context) error) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"ping",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			if err := fn(ctx); err != nil {
+				reportHostError(ctx, plugin, stack, "ping", err)
+				return
+			}
+
+			stack[0] = 0
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
+
+// NewIsAllowedHostFunction returns an `extism.HostFunction` that
+// implements the "isAllowed" import by calling fn.
+func NewIsAllowedHostFunction(fn func(ctx context.Context, input int) (bool, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"isAllowed",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			buf, err := plugin.ReadBytes(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "isAllowed", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			var input int
+			if err := json.Unmarshal(buf, &input); err != nil {
+				reportHostError(ctx, plugin, stack, "isAllowed", fmt.Errorf("unable to json.Unmarshal input: %w", err))
+				return
+			}
+
+			output, err := fn(ctx, input)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "isAllowed", err)
+				return
+			}
+
+			outBuf, err := json.Marshal(output)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "isAllowed", fmt.Errorf("unable to json.Marshal output: %w", err))
+				return
+			}
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "isAllowed", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
+
+// NewCurrentLevelHostFunction returns an `extism.HostFunction` that
+// implements the "currentLevel" import by calling fn.
+func NewCurrentLevelHostFunction(fn func(ctx context.Context) (Level, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"currentLevel",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			output, err := fn(ctx)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "currentLevel", err)
+				return
+			}
+
+			outBuf, err := json.Marshal(output)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "currentLevel", fmt.Errorf("unable to json.Marshal output: %w", err))
+				return
+			}
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "currentLevel", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
+
+// NewTranslateHostFunction returns an `extism.HostFunction` that
+// implements the "translate" import by calling fn.
+func NewTranslateHostFunction(fn func(ctx context.Context, input string) (string, error)) extism.HostFunction {
+	return extism.NewHostFunctionWithStack(
+		"translate",
+		func(ctx context.Context, plugin *extism.CurrentPlugin, stack []uint64) {
+			input, err := plugin.ReadString(stack[0])
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "translate", fmt.Errorf("unable to read input: %w", err))
+				return
+			}
+
+			output, err := fn(ctx, input)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "translate", err)
+				return
+			}
+
+			outBuf := []byte(output)
+
+			mem, err := plugin.WriteBytes(outBuf)
+			if err != nil {
+				reportHostError(ctx, plugin, stack, "translate", fmt.Errorf("unable to write output: %w", err))
+				return
+			}
+
+			stack[0] = mem
+		},
+		[]extism.ValueType{extism.ValueTypeI64},
+		[]extism.ValueType{extism.ValueTypeI64},
+	)
+}
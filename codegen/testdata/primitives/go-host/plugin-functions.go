@@ -0,0 +1,180 @@
+package primitives
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// CountWords - Counts the words in the text.
+func (p *Plugin) CountWords(ctx context.Context, input string) (output int, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("countWords: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "countWords", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("countWords: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("countWords: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("countWords: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// IsEven - Reports whether the number is even.
+func (p *Plugin) IsEven(ctx context.Context, input int) (output bool, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("isEven: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "isEven", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("isEven: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("isEven: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("isEven: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// Scale - Scales the value by the configured factor.
+func (p *Plugin) Scale(ctx context.Context, input float64) (output float64, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("scale: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "scale", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("scale: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("scale: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("scale: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// DescribeFlag - Describes the flag.
+func (p *Plugin) DescribeFlag(ctx context.Context, input bool) (output string, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("describeFlag: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "describeFlag", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("describeFlag: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("describeFlag: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("describeFlag: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// Sum - Sums the values.
+func (p *Plugin) Sum(ctx context.Context, input []int) (output int, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("sum: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "sum", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("sum: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("sum: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("sum: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// NextTicket - Returns the next ticket number.
+func (p *Plugin) NextTicket(ctx context.Context) (output int, err error) {
+	rc, outBuf, err := p.CallWithContext(ctx, "nextTicket", nil)
+	if err != nil {
+		return output, fmt.Errorf("nextTicket: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("nextTicket: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("nextTicket: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// LogMessage - Logs the message.
+func (p *Plugin) LogMessage(ctx context.Context, input string) (err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("logMessage: unable to json.Marshal input: %w", err)
+	}
+
+	rc, _, err := p.CallWithContext(ctx, "logMessage", inBuf)
+	if err != nil {
+		return fmt.Errorf("logMessage: %w", err)
+	}
+	if rc != 0 {
+		return fmt.Errorf("logMessage: plugin returned exit code %v", rc)
+	}
+
+	return nil
+}
+
+// Shout - Converts the text to upper case.
+func (p *Plugin) Shout(ctx context.Context, input string) (output string, err error) {
+	inBuf := []byte(input)
+
+	rc, outBuf, err := p.CallWithContext(ctx, "shout", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("shout: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("shout: plugin returned exit code %v", rc)
+	}
+
+	return string(outBuf), nil
+}
@@ -0,0 +1,124 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/extism/go-pdk"
+)
+
+// hostErrorVar is the name of the Extism var used by the host to report
+// an error from a host function.
+const hostErrorVar = "xtp-host-error"
+
+//go:wasmimport extism:host/user randomNumber
+func hostRandomNumber(uint64) uint64
+
+// RandomNumber - Returns a random number from the host.
+func RandomNumber() (result float64, err error) {
+	ptr := hostRandomNumber(0)
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	if err := json.Unmarshal(rmem.ReadBytes(), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+//go:wasmimport extism:host/user notify
+func hostNotify(uint64) uint64
+
+// Notify - Sends a notification to the host.
+func Notify(input string) (err error) {
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	mem := pdk.AllocateBytes(buf)
+	hostNotify(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return errors.New(string(errMsg))
+	}
+
+	return nil
+}
+
+//go:wasmimport extism:host/user ping
+func hostPing(uint64) uint64
+
+// Ping - Pings the host.
+func Ping() (err error) {
+	hostPing(0)
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return errors.New(string(errMsg))
+	}
+
+	return nil
+}
+
+//go:wasmimport extism:host/user isAllowed
+func hostIsAllowed(uint64) uint64
+
+// IsAllowed - Reports whether the user ID is allowed.
+func IsAllowed(input int) (result bool, err error) {
+	buf, err := json.Marshal(input)
+	if err != nil {
+		return result, err
+	}
+
+	mem := pdk.AllocateBytes(buf)
+	ptr := hostIsAllowed(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	if err := json.Unmarshal(rmem.ReadBytes(), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+//go:wasmimport extism:host/user currentLevel
+func hostCurrentLevel(uint64) uint64
+
+// CurrentLevel - Returns the current log level from the host.
+func CurrentLevel() (result Level, err error) {
+	ptr := hostCurrentLevel(0)
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	if err := json.Unmarshal(rmem.ReadBytes(), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+//go:wasmimport extism:host/user translate
+func hostTranslate(uint64) uint64
+
+// Translate - Translates the text on the host.
+func Translate(input string) (result string, err error) {
+	mem := pdk.AllocateString(input)
+	ptr := hostTranslate(mem.Offset())
+	if errMsg := pdk.GetVar(hostErrorVar); errMsg != nil {
+		pdk.RemoveVar(hostErrorVar)
+		return result, errors.New(string(errMsg))
+	}
+
+	rmem := pdk.FindMemory(ptr)
+	return string(rmem.ReadBytes()), nil
+}
@@ -0,0 +1,77 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// CountWords - Counts the words in the text.
+//
+// `input` - The text to count
+// Returns The number of words
+func CountWords(input string) int {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin CountWords")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin CountWords")
+	return 0
+}
+
+// IsEven - Reports whether the number is even.
+func IsEven(input int) bool {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin IsEven")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin IsEven")
+	return false
+}
+
+// Scale - Scales the value by the configured factor.
+func Scale(input float64) float64 {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Scale")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin Scale")
+	return 0.0
+}
+
+// DescribeFlag - Describes the flag.
+func DescribeFlag(input bool) string {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin DescribeFlag")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin DescribeFlag")
+	return ""
+}
+
+// Sum - Sums the values.
+func Sum(input []int) int {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Sum")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin Sum")
+	return 0
+}
+
+// NextTicket - Returns the next ticket number.
+func NextTicket() int {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin NextTicket")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin NextTicket")
+	return 0
+}
+
+// LogMessage - Logs the message.
+func LogMessage(input string) {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin LogMessage")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin LogMessage")
+}
+
+// Shout - Converts the text to upper case.
+//
+// `input` - The raw text
+// Returns The upper case text
+func Shout(input string) string {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Shout")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin Shout")
+	return ""
+}
+
+func main() {}
@@ -0,0 +1,146 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/extism/go-pdk"
+)
+
+//export countWords
+func countWords() int {
+	var input string
+	if err := json.Unmarshal(pdk.Input(), &input); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
+		return 1 // failure
+	}
+
+	output := CountWords(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export isEven
+func isEven() int {
+	var input int
+	if err := json.Unmarshal(pdk.Input(), &input); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
+		return 1 // failure
+	}
+
+	output := IsEven(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export scale
+func scale() int {
+	var input float64
+	if err := json.Unmarshal(pdk.Input(), &input); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
+		return 1 // failure
+	}
+
+	output := Scale(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export describeFlag
+func describeFlag() int {
+	var input bool
+	if err := json.Unmarshal(pdk.Input(), &input); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
+		return 1 // failure
+	}
+
+	output := DescribeFlag(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export sum
+func sum() int {
+	var input []int
+	if err := json.Unmarshal(pdk.Input(), &input); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
+		return 1 // failure
+	}
+
+	output := Sum(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export nextTicket
+func nextTicket() int {
+	output := NextTicket()
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export logMessage
+func logMessage() int {
+	var input string
+	if err := json.Unmarshal(pdk.Input(), &input); err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Unmarshal input: %v", err))
+		return 1 // failure
+	}
+
+	LogMessage(input)
+	return 0 // success
+}
+
+//export shout
+func shout() int {
+	input := pdk.InputString()
+
+	output := Shout(input)
+
+	pdk.OutputString(output)
+	return 0 // success
+}
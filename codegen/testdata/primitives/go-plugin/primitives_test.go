@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	level := LevelEnumDebug
+	buf, err := jsoncomp.Marshal(level)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"debug"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseLevel(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != level {
+		t.Errorf("ParseLevel = '%v', want '%v'", got, level)
+	}
+}
+
+func TestLevelValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllLevel() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := LevelFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("LevelFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Level
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllLevel()), 3; got != want {
+		t.Errorf("len(AllLevel()) = %v, want %v", got, want)
+	}
+}
+
+func TestLevelRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Level("not-a-level")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := LevelFromString(unknown.String()); err == nil {
+		t.Errorf("LevelFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Level
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-level"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
@@ -0,0 +1,88 @@
+// Package primitives represents the custom datatypes for an XTP Extension Plugin.
+package primitives
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// Level represents a log level.
+type Level string
+
+const (
+	LevelEnumDebug Level = "debug"
+	LevelEnumInfo  Level = "info"
+	LevelEnumError Level = "error"
+)
+
+// AllLevel returns all the values of `Level` in the order of its schema.
+func AllLevel() []Level {
+	return []Level{
+		LevelEnumDebug,
+		LevelEnumInfo,
+		LevelEnumError,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Level`.
+func (v Level) IsValid() bool {
+	switch v {
+	case LevelEnumDebug, LevelEnumInfo, LevelEnumError:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Level) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Level) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Level) UnmarshalText(text []byte) error {
+	value, err := LevelFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Level) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// LevelFromString returns the `Level` with the given (unquoted) value.
+func LevelFromString(s string) (Level, error) {
+	if v := Level(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Level: %q", s)
+}
+
+// ParseLevel parses a JSON string and returns the value.
+func ParseLevel(s string) (value Level, err error) {
+	switch s {
+	case `"debug"`:
+		return LevelEnumDebug, nil
+	case `"info"`:
+		return LevelEnumInfo, nil
+	case `"error"`:
+		return LevelEnumError, nil
+	default:
+		return value, fmt.Errorf("not a Level: %v", s)
+	}
+}
@@ -0,0 +1,64 @@
+package trees
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// WalkTree - Visits every node in a tree and returns the deepest one
+func (p *Plugin) WalkTree(ctx context.Context, input Node) (output Node, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("walkTree: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "walkTree", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("walkTree: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("walkTree: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("walkTree: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// Evaluate - Evaluates an expression
+func (p *Plugin) Evaluate(ctx context.Context, input Expr) (output int, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("evaluate: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "evaluate", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("evaluate: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("evaluate: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("evaluate: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,24 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// WalkTree - Visits every node in a tree and returns the deepest one
+func WalkTree(input Node) Node {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin WalkTree")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin WalkTree")
+	return Node{}
+}
+
+// Evaluate - Evaluates an expression
+func Evaluate(input Expr) int {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin Evaluate")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin Evaluate")
+	return 0
+}
+
+func main() {}
@@ -0,0 +1,52 @@
+//go:build tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/extism/go-pdk"
+)
+
+//export walkTree
+func walkTree() int {
+	in := pdk.InputString()
+	input, err := ParseNode(in)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseNode input: %v, input:\n%v\n", err, in))
+		return 1 // failure
+	}
+
+	output := WalkTree(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
+
+//export evaluate
+func evaluate() int {
+	in := pdk.InputString()
+	input, err := ParseExpr(in)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseExpr input: %v, input:\n%v\n", err, in))
+		return 1 // failure
+	}
+
+	output := Evaluate(input)
+
+	buf, err := json.Marshal(output)
+	if err != nil {
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to json.Marshal output: %v", err))
+		return 1 // failure
+	}
+
+	pdk.OutputString(string(buf))
+	return 0 // success
+}
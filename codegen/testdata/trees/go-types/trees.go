@@ -0,0 +1,146 @@
+// Package trees represents the custom datatypes for an XTP Extension Plugin.
+package trees
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// Node represents a node in a tree.
+type Node struct {
+	// The name of this node
+	Name string `json:"name"`
+	// The children of this node
+	Children []Node `json:"children"`
+	// The parent of this node, if any
+	Parent *Node `json:"parent,omitempty"`
+}
+
+// NewNode returns a new `Node` with the default values of its schema.
+func NewNode() *Node {
+	return &Node{}
+}
+
+// ParseNode parses a JSON string and returns the value.
+func ParseNode(s string) (value Node, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Node` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Node) Validate() error {
+	if c.Children == nil {
+		return fmt.Errorf("children: required")
+	}
+	for i, v := range c.Children {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("children[%v].%w", i, err)
+		}
+	}
+	if c.Parent != nil {
+		if err := c.Parent.Validate(); err != nil {
+			return fmt.Errorf("parent.%w", err)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Node`.
+func (c *Node) GetSchema() XTPSchema {
+	return XTPSchema{
+		"name":     "string",
+		"children": "Array<Node>",
+		"parent":   "?Node",
+	}
+}
+
+// Expr represents an expression made of terms.
+type Expr struct {
+	// The operator
+	Op string `json:"op"`
+	// The first term, if any
+	Term *Term `json:"term,omitempty"`
+}
+
+// NewExpr returns a new `Expr` with the default values of its schema.
+func NewExpr() *Expr {
+	return &Expr{}
+}
+
+// ParseExpr parses a JSON string and returns the value.
+func ParseExpr(s string) (value Expr, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Expr` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Expr) Validate() error {
+	if c.Term != nil {
+		if err := c.Term.Validate(); err != nil {
+			return fmt.Errorf("term.%w", err)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Expr`.
+func (c *Expr) GetSchema() XTPSchema {
+	return XTPSchema{
+		"op":   "string",
+		"term": "?Term",
+	}
+}
+
+// Term represents a term of an expression.
+type Term struct {
+	// The value of this term
+	Value int `json:"value"`
+	// The expression this term belongs to
+	Expr *Expr `json:"expr"`
+}
+
+// NewTerm returns a new `Term` with the default values of its schema.
+func NewTerm() *Term {
+	return &Term{}
+}
+
+// ParseTerm parses a JSON string and returns the value.
+func ParseTerm(s string) (value Term, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Term` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Term) Validate() error {
+	if c.Expr == nil {
+		return fmt.Errorf("expr: required")
+	}
+	if err := c.Expr.Validate(); err != nil {
+		return fmt.Errorf("expr.%w", err)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Term`.
+func (c *Term) GetSchema() XTPSchema {
+	return XTPSchema{
+		"value": "integer",
+		"expr":  "Expr",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
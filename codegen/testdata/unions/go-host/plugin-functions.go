@@ -0,0 +1,42 @@
+package unions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// HandleEvent - Handles an event of the user interface and returns the action to take.
+func (p *Plugin) HandleEvent(ctx context.Context, input Event) (output Action, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("handleEvent: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "handleEvent", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("handleEvent: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("handleEvent: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("handleEvent: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,16 @@
+//go:build tinygo
+
+// go-plugin represents an XTP Extension Plugin.
+package main
+
+import "github.com/extism/go-pdk"
+
+// HandleEvent - Handles an event of the user interface and returns the action to take.
+func HandleEvent(input Event) Action {
+	pdk.Log(pdk.LogDebug, "ENTER TinyGo plugin HandleEvent")
+	// TODO: fill out your implementation here
+	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin HandleEvent")
+	return Action{}
+}
+
+func main() {}
@@ -0,0 +1,488 @@
+// Package unions represents the custom datatypes for an XTP Extension Plugin.
+package unions
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"errors"
+	"fmt"
+)
+
+// Button represents a mouse button.
+type Button string
+
+const (
+	ButtonEnumLeft   Button = "left"
+	ButtonEnumMiddle Button = "middle"
+	ButtonEnumRight  Button = "right"
+)
+
+// AllButton returns all the values of `Button` in the order of its schema.
+func AllButton() []Button {
+	return []Button{
+		ButtonEnumLeft,
+		ButtonEnumMiddle,
+		ButtonEnumRight,
+	}
+}
+
+// IsValid reports whether the value is one of the values of `Button`.
+func (v Button) IsValid() bool {
+	switch v {
+	case ButtonEnumLeft, ButtonEnumMiddle, ButtonEnumRight:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (v Button) String() string {
+	return string(v)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Button) MarshalText() ([]byte, error) {
+	return []byte(v), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and rejects unknown values.
+func (v *Button) UnmarshalText(text []byte) error {
+	value, err := ButtonFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rejects unknown values.
+func (v *Button) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// ButtonFromString returns the `Button` with the given (unquoted) value.
+func ButtonFromString(s string) (Button, error) {
+	if v := Button(s); v.IsValid() {
+		return v, nil
+	}
+	return "", fmt.Errorf("not a Button: %q", s)
+}
+
+// ParseButton parses a JSON string and returns the value.
+func ParseButton(s string) (value Button, err error) {
+	switch s {
+	case `"left"`:
+		return ButtonEnumLeft, nil
+	case `"middle"`:
+		return ButtonEnumMiddle, nil
+	case `"right"`:
+		return ButtonEnumRight, nil
+	default:
+		return value, fmt.Errorf("not a Button: %v", s)
+	}
+}
+
+// Click represents a click of a mouse button.
+type Click struct {
+	// The horizontal position of the pointer
+	X int `json:"x"`
+	// The vertical position of the pointer
+	Y int `json:"y"`
+	// The button that was clicked
+	Button Button `json:"button"`
+	// Whether this was a double click
+	Double *bool `json:"double,omitempty"`
+}
+
+// NewClick returns a new `Click` with the default values of its schema.
+func NewClick() *Click {
+	return &Click{}
+}
+
+// ParseClick parses a JSON string and returns the value.
+func ParseClick(s string) (value Click, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Click` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Click) Validate() error {
+	if !c.Button.IsValid() {
+		return fmt.Errorf("button: %q is not a valid Button", c.Button)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Click`.
+func (c *Click) GetSchema() XTPSchema {
+	return XTPSchema{
+		"x":      "integer",
+		"y":      "integer",
+		"button": "Button",
+		"double": "?boolean",
+	}
+}
+
+// KeyPress represents a press of a key.
+type KeyPress struct {
+	// The name of the key
+	Key string `json:"key"`
+	// The number of times the key repeated
+	Repeat *int `json:"repeat,omitempty"`
+}
+
+// NewKeyPress returns a new `KeyPress` with the default values of its schema.
+func NewKeyPress() *KeyPress {
+	return &KeyPress{}
+}
+
+// ParseKeyPress parses a JSON string and returns the value.
+func ParseKeyPress(s string) (value KeyPress, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `KeyPress` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *KeyPress) Validate() error {
+	if c.Repeat != nil && *c.Repeat < 1 {
+		return fmt.Errorf("repeat: %v is less than minimum 1", *c.Repeat)
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `KeyPress`.
+func (c *KeyPress) GetSchema() XTPSchema {
+	return XTPSchema{
+		"key":    "string",
+		"repeat": "?integer",
+	}
+}
+
+// Scroll represents a scroll of the mouse wheel.
+type Scroll struct {
+	// The distance scrolled
+	Delta *float64 `json:"delta,omitempty"`
+}
+
+// NewScroll returns a new `Scroll` with the default values of its schema.
+func NewScroll() *Scroll {
+	return &Scroll{}
+}
+
+// ParseScroll parses a JSON string and returns the value.
+func ParseScroll(s string) (value Scroll, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Scroll` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Scroll) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Scroll`.
+func (c *Scroll) GetSchema() XTPSchema {
+	return XTPSchema{
+		"delta": "?number",
+	}
+}
+
+// Event represents an event of the user interface.
+// Its `Value` is one of `*Click`, `*KeyPress` or `*Scroll`, selected in JSON by
+// its "type" property.
+type Event struct {
+	Value EventValue
+}
+
+// EventValue is implemented by the variants of `Event`.
+type EventValue interface {
+	isEvent()
+}
+
+func (*Click) isEvent() {}
+
+func (*KeyPress) isEvent() {}
+
+func (*Scroll) isEvent() {}
+
+// ParseEvent parses a JSON string and returns the value.
+func ParseEvent(s string) (value Event, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// MarshalJSON implements json.Marshaler by adding the "type" of the
+// variant to its properties.
+func (v Event) MarshalJSON() ([]byte, error) {
+	var prefix string
+	switch v.Value.(type) {
+	case nil:
+		return []byte("null"), nil
+	case *Click:
+		prefix = `{"type":"click"`
+	case *KeyPress:
+		prefix = `{"type":"keyPress"`
+	case *Scroll:
+		prefix = `{"type":"Scroll"`
+	}
+
+	buf, err := json.Marshal(v.Value)
+	if err != nil || string(buf) == "null" {
+		return buf, err
+	}
+	if len(buf) > 2 {
+		prefix += ","
+	}
+	return append([]byte(prefix), buf[1:]...), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding the variant that is
+// selected by the "type" property.
+func (v *Event) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var discriminator struct {
+		Value *string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+	if discriminator.Value == nil {
+		return errors.New(`Event: missing "type"`)
+	}
+
+	switch *discriminator.Value {
+	case "click":
+		value := &Click{}
+		if err := json.Unmarshal(data, value); err != nil {
+			return err
+		}
+		v.Value = value
+	case "keyPress":
+		value := &KeyPress{}
+		if err := json.Unmarshal(data, value); err != nil {
+			return err
+		}
+		v.Value = value
+	case "Scroll":
+		value := &Scroll{}
+		if err := json.Unmarshal(data, value); err != nil {
+			return err
+		}
+		v.Value = value
+	default:
+		return fmt.Errorf("Event: unknown type %q", *discriminator.Value)
+	}
+	return nil
+}
+
+// Validate returns an error if the `Event` has no value
+// or its value violates a constraint of its schema.
+func (v *Event) Validate() error {
+	switch value := v.Value.(type) {
+	case *Click:
+		if value != nil {
+			return value.Validate()
+		}
+	case *KeyPress:
+		if value != nil {
+			return value.Validate()
+		}
+	case *Scroll:
+		if value != nil {
+			return value.Validate()
+		}
+	}
+	return errors.New("Event: missing value")
+}
+
+// Redraw represents a request to redraw part of the screen.
+type Redraw struct {
+	// Whether to redraw the whole screen
+	Full bool `json:"full"`
+}
+
+// NewRedraw returns a new `Redraw` with the default values of its schema.
+func NewRedraw() *Redraw {
+	return &Redraw{}
+}
+
+// ParseRedraw parses a JSON string and returns the value.
+func ParseRedraw(s string) (value Redraw, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Redraw` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Redraw) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Redraw`.
+func (c *Redraw) GetSchema() XTPSchema {
+	return XTPSchema{
+		"full": "boolean",
+	}
+}
+
+// Quit represents a request to quit.
+type Quit struct {
+	// The exit code
+	Code *int `json:"code,omitempty"`
+}
+
+// NewQuit returns a new `Quit` with the default values of its schema.
+func NewQuit() *Quit {
+	return &Quit{}
+}
+
+// ParseQuit parses a JSON string and returns the value.
+func ParseQuit(s string) (value Quit, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Quit` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Quit) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Quit`.
+func (c *Quit) GetSchema() XTPSchema {
+	return XTPSchema{
+		"code": "?integer",
+	}
+}
+
+// Action represents the action to take in response to an event.
+// Its `Value` is one of `*Redraw` or `*Quit`, selected in JSON by
+// its "action" property.
+type Action struct {
+	Value ActionValue
+}
+
+// ActionValue is implemented by the variants of `Action`.
+type ActionValue interface {
+	isAction()
+}
+
+func (*Redraw) isAction() {}
+
+func (*Quit) isAction() {}
+
+// ParseAction parses a JSON string and returns the value.
+func ParseAction(s string) (value Action, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// MarshalJSON implements json.Marshaler by adding the "action" of the
+// variant to its properties.
+func (v Action) MarshalJSON() ([]byte, error) {
+	var prefix string
+	switch v.Value.(type) {
+	case nil:
+		return []byte("null"), nil
+	case *Redraw:
+		prefix = `{"action":"redraw"`
+	case *Quit:
+		prefix = `{"action":"quit"`
+	}
+
+	buf, err := json.Marshal(v.Value)
+	if err != nil || string(buf) == "null" {
+		return buf, err
+	}
+	if len(buf) > 2 {
+		prefix += ","
+	}
+	return append([]byte(prefix), buf[1:]...), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding the variant that is
+// selected by the "action" property.
+func (v *Action) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var discriminator struct {
+		Value *string `json:"action"`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+	if discriminator.Value == nil {
+		return errors.New(`Action: missing "action"`)
+	}
+
+	switch *discriminator.Value {
+	case "redraw":
+		value := &Redraw{}
+		if err := json.Unmarshal(data, value); err != nil {
+			return err
+		}
+		v.Value = value
+	case "quit":
+		value := &Quit{}
+		if err := json.Unmarshal(data, value); err != nil {
+			return err
+		}
+		v.Value = value
+	default:
+		return fmt.Errorf("Action: unknown action %q", *discriminator.Value)
+	}
+	return nil
+}
+
+// Validate returns an error if the `Action` has no value
+// or its value violates a constraint of its schema.
+func (v *Action) Validate() error {
+	switch value := v.Value.(type) {
+	case *Redraw:
+		if value != nil {
+			return value.Validate()
+		}
+	case *Quit:
+		if value != nil {
+			return value.Validate()
+		}
+	}
+	return errors.New("Action: missing value")
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -0,0 +1,674 @@
+package unions
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+
+func TestParseButton(t *testing.T) {
+	t.Parallel()
+
+	button := ButtonEnumLeft
+	buf, err := jsoncomp.Marshal(button)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `"left"`
+	if got := string(buf); got != want {
+		t.Errorf("Marshal = '%v', want '%v'", got, want)
+	}
+
+	got, err := ParseButton(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != button {
+		t.Errorf("ParseButton = '%v', want '%v'", got, button)
+	}
+}
+
+func TestButtonValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllButton() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := ButtonFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("ButtonFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Button
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllButton()), 3; got != want {
+		t.Errorf("len(AllButton()) = %v, want %v", got, want)
+	}
+}
+
+func TestButtonRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Button("not-a-button")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := ButtonFromString(unknown.String()); err == nil {
+		t.Errorf("ButtonFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Button
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-button"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
+func TestClickMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Click
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Click{
+				X:      0,
+				Y:      0,
+				Button: ButtonEnumLeft,
+			},
+			want: `{"x":0,"y":0,"button":"left"}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Click{
+				Button: ButtonEnumLeft,
+				Double: boolPtr(false),
+			},
+			want: `{"x":0,"y":0,"button":"left","double":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Click
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestClickValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Click)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Click) {}},
+		{name: "invalid button", modify: func(v *Click) { v.Button = "invalid" }, wantErr: "button: \"invalid\" is not a valid Button"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Click{
+				X:      0,
+				Y:      0,
+				Button: ButtonEnumLeft,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyPressMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *KeyPress
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &KeyPress{
+				Key: "key",
+			},
+			want: `{"key":"key"}`,
+		},
+		{
+			name: "optional fields",
+			obj: &KeyPress{
+				Repeat: intPtr(0),
+			},
+			want: `{"key":"","repeat":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj KeyPress
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyPressValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *KeyPress)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *KeyPress) {}},
+		{name: "repeat at minimum", modify: func(v *KeyPress) { x := 1; v.Repeat = &x }},
+		{name: "repeat below minimum", modify: func(v *KeyPress) { x := 0; v.Repeat = &x }, wantErr: "repeat: 0 is less than minimum 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &KeyPress{
+				Key: "key",
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScrollMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Scroll
+		want string
+	}{
+		{
+			name: "required fields",
+			obj:  &Scroll{},
+			want: `{}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Scroll{
+				Delta: float64Ptr(0),
+			},
+			want: `{"delta":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Scroll
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestScrollValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Scroll)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Scroll) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Scroll{}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Event
+		want string
+	}{
+		{
+			name: "Click",
+			obj:  &Event{Value: &Click{Button: ButtonEnumLeft}},
+			want: `{"type":"click","x":0,"y":0,"button":"left"}`,
+		},
+		{
+			name: "KeyPress",
+			obj:  &Event{Value: &KeyPress{}},
+			want: `{"type":"keyPress","key":""}`,
+		},
+		{
+			name: "Scroll",
+			obj:  &Event{Value: &Scroll{}},
+			want: `{"type":"Scroll"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Event
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestParseEventRejectsUnknownVariants(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name:    "missing type",
+			json:    `{}`,
+			wantErr: `Event: missing "type"`,
+		},
+		{
+			name:    "unknown type",
+			json:    `{"type":"not-a-event"}`,
+			wantErr: `Event: unknown type "not-a-event"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseEvent(tt.json); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParseEvent = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventValidate(t *testing.T) {
+	t.Parallel()
+
+	var obj Event
+	if err := obj.Validate(); err == nil || err.Error() != "Event: missing value" {
+		t.Errorf("Validate = %v, want Event: missing value", err)
+	}
+
+	obj.Value = &Click{
+		X:      0,
+		Y:      0,
+		Button: ButtonEnumLeft,
+	}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(Click) = %v, want nil", err)
+	}
+
+	obj.Value = &KeyPress{
+		Key: "key",
+	}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(KeyPress) = %v, want nil", err)
+	}
+
+	obj.Value = &Scroll{}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(Scroll) = %v, want nil", err)
+	}
+}
+
+func TestRedrawMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Redraw
+		want string
+	}{
+		{
+			name: "required fields",
+			obj: &Redraw{
+				Full: true,
+			},
+			want: `{"full":true}`,
+		},
+		{
+			name: "optional fields",
+			obj:  &Redraw{},
+			want: `{"full":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Redraw
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestRedrawValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Redraw)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Redraw) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Redraw{
+				Full: true,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuitMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Quit
+		want string
+	}{
+		{
+			name: "required fields",
+			obj:  &Quit{},
+			want: `{}`,
+		},
+		{
+			name: "optional fields",
+			obj: &Quit{
+				Code: intPtr(0),
+			},
+			want: `{"code":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Quit
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestQuitValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Quit)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Quit) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Quit{}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActionMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		obj  *Action
+		want string
+	}{
+		{
+			name: "Redraw",
+			obj:  &Action{Value: &Redraw{}},
+			want: `{"action":"redraw","full":false}`,
+		},
+		{
+			name: "Quit",
+			obj:  &Action{Value: &Quit{}},
+			want: `{"action":"quit"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsoncomp.Marshal(tt.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Logf("got:\n%v", string(got))
+				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
+			}
+
+			var obj Action
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestParseActionRejectsUnknownVariants(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name:    "missing action",
+			json:    `{}`,
+			wantErr: `Action: missing "action"`,
+		},
+		{
+			name:    "unknown action",
+			json:    `{"action":"not-a-action"}`,
+			wantErr: `Action: unknown action "not-a-action"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseAction(tt.json); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ParseAction = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActionValidate(t *testing.T) {
+	t.Parallel()
+
+	var obj Action
+	if err := obj.Validate(); err == nil || err.Error() != "Action: missing value" {
+		t.Errorf("Validate = %v, want Action: missing value", err)
+	}
+
+	obj.Value = &Redraw{
+		Full: true,
+	}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(Redraw) = %v, want nil", err)
+	}
+
+	obj.Value = &Quit{}
+	if err := obj.Validate(); err != nil {
+		t.Errorf("Validate(Quit) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package user represents the custom datatypes for an XTP Extension Plugin.
+package user
+
+import (
+	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
+)
+
+// Address represents a users address.
+type Address struct {
+	// Street address
+	Street string `json:"street"`
+}
+
+// NewAddress returns a new `Address` with the default values of its schema.
+func NewAddress() *Address {
+	return &Address{}
+}
+
+// ParseAddress parses a JSON string and returns the value.
+func ParseAddress(s string) (value Address, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `Address` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Address) Validate() error {
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `Address`.
+func (c *Address) GetSchema() XTPSchema {
+	return XTPSchema{
+		"street": "string",
+	}
+}
+
+// User represents a user object in our system..
+type User struct {
+	// The user's age, naturally
+	Age *int32 `json:"age,omitempty"`
+	// The user's email, of course
+	Email   *string  `json:"email,omitempty"`
+	Address *Address `json:"address,omitempty"`
+}
+
+// NewUser returns a new `User` with the default values of its schema.
+func NewUser() *User {
+	return &User{}
+}
+
+// ParseUser parses a JSON string and returns the value.
+func ParseUser(s string) (value User, err error) {
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+// Validate returns an error if the `User` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *User) Validate() error {
+	if c.Age != nil && *c.Age < 0 {
+		return fmt.Errorf("age: %v is less than minimum 0", *c.Age)
+	}
+	if c.Age != nil && *c.Age > 200 {
+		return fmt.Errorf("age: %v is greater than maximum 200", *c.Age)
+	}
+	if c.Address != nil {
+		if err := c.Address.Validate(); err != nil {
+			return fmt.Errorf("address.%w", err)
+		}
+	}
+	return nil
+}
+
+// GetSchema returns an `XTPSchema` for the `User`.
+func (c *User) GetSchema() XTPSchema {
+	return XTPSchema{
+		"age":     "?integer",
+		"email":   "?string",
+		"address": "?Address",
+	}
+}
+
+// XTPSchema describes the values and types of an XTP object
+// in a language-agnostic format.
+type XTPSchema map[string]string
@@ -9,9 +9,11 @@ import (
 
 var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
 
-func boolPtr(b bool) *bool       { return &b }
-func intPtr(i int) *int          { return &i }
-func stringPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool          { return &b }
+func float64Ptr(f float64) *float64 { return &f }
+func intPtr(i int) *int             { return &i }
+func stringPtr(s string) *string    { return &s }
+func int32Ptr(i int32) *int32       { return &i }
 
 func TestAddressMarshal(t *testing.T) {
 	t.Parallel()
@@ -45,6 +47,46 @@ func TestAddressMarshal(t *testing.T) {
 				t.Logf("got:\n%v", string(got))
 				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
 			}
+
+			var obj Address
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestAddressValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *Address)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *Address) {}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &Address{
+				Street: "street",
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
 		})
 	}
 }
@@ -64,7 +106,7 @@ func TestUserMarshal(t *testing.T) {
 		{
 			name: "optional fields",
 			obj: &User{
-				Age:     intPtr(0),
+				Age:     int32Ptr(0),
 				Email:   stringPtr("email"),
 				Address: &Address{},
 			},
@@ -83,6 +125,48 @@ func TestUserMarshal(t *testing.T) {
 				t.Logf("got:\n%v", string(got))
 				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
 			}
+
+			var obj User
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestUserValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *User)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *User) {}},
+		{name: "age at minimum", modify: func(v *User) { x := int32(0); v.Age = &x }},
+		{name: "age below minimum", modify: func(v *User) { x := int32(-1); v.Age = &x }, wantErr: "age: -1 is less than minimum 0"},
+		{name: "age at maximum", modify: func(v *User) { x := int32(200); v.Age = &x }},
+		{name: "age above maximum", modify: func(v *User) { x := int32(201); v.Age = &x }, wantErr: "age: 201 is greater than maximum 200"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &User{}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
 		})
 	}
 }
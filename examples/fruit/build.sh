@@ -1,21 +1,6 @@
 #!/bin/bash -e
-echo GENERATING fruit for Go
-go run ../../cmd/xtp2code/main.go \
-    -lang=go \
-    -pkg=fruit \
-    -host=go-host \
-    -plugin=go-plugin \
-    -types=go-types \
-    -yaml=schema.yaml \
-    "$@"
-echo GENERATING fruit for MoonBit
-go run ../../cmd/xtp2code/main.go \
-    -lang=mbt \
-    -pkg=fruit \
-    -plugin=mbt-plugin \
-    -types=mbt-types \
-    -yaml=schema.yaml \
-    "$@"
+echo GENERATING fruit for Go and MoonBit
+go run ../../cmd/xtp2code -config=xtp2code.yaml "$@"
 
 for i in $(echo */build.sh); do
     dirname=$(pwd)/${i%"build.sh"}
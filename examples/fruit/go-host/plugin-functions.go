@@ -0,0 +1,77 @@
+package fruit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// VoidFunc - This demonstrates how you can create an export with
+// no inputs or outputs.
+func (p *Plugin) VoidFunc(ctx context.Context) (err error) {
+	rc, _, err := p.CallWithContext(ctx, "voidFunc", nil)
+	if err != nil {
+		return fmt.Errorf("voidFunc: %w", err)
+	}
+	if rc != 0 {
+		return fmt.Errorf("voidFunc: plugin returned exit code %v", rc)
+	}
+
+	return nil
+}
+
+// PrimitiveTypeFunc - This demonstrates how you can accept or return primtive types.
+// This function takes a utf8 string and returns a json encoded boolean
+func (p *Plugin) PrimitiveTypeFunc(ctx context.Context, input string) (output bool, err error) {
+	inBuf := []byte(input)
+
+	rc, outBuf, err := p.CallWithContext(ctx, "primitiveTypeFunc", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("primitiveTypeFunc: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("primitiveTypeFunc: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("primitiveTypeFunc: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
+
+// ReferenceTypeFunc - This demonstrates how you can accept or return references to schema types.
+// And it shows how you can define an enum to be used as a property or input/output.
+func (p *Plugin) ReferenceTypeFunc(ctx context.Context, input Fruit) (output ComplexObject, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("referenceTypeFunc: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "referenceTypeFunc", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("referenceTypeFunc: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("referenceTypeFunc: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("referenceTypeFunc: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
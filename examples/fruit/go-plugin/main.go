@@ -36,7 +36,7 @@ func ReferenceTypeFunc(input Fruit) ComplexObject {
 	pdk.Log(pdk.LogDebug, fmt.Sprintf("ENTER TinyGo plugin ReferenceTypeFunc('%v')", input))
 	// TODO: fill out your implementation here
 	pdk.Log(pdk.LogDebug, fmt.Sprintf("LEAVE TinyGo plugin ReferenceTypeFunc('%v')", input))
-	now := time.Now().Format(time.RFC3339)
+	now := time.Now().UTC()
 	return ComplexObject{
 		Ghost:          GhostGangEnumBlinky,
 		ABoolean:       true,
@@ -2,6 +2,7 @@ package fruit
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	jsoniter "github.com/json-iterator/go"
@@ -9,9 +10,11 @@ import (
 
 var jsoncomp = jsoniter.ConfigCompatibleWithStandardLibrary
 
-func boolPtr(b bool) *bool       { return &b }
-func intPtr(i int) *int          { return &i }
-func stringPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool           { return &b }
+func float64Ptr(f float64) *float64  { return &f }
+func intPtr(i int) *int              { return &i }
+func stringPtr(s string) *string     { return &s }
+func timePtr(t time.Time) *time.Time { return &t }
 
 func TestParseFruit(t *testing.T) {
 	t.Parallel()
@@ -36,6 +39,56 @@ func TestParseFruit(t *testing.T) {
 	}
 }
 
+func TestFruitValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllFruit() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := FruitFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("FruitFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj Fruit
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllFruit()), 4; got != want {
+		t.Errorf("len(AllFruit()) = %v, want %v", got, want)
+	}
+}
+
+func TestFruitRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := Fruit("not-a-fruit")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := FruitFromString(unknown.String()); err == nil {
+		t.Errorf("FruitFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj Fruit
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-fruit"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
 func TestParseGhostGang(t *testing.T) {
 	t.Parallel()
 
@@ -59,6 +112,56 @@ func TestParseGhostGang(t *testing.T) {
 	}
 }
 
+func TestGhostGangValues(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range AllGhostGang() {
+		if !v.IsValid() {
+			t.Errorf("%v.IsValid = false, want true", v)
+		}
+
+		got, err := GhostGangFromString(v.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Errorf("GhostGangFromString(%q) = '%v', want '%v'", v.String(), got, v)
+		}
+
+		var obj GhostGang
+		if err := jsoncomp.Unmarshal([]byte(`"`+v.String()+`"`), &obj); err != nil {
+			t.Fatal(err)
+		}
+		if obj != v {
+			t.Errorf("Unmarshal = '%v', want '%v'", obj, v)
+		}
+	}
+
+	if got, want := len(AllGhostGang()), 4; got != want {
+		t.Errorf("len(AllGhostGang()) = %v, want %v", got, want)
+	}
+}
+
+func TestGhostGangRejectsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	unknown := GhostGang("not-a-ghostGang")
+	if unknown.IsValid() {
+		t.Errorf("%v.IsValid = true, want false", unknown)
+	}
+	if _, err := GhostGangFromString(unknown.String()); err == nil {
+		t.Errorf("GhostGangFromString(%q) = nil error, want error", unknown)
+	}
+
+	var obj GhostGang
+	if err := obj.UnmarshalText([]byte(unknown)); err == nil {
+		t.Errorf("UnmarshalText(%q) = nil error, want error", unknown)
+	}
+	if err := jsoncomp.Unmarshal([]byte(`"not-a-ghostGang"`), &obj); err == nil {
+		t.Error("Unmarshal = nil error, want error")
+	}
+}
+
 func TestComplexObjectMarshal(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -79,9 +182,10 @@ func TestComplexObjectMarshal(t *testing.T) {
 		{
 			name: "optional fields",
 			obj: &ComplexObject{
-				AnOptionalDate: stringPtr("anOptionalDate"),
+				Ghost:          GhostGangEnumBlinky,
+				AnOptionalDate: timePtr(time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)),
 			},
-			want: `{"ghost":"","aBoolean":false,"aString":"","anInt":0,"anOptionalDate":"anOptionalDate"}`,
+			want: `{"ghost":"blinky","aBoolean":false,"aString":"","anInt":0,"anOptionalDate":"2024-01-02T03:04:05Z"}`,
 		},
 	}
 
@@ -96,6 +200,50 @@ func TestComplexObjectMarshal(t *testing.T) {
 				t.Logf("got:\n%v", string(got))
 				t.Errorf("Marshal mismatch (-want +got):\n%v", diff)
 			}
+
+			var obj ComplexObject
+			if err := jsoncomp.Unmarshal([]byte(tt.want), &obj); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.obj, &obj); diff != "" {
+				t.Errorf("Unmarshal mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestComplexObjectValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		modify  func(v *ComplexObject)
+		wantErr string
+	}{
+		{name: "valid", modify: func(v *ComplexObject) {}},
+		{name: "invalid ghost", modify: func(v *ComplexObject) { v.Ghost = "invalid" }, wantErr: "ghost: \"invalid\" is not a valid GhostGang"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &ComplexObject{
+				Ghost:    GhostGangEnumBlinky,
+				ABoolean: true,
+				AString:  "aString",
+				AnInt:    0,
+			}
+			tt.modify(obj)
+
+			err := obj.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate = %v, want %v", err, tt.wantErr)
+			}
 		})
 	}
 }
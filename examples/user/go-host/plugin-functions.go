@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	extism "github.com/extism/go-sdk"
+)
+
+// Plugin wraps an `extism.Plugin` with typed methods for calling
+// each of the functions exported by the XTP Extension Plugin.
+type Plugin struct {
+	*extism.Plugin
+}
+
+// NewPlugin returns a new `Plugin` wrapping the provided `extism.Plugin`.
+func NewPlugin(plugin *extism.Plugin) *Plugin {
+	return &Plugin{Plugin: plugin}
+}
+
+// ProcessUser - The second export function
+func (p *Plugin) ProcessUser(ctx context.Context, input User) (output User, err error) {
+	inBuf, err := json.Marshal(input)
+	if err != nil {
+		return output, fmt.Errorf("processUser: unable to json.Marshal input: %w", err)
+	}
+
+	rc, outBuf, err := p.CallWithContext(ctx, "processUser", inBuf)
+	if err != nil {
+		return output, fmt.Errorf("processUser: %w", err)
+	}
+	if rc != 0 {
+		return output, fmt.Errorf("processUser: plugin returned exit code %v", rc)
+	}
+
+	if err := json.Unmarshal(outBuf, &output); err != nil {
+		return output, fmt.Errorf("processUser: unable to json.Unmarshal output: %w", err)
+	}
+
+	return output, nil
+}
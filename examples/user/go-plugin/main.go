@@ -19,7 +19,7 @@ func ProcessUser(input User) User {
 	pdk.Log(pdk.LogDebug, fmt.Sprintf("ENTER TinyGo plugin ProcessUser(): input=\n%s", inBuf))
 	// TODO: fill out your implementation here
 	pdk.Log(pdk.LogDebug, "LEAVE TinyGo plugin ProcessUser")
-	age := 42
+	age := int32(42)
 	email := "email@example.com"
 	return User{
 		Age:     &age,
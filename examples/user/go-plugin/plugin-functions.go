@@ -11,14 +11,14 @@ import (
 
 //export processUser
 func processUser() int {
-	input := pdk.InputString()
-	v, err := ParseUser(input)
+	in := pdk.InputString()
+	input, err := ParseUser(in)
 	if err != nil {
-		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseUser input: %v, input:\n%v\n", err, input))
+		pdk.Log(pdk.LogError, fmt.Sprintf("unable to ParseUser input: %v, input:\n%v\n", err, in))
 		return 1 // failure
 	}
 
-	output := ProcessUser(v)
+	output := ProcessUser(input)
 
 	buf, err := json.Marshal(output)
 	if err != nil {
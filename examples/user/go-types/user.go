@@ -3,6 +3,7 @@ package user
 
 import (
 	"encoding/json" // jsoniter/jsoncomp are not compatible with tinygo.
+	"fmt"
 )
 
 // Address represents a users address.
@@ -11,6 +12,11 @@ type Address struct {
 	Street string `json:"street"`
 }
 
+// NewAddress returns a new `Address` with the default values of its schema.
+func NewAddress() *Address {
+	return &Address{}
+}
+
 // ParseAddress parses a JSON string and returns the value.
 func ParseAddress(s string) (value Address, err error) {
 	if err := json.Unmarshal([]byte(s), &value); err != nil {
@@ -20,6 +26,12 @@ func ParseAddress(s string) (value Address, err error) {
 	return value, nil
 }
 
+// Validate returns an error if the `Address` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *Address) Validate() error {
+	return nil
+}
+
 // GetSchema returns an `XTPSchema` for the `Address`.
 func (c *Address) GetSchema() XTPSchema {
 	return XTPSchema{
@@ -30,12 +42,17 @@ func (c *Address) GetSchema() XTPSchema {
 // User represents a user object in our system..
 type User struct {
 	// The user's age, naturally
-	Age *int `json:"age,omitempty"`
+	Age *int32 `json:"age,omitempty"`
 	// The user's email, of course
 	Email   *string  `json:"email,omitempty"`
 	Address *Address `json:"address,omitempty"`
 }
 
+// NewUser returns a new `User` with the default values of its schema.
+func NewUser() *User {
+	return &User{}
+}
+
 // ParseUser parses a JSON string and returns the value.
 func ParseUser(s string) (value User, err error) {
 	if err := json.Unmarshal([]byte(s), &value); err != nil {
@@ -45,6 +62,23 @@ func ParseUser(s string) (value User, err error) {
 	return value, nil
 }
 
+// Validate returns an error if the `User` violates a constraint of its schema,
+// such as a missing required property or a number outside of its bounds.
+func (c *User) Validate() error {
+	if c.Age != nil && *c.Age < 0 {
+		return fmt.Errorf("age: %v is less than minimum 0", *c.Age)
+	}
+	if c.Age != nil && *c.Age > 200 {
+		return fmt.Errorf("age: %v is greater than maximum 200", *c.Age)
+	}
+	if c.Address != nil {
+		if err := c.Address.Validate(); err != nil {
+			return fmt.Errorf("address.%w", err)
+		}
+	}
+	return nil
+}
+
 // GetSchema returns an `XTPSchema` for the `User`.
 func (c *User) GetSchema() XTPSchema {
 	return XTPSchema{
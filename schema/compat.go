@@ -0,0 +1,484 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Compatibility classifies a Change between two versions of a schema.
+type Compatibility string
+
+const (
+	// Compatible changes do not affect plugins bound to the old schema.
+	Compatible Compatibility = "compatible"
+	// Breaking changes may cause plugins bound to the old schema to fail.
+	Breaking Compatibility = "breaking"
+)
+
+// Change represents a single difference between two versions of a schema.
+type Change struct {
+	Compatibility Compatibility `json:"compatibility"`
+	// Path locates the changed value by name,
+	// e.g. "schemas.User.properties.age.maximum".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	// Pos is the position of the changed value within the new schema,
+	// or within the old schema if the value was removed.
+	Pos Position `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler and includes the Pos when known.
+func (c *Change) MarshalJSON() ([]byte, error) {
+	type plain Change
+	v := struct {
+		*plain
+		Position string `json:"position,omitempty"`
+	}{plain: (*plain)(c)}
+	if c.Pos.IsValid() {
+		v.Position = c.Pos.String()
+	}
+	return json.Marshal(v)
+}
+
+// String returns the change as "pos: compatibility: path: message".
+func (c *Change) String() string {
+	if c.Pos.IsValid() {
+		return fmt.Sprintf("%v: %v: %v: %v", c.Pos, c.Compatibility, c.Path, c.Message)
+	}
+	return fmt.Sprintf("%v: %v: %v", c.Compatibility, c.Path, c.Message)
+}
+
+// CompatReport represents all the changes found by Compare.
+type CompatReport struct {
+	// Compatible is false if any of the Changes is Breaking.
+	Compatible bool      `json:"compatible"`
+	Changes    []*Change `json:"changes"`
+}
+
+// Breaking returns only the breaking changes.
+func (r *CompatReport) Breaking() []*Change {
+	var changes []*Change
+	for _, c := range r.Changes {
+		if c.Compatibility == Breaking {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String returns a human-readable report with one change per line,
+// followed by a summary.
+func (r *CompatReport) String() string {
+	var sb strings.Builder
+	for _, c := range r.Changes {
+		fmt.Fprintln(&sb, c)
+	}
+
+	numBreaking := len(r.Breaking())
+	switch {
+	case len(r.Changes) == 0:
+		sb.WriteString("no changes\n")
+	case numBreaking == 0:
+		fmt.Fprintf(&sb, "%v compatible change(s)\n", len(r.Changes))
+	default:
+		fmt.Fprintf(&sb, "%v breaking and %v compatible change(s)\n", numBreaking, len(r.Changes)-numBreaking)
+	}
+	return sb.String()
+}
+
+// Compare reports the changes made to the old schema by the new one,
+// as seen by plugins that are bound to the old schema.
+//
+// Removing or changing anything that a bound plugin may rely upon is
+// Breaking, e.g. removing an export or import, changing an input or output
+// type, adding a required property, removing an enum value, or narrowing
+// a minimum or maximum. Purely additive changes are Compatible.
+func Compare(oldPlugin, newPlugin *Plugin) *CompatReport {
+	c := &comparer{changes: []*Change{}}
+	c.compareExports(oldPlugin.Exports, newPlugin.Exports)
+	c.compareImports(oldPlugin.Imports, newPlugin.Imports)
+	c.compareCustomTypes(oldPlugin.CustomTypes, newPlugin.CustomTypes)
+
+	return &CompatReport{
+		Compatible: c.numBreaking == 0,
+		Changes:    c.changes,
+	}
+}
+
+type comparer struct {
+	changes     []*Change
+	numBreaking int
+}
+
+func (c *comparer) add(compat Compatibility, pos Position, path, format string, args ...any) {
+	if compat == Breaking {
+		c.numBreaking++
+	}
+	c.changes = append(c.changes, &Change{
+		Compatibility: compat,
+		Path:          path,
+		Message:       fmt.Sprintf(format, args...),
+		Pos:           pos,
+	})
+}
+
+func (c *comparer) compareExports(oldExports, newExports []*Export) {
+	newByName := map[string]*Export{}
+	for _, e := range newExports {
+		newByName[e.Name] = e
+	}
+	oldByName := map[string]*Export{}
+	for _, oldExport := range oldExports {
+		oldByName[oldExport.Name] = oldExport
+		path := "exports." + oldExport.Name
+		newExport := newByName[oldExport.Name]
+		if newExport == nil {
+			c.add(Breaking, oldExport.Pos, path, "export %q removed", oldExport.Name)
+			continue
+		}
+		c.compareDescription(newExport.Pos, path, oldExport.Description, newExport.Description)
+		c.compareInput(newExport.Pos, path+".input", oldExport.Input, newExport.Input)
+		c.compareOutput(newExport.Pos, path+".output", oldExport.Output, newExport.Output)
+	}
+
+	for _, newExport := range newExports {
+		if oldByName[newExport.Name] == nil {
+			c.add(Compatible, newExport.Pos, "exports."+newExport.Name, "export %q added", newExport.Name)
+		}
+	}
+}
+
+func (c *comparer) compareImports(oldImports, newImports []*Import) {
+	newByName := map[string]*Import{}
+	for _, i := range newImports {
+		newByName[i.Name] = i
+	}
+	oldByName := map[string]*Import{}
+	for _, oldImport := range oldImports {
+		oldByName[oldImport.Name] = oldImport
+		path := "imports." + oldImport.Name
+		newImport := newByName[oldImport.Name]
+		if newImport == nil {
+			c.add(Breaking, oldImport.Pos, path, "import %q removed", oldImport.Name)
+			continue
+		}
+		c.compareDescription(newImport.Pos, path, oldImport.Description, newImport.Description)
+		c.compareInput(newImport.Pos, path+".input", oldImport.Input, newImport.Input)
+		c.compareOutput(newImport.Pos, path+".output", oldImport.Output, newImport.Output)
+	}
+
+	for _, newImport := range newImports {
+		if oldByName[newImport.Name] == nil {
+			c.add(Compatible, newImport.Pos, "imports."+newImport.Name, "import %q added", newImport.Name)
+		}
+	}
+}
+
+func (c *comparer) compareInput(pos Position, path string, oldInput, newInput *Input) {
+	switch {
+	case oldInput == nil && newInput == nil:
+		return
+	case oldInput == nil:
+		c.add(Breaking, newInput.Pos, path, "input added")
+		return
+	case newInput == nil:
+		c.add(Breaking, pos, path, "input removed")
+		return
+	}
+
+	oldType := typeString(oldInput.Ref, oldInput.Type, "", oldInput.Items, oldInput.AdditionalProperties)
+	newType := typeString(newInput.Ref, newInput.Type, "", newInput.Items, newInput.AdditionalProperties)
+	c.compareType(newInput.Pos, path, oldType, newType)
+	c.compareContentType(newInput.Pos, path, contentTypeOf(oldInput.Type, oldInput.ContentType), contentTypeOf(newInput.Type, newInput.ContentType))
+	c.compareDescription(newInput.Pos, path, oldInput.Description, newInput.Description)
+	if oldType == newType {
+		c.compareElements(path, oldInput.Items, newInput.Items, oldInput.AdditionalProperties, newInput.AdditionalProperties)
+	}
+}
+
+func (c *comparer) compareOutput(pos Position, path string, oldOutput, newOutput *Output) {
+	switch {
+	case oldOutput == nil && newOutput == nil:
+		return
+	case oldOutput == nil:
+		c.add(Breaking, newOutput.Pos, path, "output added")
+		return
+	case newOutput == nil:
+		c.add(Breaking, pos, path, "output removed")
+		return
+	}
+
+	oldType := typeString(oldOutput.Ref, oldOutput.Type, "", oldOutput.Items, oldOutput.AdditionalProperties)
+	newType := typeString(newOutput.Ref, newOutput.Type, "", newOutput.Items, newOutput.AdditionalProperties)
+	c.compareType(newOutput.Pos, path, oldType, newType)
+	c.compareContentType(newOutput.Pos, path, contentTypeOf(oldOutput.Type, oldOutput.ContentType), contentTypeOf(newOutput.Type, newOutput.ContentType))
+	c.compareDescription(newOutput.Pos, path, oldOutput.Description, newOutput.Description)
+	if oldType == newType {
+		c.compareElements(path, oldOutput.Items, newOutput.Items, oldOutput.AdditionalProperties, newOutput.AdditionalProperties)
+	}
+}
+
+func (c *comparer) compareType(pos Position, path, oldType, newType string) {
+	if oldType != newType {
+		c.add(Breaking, pos, path+".type", "type changed from %v to %v", oldType, newType)
+	}
+}
+
+// compareContentType compares the media types of two contentTypes, since
+// their parameters, such as "; charset=utf-8", do not change the encoding.
+func (c *comparer) compareContentType(pos Position, path, oldContentType, newContentType string) {
+	if mediaType(oldContentType) != mediaType(newContentType) {
+		c.add(Breaking, pos, path+".contentType", "contentType changed from %q to %q", oldContentType, newContentType)
+	}
+}
+
+func (c *comparer) compareDescription(pos Position, path, oldDescription, newDescription string) {
+	if strings.TrimSpace(oldDescription) != strings.TrimSpace(newDescription) {
+		c.add(Compatible, pos, path+".description", "description changed")
+	}
+}
+
+func (c *comparer) compareCustomTypes(oldTypes, newTypes []*CustomType) {
+	newByName := map[string]*CustomType{}
+	for _, ct := range newTypes {
+		newByName[ct.Name] = ct
+	}
+	oldByName := map[string]*CustomType{}
+	for _, oldType := range oldTypes {
+		oldByName[oldType.Name] = oldType
+		path := "schemas." + oldType.Name
+		newType := newByName[oldType.Name]
+		if newType == nil {
+			c.add(Breaking, oldType.Pos, path, "schema %q removed", oldType.Name)
+			continue
+		}
+		c.compareDescription(newType.Pos, path, oldType.Description, newType.Description)
+		c.compareEnum(newType.Pos, path+".enum", oldType.Enum, newType.Enum)
+		c.compareOneOf(newType.Pos, path+".oneOf", oldType, newType)
+		c.compareProperties(path+".properties", oldType, newType)
+	}
+
+	for _, newType := range newTypes {
+		if oldByName[newType.Name] == nil {
+			c.add(Compatible, newType.Pos, "schemas."+newType.Name, "schema %q added", newType.Name)
+		}
+	}
+}
+
+func (c *comparer) compareEnum(pos Position, path string, oldValues, newValues []string) {
+	oldSet := map[string]bool{}
+	for _, v := range oldValues {
+		oldSet[v] = true
+	}
+	newSet := map[string]bool{}
+	for _, v := range newValues {
+		newSet[v] = true
+	}
+
+	for _, v := range oldValues {
+		if !newSet[v] {
+			c.add(Breaking, pos, path, "enum value %q removed", v)
+		}
+	}
+	for _, v := range newValues {
+		if !oldSet[v] {
+			c.add(Compatible, pos, path, "enum value %q added", v)
+		}
+	}
+}
+
+// compareOneOf compares the variants of two versions of a `oneOf` schema
+// and the discriminator values that select them.
+func (c *comparer) compareOneOf(pos Position, path string, oldType, newType *CustomType) {
+	var oldName, newName string
+	if oldType.Discriminator != nil {
+		oldName = oldType.Discriminator.PropertyName
+	}
+	if newType.Discriminator != nil {
+		newName = newType.Discriminator.PropertyName
+	}
+	if oldName != newName {
+		c.add(Breaking, pos, path, "discriminator property changed from %q to %q", oldName, newName)
+	}
+
+	newByRef := map[string]*Property{}
+	for _, variant := range newType.OneOf {
+		newByRef[variant.Ref] = variant
+	}
+	oldByRef := map[string]*Property{}
+	for _, oldVariant := range oldType.OneOf {
+		oldByRef[oldVariant.Ref] = oldVariant
+		newVariant := newByRef[oldVariant.Ref]
+		if newVariant == nil {
+			c.add(Breaking, oldVariant.Pos, path, "oneOf variant %q removed", refName(oldVariant.Ref))
+			continue
+		}
+		if oldType.Discriminator == nil || newType.Discriminator == nil {
+			continue
+		}
+		oldValue := oldType.Discriminator.Value(oldVariant.Ref)
+		if newValue := newType.Discriminator.Value(newVariant.Ref); oldValue != newValue {
+			c.add(Breaking, newVariant.Pos, path, "discriminator value of %q changed from %q to %q", refName(oldVariant.Ref), oldValue, newValue)
+		}
+	}
+
+	for _, newVariant := range newType.OneOf {
+		if oldByRef[newVariant.Ref] == nil {
+			c.add(Compatible, newVariant.Pos, path, "oneOf variant %q added", refName(newVariant.Ref))
+		}
+	}
+}
+
+func (c *comparer) compareProperties(path string, oldType, newType *CustomType) {
+	oldRequired := requiredSet(oldType)
+	newRequired := requiredSet(newType)
+
+	newByName := map[string]*Property{}
+	for _, prop := range newType.Properties {
+		newByName[prop.Name] = prop
+	}
+	oldByName := map[string]*Property{}
+	for _, oldProp := range oldType.Properties {
+		oldByName[oldProp.Name] = oldProp
+		propPath := path + "." + oldProp.Name
+		newProp := newByName[oldProp.Name]
+		switch {
+		case newProp == nil && oldRequired[oldProp.Name]:
+			c.add(Breaking, oldProp.Pos, propPath, "required property %q removed", oldProp.Name)
+			continue
+		case newProp == nil:
+			c.add(Compatible, oldProp.Pos, propPath, "optional property %q removed", oldProp.Name)
+			continue
+		case !oldRequired[oldProp.Name] && newRequired[oldProp.Name]:
+			c.add(Breaking, newProp.Pos, propPath, "property %q is now required", oldProp.Name)
+		case oldRequired[oldProp.Name] && !newRequired[oldProp.Name]:
+			c.add(Breaking, newProp.Pos, propPath, "property %q is now optional", oldProp.Name)
+		}
+		c.compareProperty(propPath, oldProp, newProp)
+	}
+
+	for _, newProp := range newType.Properties {
+		if oldByName[newProp.Name] != nil {
+			continue
+		}
+		if newRequired[newProp.Name] {
+			c.add(Breaking, newProp.Pos, path+"."+newProp.Name, "required property %q added", newProp.Name)
+		} else {
+			c.add(Compatible, newProp.Pos, path+"."+newProp.Name, "optional property %q added", newProp.Name)
+		}
+	}
+}
+
+func (c *comparer) compareProperty(path string, oldProp, newProp *Property) {
+	pos := newProp.Pos
+	oldType := typeString(oldProp.Ref, oldProp.Type, oldProp.Format, oldProp.Items, oldProp.AdditionalProperties)
+	newType := typeString(newProp.Ref, newProp.Type, newProp.Format, newProp.Items, newProp.AdditionalProperties)
+	c.compareType(pos, path, oldType, newType)
+	c.compareDescription(pos, path, oldProp.Description, newProp.Description)
+
+	switch {
+	case oldProp.Minimum == nil && newProp.Minimum != nil:
+		c.add(Breaking, pos, path+".minimum", "minimum %v added", *newProp.Minimum)
+	case oldProp.Minimum != nil && newProp.Minimum == nil:
+		c.add(Compatible, pos, path+".minimum", "minimum %v removed", *oldProp.Minimum)
+	case oldProp.Minimum != nil && *newProp.Minimum > *oldProp.Minimum:
+		c.add(Breaking, pos, path+".minimum", "minimum raised from %v to %v", *oldProp.Minimum, *newProp.Minimum)
+	case oldProp.Minimum != nil && *newProp.Minimum < *oldProp.Minimum:
+		c.add(Compatible, pos, path+".minimum", "minimum lowered from %v to %v", *oldProp.Minimum, *newProp.Minimum)
+	}
+
+	switch {
+	case oldProp.Maximum == nil && newProp.Maximum != nil:
+		c.add(Breaking, pos, path+".maximum", "maximum %v added", *newProp.Maximum)
+	case oldProp.Maximum != nil && newProp.Maximum == nil:
+		c.add(Compatible, pos, path+".maximum", "maximum %v removed", *oldProp.Maximum)
+	case oldProp.Maximum != nil && *newProp.Maximum < *oldProp.Maximum:
+		c.add(Breaking, pos, path+".maximum", "maximum lowered from %v to %v", *oldProp.Maximum, *newProp.Maximum)
+	case oldProp.Maximum != nil && *newProp.Maximum > *oldProp.Maximum:
+		c.add(Compatible, pos, path+".maximum", "maximum raised from %v to %v", *oldProp.Maximum, *newProp.Maximum)
+	}
+
+	if (oldProp.Default == nil) != (newProp.Default == nil) ||
+		(oldProp.Default != nil && *oldProp.Default != *newProp.Default) {
+		c.add(Compatible, pos, path+".default", "default changed")
+	}
+
+	if oldType == newType {
+		c.compareElements(path, oldProp.Items, newProp.Items, oldProp.AdditionalProperties, newProp.AdditionalProperties)
+	}
+}
+
+// compareElements compares the elements of two versions of an array or map
+// of the same type, whose constraints may still differ.
+func (c *comparer) compareElements(path string, oldItems, newItems, oldValues, newValues *Property) {
+	if oldItems != nil && newItems != nil {
+		c.compareProperty(path+".items", oldItems, newItems)
+	}
+	if oldValues != nil && newValues != nil {
+		c.compareProperty(path+".additionalProperties", oldValues, newValues)
+	}
+}
+
+// contentTypeOf returns the contentType of an input or output,
+// which defaults to "application/x-binary" for a buffer and
+// "application/json" for anything else.
+func contentTypeOf(typ, contentType string) string {
+	switch {
+	case contentType != "":
+		return contentType
+	case typ == "buffer":
+		return "application/x-binary"
+	default:
+		return "application/json"
+	}
+}
+
+// mediaType returns the media type of a contentType without its parameters,
+// e.g. "application/json" for "application/json; charset=utf-8", or the
+// contentType itself if it cannot be parsed.
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+	return mediaType
+}
+
+// requiredSet returns the names of the required properties of ct.
+func requiredSet(ct *CustomType) map[string]bool {
+	m := map[string]bool{}
+	for _, name := range ct.Required {
+		m[name] = true
+	}
+	return m
+}
+
+// typeString describes a type for comparison and reporting,
+// e.g. "Fruit", "string (date-time)", "array of Fruit", or "map of integer".
+func typeString(ref, typ, format string, items, additionalProperties *Property) string {
+	if ref != "" {
+		return refName(ref)
+	}
+
+	elemString := func(elem *Property) string {
+		if elem == nil {
+			return "any"
+		}
+		return typeString(elem.Ref, elem.Type, elem.Format, elem.Items, elem.AdditionalProperties)
+	}
+
+	switch {
+	case typ == "array":
+		return "array of " + elemString(items)
+	case typ == "object":
+		return "map of " + elemString(additionalProperties)
+	case typ == "":
+		return "void"
+	case format != "":
+		return fmt.Sprintf("%v (%v)", typ, format)
+	default:
+		return typ
+	}
+}
@@ -0,0 +1,385 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var compatOldYaml = `version: v1-draft
+exports:
+  - name: getUser
+    input:
+      type: string
+    output:
+      $ref: '#/schemas/User'
+  - name: deleteUser
+    input:
+      type: string
+imports:
+  - name: log
+    input:
+      type: string
+      contentType: text/plain
+schemas:
+  - name: Role
+    enum:
+      - admin
+      - guest
+  - name: User
+    description: A user
+    required:
+      - name
+    properties:
+      - name: name
+        type: string
+      - name: age
+        type: integer
+        minimum: 0
+        maximum: 200
+      - name: nickname
+        type: string
+      - name: role
+        $ref: '#/schemas/Role'
+`
+
+var compatNewYaml = `version: v1-draft
+exports:
+  - name: getUser
+    input:
+      type: integer
+    output:
+      $ref: '#/schemas/User'
+  - name: listUsers
+    output:
+      type: array
+      items:
+        $ref: '#/schemas/User'
+imports:
+  - name: log
+    input:
+      type: string
+      contentType: text/plain
+  - name: now
+    output:
+      type: string
+      format: date-time
+schemas:
+  - name: Role
+    enum:
+      - admin
+      - owner
+  - name: User
+    description: A user of the system
+    required:
+      - name
+      - email
+    properties:
+      - name: name
+        type: string
+      - name: age
+        type: integer
+        minimum: 18
+        maximum: 300
+      - name: email
+        type: string
+      - name: role
+        $ref: '#/schemas/Role'
+`
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	oldPlugin, err := ParseNamedStr("old.yaml", compatOldYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPlugin, err := ParseNamedStr("new.yaml", compatNewYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Change{
+		{Compatibility: Breaking, Path: "exports.getUser.input.type", Message: "type changed from string to integer", Pos: Position{Filename: "new.yaml", Line: 5, Column: 7}},
+		{Compatibility: Breaking, Path: "exports.deleteUser", Message: `export "deleteUser" removed`, Pos: Position{Filename: "old.yaml", Line: 8, Column: 5}},
+		{Compatibility: Compatible, Path: "exports.listUsers", Message: `export "listUsers" added`, Pos: Position{Filename: "new.yaml", Line: 8, Column: 5}},
+		{Compatibility: Compatible, Path: "imports.now", Message: `import "now" added`, Pos: Position{Filename: "new.yaml", Line: 18, Column: 5}},
+		{Compatibility: Breaking, Path: "schemas.Role.enum", Message: `enum value "guest" removed`, Pos: Position{Filename: "new.yaml", Line: 23, Column: 5}},
+		{Compatibility: Compatible, Path: "schemas.Role.enum", Message: `enum value "owner" added`, Pos: Position{Filename: "new.yaml", Line: 23, Column: 5}},
+		{Compatibility: Compatible, Path: "schemas.User.description", Message: "description changed", Pos: Position{Filename: "new.yaml", Line: 27, Column: 5}},
+		{Compatibility: Breaking, Path: "schemas.User.properties.age.minimum", Message: "minimum raised from 0 to 18", Pos: Position{Filename: "new.yaml", Line: 35, Column: 9}},
+		{Compatibility: Compatible, Path: "schemas.User.properties.age.maximum", Message: "maximum raised from 200 to 300", Pos: Position{Filename: "new.yaml", Line: 35, Column: 9}},
+		{Compatibility: Compatible, Path: "schemas.User.properties.nickname", Message: `optional property "nickname" removed`, Pos: Position{Filename: "old.yaml", Line: 32, Column: 9}},
+		{Compatibility: Breaking, Path: "schemas.User.properties.email", Message: `required property "email" added`, Pos: Position{Filename: "new.yaml", Line: 39, Column: 9}},
+	}
+
+	got := Compare(oldPlugin, newPlugin)
+	if diff := cmp.Diff(want, got.Changes); diff != "" {
+		t.Errorf("Compare mismatch (-want +got):\n%v", diff)
+	}
+	if got.Compatible {
+		t.Error("Compatible = true, want false")
+	}
+	if n := len(got.Breaking()); n != 5 {
+		t.Errorf("len(Breaking) = %v, want 5", n)
+	}
+}
+
+func TestCompare_Rules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		oldYaml string
+		newYaml string
+		want    Compatibility
+		wantMsg string
+	}{
+		{
+			name:    "narrowed maximum",
+			oldYaml: "properties:\n      - name: n\n        type: integer\n        maximum: 10",
+			newYaml: "properties:\n      - name: n\n        type: integer\n        maximum: 5",
+			want:    Breaking,
+			wantMsg: "maximum lowered from 10 to 5",
+		},
+		{
+			name:    "added minimum",
+			oldYaml: "properties:\n      - name: n\n        type: integer",
+			newYaml: "properties:\n      - name: n\n        type: integer\n        minimum: 1",
+			want:    Breaking,
+			wantMsg: "minimum 1 added",
+		},
+		{
+			name:    "removed minimum",
+			oldYaml: "properties:\n      - name: n\n        type: integer\n        minimum: 1",
+			newYaml: "properties:\n      - name: n\n        type: integer",
+			want:    Compatible,
+			wantMsg: "minimum 1 removed",
+		},
+		{
+			name:    "added optional property",
+			oldYaml: "properties:\n      - name: n\n        type: integer",
+			newYaml: "properties:\n      - name: n\n        type: integer\n      - name: m\n        type: string",
+			want:    Compatible,
+			wantMsg: `optional property "m" added`,
+		},
+		{
+			name:    "now required",
+			oldYaml: "properties:\n      - name: n\n        type: integer",
+			newYaml: "required:\n      - n\n    properties:\n      - name: n\n        type: integer",
+			want:    Breaking,
+			wantMsg: `property "n" is now required`,
+		},
+		{
+			name:    "removed required property",
+			oldYaml: "required:\n      - n\n    properties:\n      - name: n\n        type: integer\n      - name: m\n        type: string",
+			newYaml: "properties:\n      - name: m\n        type: string",
+			want:    Breaking,
+			wantMsg: `required property "n" removed`,
+		},
+		{
+			name:    "changed format",
+			oldYaml: "properties:\n      - name: n\n        type: integer\n        format: int32",
+			newYaml: "properties:\n      - name: n\n        type: integer\n        format: int64",
+			want:    Breaking,
+			wantMsg: "type changed from integer (int32) to integer (int64)",
+		},
+		{
+			name:    "changed array items",
+			oldYaml: "properties:\n      - name: n\n        type: array\n        items:\n          type: integer",
+			newYaml: "properties:\n      - name: n\n        type: array\n        items:\n          type: string",
+			want:    Breaking,
+			wantMsg: "type changed from array of integer to array of string",
+		},
+		{
+			name:    "raised minimum of array items",
+			oldYaml: "properties:\n      - name: n\n        type: array\n        items:\n          type: integer\n          minimum: 0",
+			newYaml: "properties:\n      - name: n\n        type: array\n        items:\n          type: integer\n          minimum: 1",
+			want:    Breaking,
+			wantMsg: "minimum raised from 0 to 1",
+		},
+		{
+			name:    "added maximum of map values",
+			oldYaml: "properties:\n      - name: n\n        type: object\n        additionalProperties:\n          type: number",
+			newYaml: "properties:\n      - name: n\n        type: object\n        additionalProperties:\n          type: number\n          maximum: 9.5",
+			want:    Breaking,
+			wantMsg: "maximum 9.5 added",
+		},
+		{
+			name:    "removed maximum of nested array items",
+			oldYaml: "properties:\n      - name: n\n        type: array\n        items:\n          type: array\n          items:\n            type: integer\n            maximum: 3",
+			newYaml: "properties:\n      - name: n\n        type: array\n        items:\n          type: array\n          items:\n            type: integer",
+			want:    Compatible,
+			wantMsg: "maximum 3 removed",
+		},
+		{
+			name:    "changed format of map values",
+			oldYaml: "properties:\n      - name: n\n        type: object\n        additionalProperties:\n          type: number\n          format: double",
+			newYaml: "properties:\n      - name: n\n        type: object\n        additionalProperties:\n          type: number\n          format: float",
+			want:    Breaking,
+			wantMsg: "type changed from map of number (double) to map of number (float)",
+		},
+		{
+			name:    "added oneOf variant",
+			oldYaml: "oneOf:\n      - $ref: '#/schemas/A'\n    discriminator:\n      propertyName: kind",
+			newYaml: "oneOf:\n      - $ref: '#/schemas/A'\n      - $ref: '#/schemas/B'\n    discriminator:\n      propertyName: kind",
+			want:    Compatible,
+			wantMsg: `oneOf variant "B" added`,
+		},
+		{
+			name:    "removed oneOf variant",
+			oldYaml: "oneOf:\n      - $ref: '#/schemas/A'\n      - $ref: '#/schemas/B'\n    discriminator:\n      propertyName: kind",
+			newYaml: "oneOf:\n      - $ref: '#/schemas/A'\n    discriminator:\n      propertyName: kind",
+			want:    Breaking,
+			wantMsg: `oneOf variant "B" removed`,
+		},
+		{
+			name:    "changed discriminator value",
+			oldYaml: "oneOf:\n      - $ref: '#/schemas/A'\n    discriminator:\n      propertyName: kind",
+			newYaml: "oneOf:\n      - $ref: '#/schemas/A'\n    discriminator:\n      propertyName: kind\n      mapping:\n        a: '#/schemas/A'",
+			want:    Breaking,
+			wantMsg: `discriminator value of "A" changed from "A" to "a"`,
+		},
+		{
+			name:    "changed discriminator property",
+			oldYaml: "oneOf:\n      - $ref: '#/schemas/A'\n    discriminator:\n      propertyName: kind",
+			newYaml: "oneOf:\n      - $ref: '#/schemas/A'\n    discriminator:\n      propertyName: type",
+			want:    Breaking,
+			wantMsg: `discriminator property changed from "kind" to "type"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parse := func(props string) *Plugin {
+				t.Helper()
+				p, err := ParseStr("version: v1-draft\nexports: []\nschemas:\n  - name: T\n    " + props + "\n")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return p
+			}
+
+			got := Compare(parse(tt.oldYaml), parse(tt.newYaml))
+			if len(got.Changes) != 1 {
+				t.Fatalf("Compare = %v, want 1 change", got)
+			}
+			if c := got.Changes[0]; c.Compatibility != tt.want || c.Message != tt.wantMsg {
+				t.Errorf("Compare = %v: %v, want %v: %v", c.Compatibility, c.Message, tt.want, tt.wantMsg)
+			}
+			if got.Compatible != (tt.want == Compatible) {
+				t.Errorf("Compatible = %v, want %v", got.Compatible, tt.want == Compatible)
+			}
+		})
+	}
+}
+
+func TestCompare_ContentType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		oldContentType string
+		newContentType string
+		wantMsg        string
+	}{
+		{
+			name:           "added charset",
+			oldContentType: "application/json",
+			newContentType: "application/json; charset=utf-8",
+		},
+		{
+			name:           "removed charset",
+			oldContentType: "text/plain; charset=utf-8",
+			newContentType: "text/plain",
+		},
+		{
+			name:           "changed case",
+			oldContentType: "application/json",
+			newContentType: "Application/JSON",
+		},
+		{
+			name:           "changed media type",
+			oldContentType: "application/json; charset=utf-8",
+			newContentType: "text/plain; charset=utf-8",
+			wantMsg:        `contentType changed from "application/json; charset=utf-8" to "text/plain; charset=utf-8"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parse := func(contentType string) *Plugin {
+				t.Helper()
+				p, err := ParseStr("version: v1-draft\nexports:\n  - name: greet\n    input:\n      type: string\n      contentType: " + contentType + "\n")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return p
+			}
+
+			got := Compare(parse(tt.oldContentType), parse(tt.newContentType))
+			if tt.wantMsg == "" {
+				if !got.Compatible || len(got.Changes) != 0 {
+					t.Errorf("Compare = %v, want no changes", got)
+				}
+				return
+			}
+			if len(got.Changes) != 1 {
+				t.Fatalf("Compare = %v, want 1 change", got)
+			}
+			if c := got.Changes[0]; c.Compatibility != Breaking || c.Message != tt.wantMsg {
+				t.Errorf("Compare = %v: %v, want %v: %v", c.Compatibility, c.Message, Breaking, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestCompare_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	oldPlugin, err := ParseStr(userYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPlugin, err := ParseStr(userYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Compare(oldPlugin, newPlugin)
+	if !got.Compatible || len(got.Changes) != 0 {
+		t.Errorf("Compare = %v, want no changes", got)
+	}
+	if want := "no changes\n"; got.String() != want {
+		t.Errorf("String = %q, want %q", got.String(), want)
+	}
+}
+
+func TestCompatReport_Output(t *testing.T) {
+	t.Parallel()
+
+	report := &CompatReport{
+		Changes: []*Change{
+			{Compatibility: Breaking, Path: "exports.run", Message: `export "run" removed`, Pos: Position{Filename: "old.yaml", Line: 3, Column: 5}},
+			{Compatibility: Compatible, Path: "schemas.Job", Message: `schema "Job" added`},
+		},
+	}
+
+	wantText := `old.yaml:3:5: breaking: exports.run: export "run" removed
+compatible: schemas.Job: schema "Job" added
+1 breaking and 1 compatible change(s)
+`
+	if got := report.String(); got != wantText {
+		t.Errorf("String =\n%v\nwant:\n%v", got, wantText)
+	}
+
+	wantJSON := `{"compatible":false,"changes":[{"compatibility":"breaking","path":"exports.run","message":"export \"run\" removed","position":"old.yaml:3:5"},{"compatibility":"compatible","path":"schemas.Job","message":"schema \"Job\" added"}]}`
+	buf, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantJSON, string(buf)); diff != "" {
+		t.Errorf("json.Marshal mismatch (-want +got):\n%v", diff)
+	}
+}
@@ -0,0 +1,75 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RefCycle is a chain of required properties, each one referring to the
+// schema that declares the next, where the last refers back to the schema
+// that declares the first.
+//
+// A schema within a RefCycle has no finite value. Recursive schemas must
+// instead recurse through an optional property, an array, or a map.
+type RefCycle []*Property
+
+// String returns the cycle in the form "Term.expr -> Expr.term -> Term".
+func (rc RefCycle) String() string {
+	if len(rc) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	owner := refName(rc[len(rc)-1].Ref)
+	for _, prop := range rc {
+		fmt.Fprintf(&sb, "%v.%v -> ", owner, prop.Name)
+		owner = refName(prop.Ref)
+	}
+	sb.WriteString(owner)
+	return sb.String()
+}
+
+// RequiredRefCycle returns the cycle of required references leading from
+// ct back to itself, or nil if there is none.
+// It relies upon the references linked by ParseStr.
+func (ct *CustomType) RequiredRefCycle() RefCycle {
+	return findRequiredRefCycle(ct, func(prop *Property) *CustomType { return prop.RefCustomType })
+}
+
+// findRequiredRefCycle walks the required properties of start, using resolve
+// to follow each `$ref`, and returns the first chain that leads back to start.
+func findRequiredRefCycle(start *CustomType, resolve func(prop *Property) *CustomType) RefCycle {
+	visited := map[*CustomType]bool{}
+	var chain RefCycle
+
+	var walk func(ct *CustomType) bool
+	walk = func(ct *CustomType) bool {
+		visited[ct] = true
+		for _, prop := range ct.GetRequiredProps() {
+			if prop.Ref == "" {
+				continue
+			}
+			next := resolve(prop)
+			if next == nil {
+				continue
+			}
+			chain = append(chain, prop)
+			if next == start || (!visited[next] && walk(next)) {
+				return true
+			}
+			chain = chain[:len(chain)-1]
+		}
+		return false
+	}
+
+	if !walk(start) {
+		return nil
+	}
+	return chain
+}
+
+// refName returns the schema name of a `$ref` such as "#/schemas/Node".
+func refName(ref string) string {
+	parts := strings.Split(ref, "/")
+	return parts[len(parts)-1]
+}
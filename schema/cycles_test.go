@@ -0,0 +1,36 @@
+package schema
+
+import "testing"
+
+func TestRequiredRefCycle(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(cyclesYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Node", want: "Node.parent -> Node"},
+		{name: "Expr", want: "Expr.term -> Term.expr -> Expr"},
+		{name: "Term", want: "Term.expr -> Expr.term -> Term"},
+		{name: "Tree", want: ""},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := plugin.CustomTypes[i]
+			if ct.Name != tt.name {
+				t.Fatalf("CustomTypes[%v] = %q, want %q", i, ct.Name, tt.name)
+			}
+
+			cycle := ct.RequiredRefCycle()
+			if got := cycle.String(); got != tt.want {
+				t.Errorf("RequiredRefCycle = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
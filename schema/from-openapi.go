@@ -0,0 +1,452 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unsupported is reported by FromOpenAPI for a construct that cannot be
+// expressed in an XTP schema and was either ignored or approximated.
+const Unsupported DiagnosticKind = "unsupported"
+
+// openAPIAnnotations are keywords that carry no meaning for code generation
+// and are silently dropped.
+var openAPIAnnotations = map[string]bool{
+	"title":        true,
+	"example":      true,
+	"examples":     true,
+	"deprecated":   true,
+	"readOnly":     true,
+	"writeOnly":    true,
+	"externalDocs": true,
+	"xml":          true,
+}
+
+// openAPIKeywords are the keywords understood by FromOpenAPI.
+var openAPIKeywords = map[string]bool{
+	"$ref":                 true,
+	"type":                 true,
+	"format":               true,
+	"description":          true,
+	"enum":                 true,
+	"required":             true,
+	"properties":           true,
+	"items":                true,
+	"additionalProperties": true,
+	"minimum":              true,
+	"maximum":              true,
+	"default":              true,
+	"nullable":             true,
+}
+
+// FromOpenAPI converts the `components/schemas` of an OpenAPI 3 document,
+// in either YAML or JSON, into a Plugin with no exports or imports whose
+// CustomTypes can be written with ToYaml.
+//
+// Object schemas become structs and string enums become enums. Other named
+// schemas are inlined wherever they are referenced, and inline objects and
+// enums are hoisted into their own schemas named after their parent.
+// Everything that cannot be expressed (such as `oneOf` or `pattern`) is
+// ignored and reported as an Unsupported diagnostic, positioned within the
+// document named by filename.
+func FromOpenAPI(filename string, doc []byte) (*Plugin, Diagnostics, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(doc, &root); err != nil {
+		return nil, nil, positionedError(filename, err)
+	}
+
+	node := &root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if version := childNode(node, "openapi"); version == nil || !strings.HasPrefix(version.Value, "3.") {
+		return nil, nil, fmt.Errorf("%v: not an OpenAPI 3 document", Position{Filename: filename})
+	}
+	schemas := childNode(node, "components")
+	if schemas != nil {
+		schemas = childNode(schemas, "schemas")
+	}
+	if schemas == nil || schemas.Kind != yaml.MappingNode || len(schemas.Content) == 0 {
+		return nil, nil, fmt.Errorf("%v: no components/schemas found", Position{Filename: filename})
+	}
+
+	c := &openAPIConverter{
+		filename: filename,
+		schemas:  map[string]*yaml.Node{},
+		names:    map[string]bool{},
+		inlining: map[string]bool{},
+		aliases:  map[string]*openAPIAlias{},
+	}
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		name := schemas.Content[i].Value
+		c.order = append(c.order, name)
+		c.schemas[name] = schemas.Content[i+1]
+		c.names[name] = true
+	}
+
+	for _, name := range c.order {
+		at := path{"components", "schemas", name}
+		node := c.schemas[name]
+		switch c.kindOf(name) {
+		case "struct":
+			c.addStruct(at, name, node)
+		case "enum":
+			c.addEnum(at, name, node)
+		default:
+			c.warn(node, at, "schema %q is not an object or string enum; it is inlined wherever it is referenced", name)
+		}
+	}
+
+	plugin := &Plugin{
+		Version:     "v1-draft",
+		Exports:     []*Export{},
+		CustomTypes: c.customTypes,
+	}
+
+	// Round-trip through YAML so that the result is linked exactly
+	// as if it had been parsed from the schema that ToYaml writes.
+	yamlStr, err := plugin.ToYaml()
+	if err != nil {
+		return nil, nil, err
+	}
+	plugin, err = ParseStr(yamlStr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plugin, c.diags, nil
+}
+
+type openAPIConverter struct {
+	filename    string
+	order       []string
+	schemas     map[string]*yaml.Node
+	names       map[string]bool // all schema names, including hoisted ones
+	inlining    map[string]bool // guards against recursively inlined schemas
+	aliases     map[string]*openAPIAlias
+	customTypes []*CustomType
+	diags       Diagnostics
+}
+
+// openAPIAlias is the conversion of a schema that is inlined wherever it
+// is referenced, which is only done once so that the schemas hoisted out of
+// it, and its diagnostics, are not repeated for every reference.
+type openAPIAlias struct {
+	prop         *Property
+	nullable, ok bool
+}
+
+func (c *openAPIConverter) warn(node *yaml.Node, at path, format string, args ...any) {
+	c.diags = append(c.diags, &Diagnostic{
+		Kind:    Unsupported,
+		Path:    at.String(),
+		Message: fmt.Sprintf(format, args...),
+		Pos:     Position{Filename: c.filename, Line: node.Line, Column: node.Column},
+	})
+}
+
+// kindOf classifies a named schema as a "struct", an "enum", or an "alias".
+func (c *openAPIConverter) kindOf(name string) string {
+	node := c.schemas[name]
+	if node == nil || node.Kind != yaml.MappingNode || childNode(node, "$ref") != nil {
+		return "alias"
+	}
+	if childNode(node, "properties") != nil {
+		return "struct"
+	}
+	if enum := childNode(node, "enum"); enum != nil && isStringEnum(enum) {
+		return "enum"
+	}
+	return "alias"
+}
+
+func isStringEnum(enum *yaml.Node) bool {
+	if enum.Kind != yaml.SequenceNode || len(enum.Content) == 0 {
+		return false
+	}
+	for _, v := range enum.Content {
+		if v.Kind != yaml.ScalarNode || v.Tag != "!!str" {
+			return false
+		}
+	}
+	return true
+}
+
+// checkKeywords warns about every keyword of node that is not understood.
+func (c *openAPIConverter) checkKeywords(at path, node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if openAPIKeywords[key] || openAPIAnnotations[key] || strings.HasPrefix(key, "x-") {
+			continue
+		}
+		c.warn(node.Content[i], at.with(key), "%q is not supported; ignored", key)
+	}
+}
+
+// uniqueName returns name, or name followed by a number if it is taken.
+func (c *openAPIConverter) uniqueName(name string) string {
+	unique := name
+	for i := 2; c.names[unique]; i++ {
+		unique = fmt.Sprintf("%v%v", name, i)
+	}
+	c.names[unique] = true
+	return unique
+}
+
+func (c *openAPIConverter) addEnum(at path, name string, node *yaml.Node) {
+	c.checkKeywords(at, node)
+	ct := &CustomType{Name: name}
+	if desc := childNode(node, "description"); desc != nil {
+		ct.Description = desc.Value
+	}
+	for i, v := range childNode(node, "enum").Content {
+		if !identifierRE.MatchString(v.Value) {
+			c.warn(v, at.with("enum", i), "enum value %q is not a valid identifier", v.Value)
+		}
+		ct.Enum = append(ct.Enum, v.Value)
+	}
+	c.customTypes = append(c.customTypes, ct)
+}
+
+func (c *openAPIConverter) addStruct(at path, name string, node *yaml.Node) {
+	c.checkKeywords(at, node)
+	ct := &CustomType{Name: name}
+	if desc := childNode(node, "description"); desc != nil {
+		ct.Description = desc.Value
+	}
+	if addl := childNode(node, "additionalProperties"); addl != nil {
+		c.warn(addl, at.with("additionalProperties"), "additionalProperties of an object with properties is not supported; ignored")
+	}
+	// Add the struct before converting its properties so that
+	// any schemas hoisted out of them follow it.
+	c.customTypes = append(c.customTypes, ct)
+
+	required := map[string]bool{}
+	if req := childNode(node, "required"); req != nil {
+		for _, v := range req.Content {
+			required[v.Value] = true
+		}
+	}
+
+	props := childNode(node, "properties")
+	if props == nil {
+		return
+	}
+	for i := 0; i+1 < len(props.Content); i += 2 {
+		propName, propNode := props.Content[i].Value, props.Content[i+1]
+		propAt := at.with("properties", propName)
+		if !identifierRE.MatchString(propName) {
+			c.warn(props.Content[i], propAt, "property name %q is not a valid identifier", propName)
+		}
+
+		prop, nullable, ok := c.convert(propAt, propNode, name+uppercaseFirstASCII(propName))
+		if !ok {
+			continue
+		}
+		prop.Name = propName
+		c.addConstraints(propAt, propNode, prop)
+		ct.Properties = append(ct.Properties, prop)
+
+		if required[propName] {
+			if nullable {
+				c.warn(propNode, propAt, "required nullable property %q is not supported; made optional", propName)
+				continue
+			}
+			ct.Required = append(ct.Required, propName)
+		}
+	}
+}
+
+// addConstraints copies the description, minimum, maximum and default
+// of a property.
+func (c *openAPIConverter) addConstraints(at path, node *yaml.Node, prop *Property) {
+	if node.Kind != yaml.MappingNode || childNode(node, "$ref") != nil {
+		return
+	}
+	if desc := childNode(node, "description"); desc != nil {
+		prop.Description = desc.Value
+	}
+	for _, bound := range []struct {
+		key string
+		dst **float64
+	}{
+		{key: "minimum", dst: &prop.Minimum},
+		{key: "maximum", dst: &prop.Maximum},
+	} {
+		if v := childNode(node, bound.key); v != nil {
+			var f float64
+			if err := v.Decode(&f); err != nil {
+				c.warn(v, at.with(bound.key), "%v %q is not a number; ignored", bound.key, v.Value)
+				continue
+			}
+			*bound.dst = &f
+		}
+	}
+	if def := childNode(node, "default"); def != nil {
+		if def.Kind != yaml.ScalarNode {
+			c.warn(def, at.with("default"), "a default that is not a scalar is not supported; ignored")
+		} else {
+			value := def.Value
+			prop.Default = &value
+		}
+	}
+}
+
+// convert returns the type of the schema node as a Property without a name.
+// hoistName names any inline object or enum that must become its own schema.
+// It also reports whether the schema is nullable, and returns ok=false if
+// the schema cannot be expressed at all.
+func (c *openAPIConverter) convert(at path, node *yaml.Node, hoistName string) (prop *Property, nullable, ok bool) {
+	if node.Kind != yaml.MappingNode {
+		c.warn(node, at, "schema is not an object; ignored")
+		return nil, false, false
+	}
+
+	if ref := childNode(node, "$ref"); ref != nil {
+		return c.convertRef(at.with("$ref"), ref)
+	}
+
+	if n := childNode(node, "nullable"); n != nil && n.Value == "true" {
+		nullable = true
+	}
+
+	var typ string
+	if t := childNode(node, "type"); t != nil {
+		switch t.Kind {
+		case yaml.ScalarNode:
+			typ = t.Value
+		case yaml.SequenceNode: // OpenAPI 3.1, e.g. [string, "null"]
+			var types []string
+			for _, v := range t.Content {
+				if v.Value == "null" {
+					nullable = true
+					continue
+				}
+				types = append(types, v.Value)
+			}
+			if len(types) != 1 {
+				c.warn(t, at.with("type"), "multiple types %v are not supported; ignored", types)
+				return nil, false, false
+			}
+			typ = types[0]
+		}
+	} else if childNode(node, "properties") != nil {
+		typ = "object"
+	}
+
+	enum := childNode(node, "enum")
+	format := childNode(node, "format")
+
+	// Hoisted schemas check their own keywords.
+	if hoisted := (typ == "string" && enum != nil) || (typ == "object" && childNode(node, "properties") != nil); !hoisted {
+		c.checkKeywords(at, node)
+	}
+
+	switch typ {
+	case "string":
+		if enum != nil {
+			name := c.uniqueName(hoistName)
+			c.warn(enum, at.with("enum"), "inline enum hoisted to schema %q", name)
+			c.addEnum(at, name, node)
+			return &Property{Ref: "#/schemas/" + name}, nullable, true
+		}
+		if format != nil && (format.Value == "byte" || format.Value == "binary") {
+			return &Property{Type: "buffer"}, nullable, true
+		}
+	case "integer", "number", "boolean":
+		if enum != nil {
+			c.warn(enum, at.with("enum"), "enum of type %q is not supported; ignored", typ)
+		}
+	case "array":
+		items := childNode(node, "items")
+		if items == nil {
+			c.warn(node, at, "array without items is not supported; ignored")
+			return nil, false, false
+		}
+		itemsProp, _, ok := c.convert(at.with("items"), items, hoistName+"Item")
+		if !ok {
+			return nil, false, false
+		}
+		return &Property{Type: "array", Items: itemsProp}, nullable, true
+	case "object":
+		if childNode(node, "properties") != nil {
+			name := c.uniqueName(hoistName)
+			c.warn(node, at, "inline object hoisted to schema %q", name)
+			c.addStruct(at, name, node)
+			return &Property{Ref: "#/schemas/" + name}, nullable, true
+		}
+		prop := &Property{Type: "object"}
+		if addl := childNode(node, "additionalProperties"); addl != nil && addl.Kind == yaml.MappingNode {
+			values, _, ok := c.convert(at.with("additionalProperties"), addl, hoistName+"Value")
+			if !ok {
+				return nil, false, false
+			}
+			prop.AdditionalProperties = values
+		}
+		return prop, nullable, true
+	case "":
+		c.warn(node, at, "schema without a type is not supported; ignored")
+		return nil, false, false
+	default:
+		c.warn(node, at, "type %q is not supported; ignored", typ)
+		return nil, false, false
+	}
+
+	prop = &Property{Type: typ}
+	if format != nil {
+		if knownFormats[typ][format.Value] {
+			prop.Format = format.Value
+		} else {
+			c.warn(format, at.with("format"), "format %q of type %q is not supported; ignored", format.Value, typ)
+		}
+	}
+	return prop, nullable, true
+}
+
+// convertRef converts a `$ref` to a named schema, inlining it unless
+// it is a struct or enum.
+func (c *openAPIConverter) convertRef(at path, ref *yaml.Node) (prop *Property, nullable, ok bool) {
+	name, found := strings.CutPrefix(ref.Value, "#/components/schemas/")
+	if !found || c.schemas[name] == nil {
+		c.warn(ref, at, "unresolved or external reference %q is not supported; ignored", ref.Value)
+		return nil, false, false
+	}
+
+	if kind := c.kindOf(name); kind != "alias" {
+		return &Property{Ref: "#/schemas/" + name}, false, true
+	}
+
+	alias := c.aliases[name]
+	if alias == nil {
+		if c.inlining[name] {
+			c.warn(ref, at, "recursive reference %q to a schema that is not an object is not supported; ignored", ref.Value)
+			return nil, false, false
+		}
+		c.inlining[name] = true
+		at = path{"components", "schemas", name}
+		alias = &openAPIAlias{}
+		alias.prop, alias.nullable, alias.ok = c.convert(at, c.schemas[name], name)
+		if alias.ok {
+			c.addConstraints(at, c.schemas[name], alias.prop)
+		}
+		delete(c.inlining, name)
+		c.aliases[name] = alias
+	}
+	if !alias.ok {
+		return nil, false, false
+	}
+	// Every reference gets its own copy, which is named by its caller.
+	prop = new(Property)
+	*prop = *alias.prop
+	return prop, alias.nullable, true
+}
+
+// uppercaseFirstASCII returns s with its first letter in upper case.
+func uppercaseFirstASCII(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,279 @@
+package schema
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+//go:embed testdata/petstore.openapi.yaml
+var petstoreOpenAPIYaml []byte
+
+//go:embed testdata/petstore.openapi.json
+var petstoreOpenAPIJSON []byte
+
+//go:embed testdata/petstore.yaml
+var petstoreYaml string
+
+func TestFromOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	plugin, diags, err := FromOpenAPI("petstore.openapi.yaml", petstoreOpenAPIYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := plugin.ToYaml()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(petstoreYaml, got); diff != "" {
+		t.Logf("got:\n%v", got)
+		t.Errorf("FromOpenAPI mismatch (-want +got):\n%v", diff)
+	}
+
+	wantDiags := []string{
+		`petstore.openapi.yaml:22:11: components.schemas.Pet.properties.name.minLength: "minLength" is not supported; ignored`,
+		`petstore.openapi.yaml:24:11: components.schemas.Pet.properties.nickname: required nullable property "nickname" is not supported; made optional`,
+		`petstore.openapi.yaml:33:11: components.schemas.Pet.properties.owner: inline object hoisted to schema "PetOwner"`,
+		`petstore.openapi.yaml:37:23: components.schemas.Pet.properties.owner.properties.email.format: format "email" of type "string" is not supported; ignored`,
+		`petstore.openapi.yaml:43:13: components.schemas.Pet.properties.size.enum: inline enum hoisted to schema "PetSize"`,
+		`petstore.openapi.yaml:63:11: components.schemas.Pet.properties.category.oneOf: "oneOf" is not supported; ignored`,
+		`petstore.openapi.yaml:63:11: components.schemas.Pet.properties.category: schema without a type is not supported; ignored`,
+		`petstore.openapi.yaml:82:7: components.schemas.Weight: schema "Weight" is not an object or string enum; it is inlined wherever it is referenced`,
+	}
+	var gotDiags []string
+	for _, d := range diags {
+		if d.Kind != Unsupported {
+			t.Errorf("Kind = %v, want %v", d.Kind, Unsupported)
+		}
+		gotDiags = append(gotDiags, d.Error())
+	}
+	if diff := cmp.Diff(wantDiags, gotDiags); diff != "" {
+		t.Errorf("FromOpenAPI diagnostics mismatch (-want +got):\n%v", diff)
+	}
+
+	if ds := plugin.Validate(); ds != nil {
+		t.Errorf("Validate =\n%v", ds)
+	}
+}
+
+func TestFromOpenAPI_JSON(t *testing.T) {
+	t.Parallel()
+
+	plugin, diags, err := FromOpenAPI("petstore.openapi.json", petstoreOpenAPIJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := plugin.ToYaml()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(petstoreYaml, got); diff != "" {
+		t.Errorf("FromOpenAPI mismatch (-want +got):\n%v", diff)
+	}
+
+	if len(diags) != 8 {
+		t.Fatalf("FromOpenAPI = %v diagnostics, want 8:\n%v", len(diags), diags)
+	}
+	if want := "petstore.openapi.json:26:13: components.schemas.Pet.properties.name.minLength"; !strings.HasPrefix(diags[0].Error(), want) {
+		t.Errorf("diags[0] = %v, want prefix %v", diags[0], want)
+	}
+}
+
+func TestFromOpenAPI_Conversions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		schemas   string
+		want      string
+		wantDiags []string
+	}{
+		{
+			name: "array of inline objects",
+			schemas: `
+    List:
+      type: object
+      properties:
+        entries:
+          type: array
+          items:
+            type: object
+            properties:
+              key:
+                type: string`,
+			want: `  - name: List
+    properties:
+      - name: entries
+        type: array
+        items:
+          $ref: '#/schemas/ListEntriesItem'
+  - name: ListEntriesItem
+    properties:
+      - name: key
+        type: string
+`,
+			wantDiags: []string{`inline object hoisted to schema "ListEntriesItem"`},
+		},
+		{
+			name: "OpenAPI 3.1 nullable type",
+			schemas: `
+    Box:
+      type: object
+      required: [size]
+      properties:
+        size:
+          type: [integer, "null"]
+          format: int32`,
+			want: `  - name: Box
+    properties:
+      - name: size
+        type: integer
+        format: int32
+`,
+			wantDiags: []string{`required nullable property "size" is not supported; made optional`},
+		},
+		{
+			name: "recursive alias",
+			schemas: `
+    Tree:
+      type: object
+      properties:
+        children:
+          $ref: '#/components/schemas/Forest'
+    Forest:
+      type: array
+      items:
+        $ref: '#/components/schemas/Forest'`,
+			want: `  - name: Tree
+`,
+			wantDiags: []string{
+				`recursive reference "#/components/schemas/Forest" to a schema that is not an object is not supported; ignored`,
+				`schema "Forest" is not an object or string enum; it is inlined wherever it is referenced`,
+			},
+		},
+		{
+			name: "alias referenced twice",
+			schemas: `
+    Post:
+      type: object
+      properties:
+        tags:
+          $ref: '#/components/schemas/Tags'
+        draftTags:
+          $ref: '#/components/schemas/Tags'
+    Tags:
+      type: array
+      description: The tags
+      items:
+        type: object
+        properties:
+          label:
+            type: string`,
+			want: `  - name: Post
+    properties:
+      - name: tags
+        type: array
+        items:
+          $ref: '#/schemas/TagsItem'
+        description: The tags
+      - name: draftTags
+        type: array
+        items:
+          $ref: '#/schemas/TagsItem'
+        description: The tags
+  - name: TagsItem
+    properties:
+      - name: label
+        type: string
+`,
+			wantDiags: []string{
+				`inline object hoisted to schema "TagsItem"`,
+				`schema "Tags" is not an object or string enum; it is inlined wherever it is referenced`,
+			},
+		},
+		{
+			name: "free-form object and external reference",
+			schemas: `
+    Doc:
+      type: object
+      properties:
+        meta:
+          type: object
+        link:
+          $ref: 'other.yaml#/components/schemas/Link'`,
+			want: `  - name: Doc
+    properties:
+      - name: meta
+        type: object
+`,
+			wantDiags: []string{`unresolved or external reference "other.yaml#/components/schemas/Link" is not supported; ignored`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := "openapi: 3.1.0\ncomponents:\n  schemas:" + tt.schemas + "\n"
+			plugin, diags, err := FromOpenAPI("", []byte(doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := plugin.ToYaml()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "version: v1-draft\nexports: []\nschemas:\n" + tt.want
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("FromOpenAPI mismatch (-want +got):\n%v", diff)
+			}
+
+			var gotDiags []string
+			for _, d := range diags {
+				gotDiags = append(gotDiags, d.Message)
+			}
+			if diff := cmp.Diff(tt.wantDiags, gotDiags); diff != "" {
+				t.Errorf("FromOpenAPI diagnostics mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestFromOpenAPI_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "swagger 2",
+			doc:  "swagger: '2.0'\n",
+			want: "api.yaml: not an OpenAPI 3 document",
+		},
+		{
+			name: "no schemas",
+			doc:  "openapi: 3.0.0\npaths: {}\n",
+			want: "api.yaml: no components/schemas found",
+		},
+		{
+			name: "syntax error",
+			doc:  "openapi: 3.0.0\ncomponents: [\n",
+			want: "api.yaml:2: did not find expected node content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := FromOpenAPI("api.yaml", []byte(tt.doc))
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("FromOpenAPI error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position represents a location within a parsed schema.yaml.
+type Position struct {
+	Filename string // empty if the schema was parsed without a name
+	Line     int    // 1-based, or 0 if unknown
+	Column   int    // 1-based, or 0 if unknown
+}
+
+// IsValid reports whether the position is known.
+func (p Position) IsValid() bool { return p.Line > 0 }
+
+// String returns the position in one of these forms:
+//
+//	file:line:column
+//	file:line
+//	line:column
+//	line
+//	file
+//	-
+func (p Position) String() string {
+	s := p.Filename
+	if p.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += strconv.Itoa(p.Line)
+		if p.Column > 0 {
+			s += ":" + strconv.Itoa(p.Column)
+		}
+	}
+	if s == "" {
+		s = "-"
+	}
+	return s
+}
+
+// positionOf returns the position of the YAML node.
+// Its Filename is filled in by setFilename once decoding is complete.
+func positionOf(node *yaml.Node) Position {
+	return Position{Line: node.Line, Column: node.Column}
+}
+
+// setFilename records the filename in the Position of every decoded value.
+func (p *Plugin) setFilename(filename string) {
+	p.Filename = filename
+	if filename == "" {
+		return
+	}
+
+	var setProp func(prop *Property)
+	setProp = func(prop *Property) {
+		if prop == nil {
+			return
+		}
+		prop.Pos.Filename = filename
+		setProp(prop.Items)
+		setProp(prop.AdditionalProperties)
+	}
+	setInput := func(in *Input) {
+		if in != nil {
+			in.Pos.Filename = filename
+			setProp(in.Items)
+			setProp(in.AdditionalProperties)
+		}
+	}
+	setOutput := func(out *Output) {
+		if out != nil {
+			out.Pos.Filename = filename
+			setProp(out.Items)
+			setProp(out.AdditionalProperties)
+		}
+	}
+
+	for _, export := range p.Exports {
+		export.Pos.Filename = filename
+		setInput(export.Input)
+		setOutput(export.Output)
+	}
+	for _, imp := range p.Imports {
+		imp.Pos.Filename = filename
+		setInput(imp.Input)
+		setOutput(imp.Output)
+	}
+	for _, ct := range p.CustomTypes {
+		ct.Pos.Filename = filename
+		for _, prop := range ct.Properties {
+			setProp(prop)
+		}
+		for _, variant := range ct.OneOf {
+			setProp(variant)
+		}
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and records the position of the export.
+func (e *Export) UnmarshalYAML(node *yaml.Node) error {
+	type plain Export
+	if err := node.Decode((*plain)(e)); err != nil {
+		return err
+	}
+	e.Pos = positionOf(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and records the position of the import.
+func (i *Import) UnmarshalYAML(node *yaml.Node) error {
+	type plain Import
+	if err := node.Decode((*plain)(i)); err != nil {
+		return err
+	}
+	i.Pos = positionOf(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and records the position of the input.
+func (i *Input) UnmarshalYAML(node *yaml.Node) error {
+	type plain Input
+	if err := node.Decode((*plain)(i)); err != nil {
+		return err
+	}
+	i.Pos = positionOf(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and records the position of the output.
+func (o *Output) UnmarshalYAML(node *yaml.Node) error {
+	type plain Output
+	if err := node.Decode((*plain)(o)); err != nil {
+		return err
+	}
+	o.Pos = positionOf(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and records the position of the custom type.
+func (ct *CustomType) UnmarshalYAML(node *yaml.Node) error {
+	type plain CustomType
+	if err := node.Decode((*plain)(ct)); err != nil {
+		return err
+	}
+	ct.Pos = positionOf(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler and records the position of the property.
+func (p *Property) UnmarshalYAML(node *yaml.Node) error {
+	type plain Property
+	if err := node.Decode((*plain)(p)); err != nil {
+		return err
+	}
+	p.Pos = positionOf(node)
+	return nil
+}
+
+var yamlErrLineRE = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// positionedError wraps a yaml error, rewriting its "line N: ..." messages
+// as "filename:N: ..." so that they read like compiler errors.
+func positionedError(filename string, err error) error {
+	var msgs []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		msgs = typeErr.Errors
+	} else {
+		msgs = []string{err.Error()}
+	}
+
+	lines := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		m := yamlErrLineRE.FindStringSubmatch(msg)
+		if m == nil {
+			if filename != "" {
+				msg = filename + ": " + msg
+			}
+			lines = append(lines, msg)
+			continue
+		}
+		line, _ := strconv.Atoi(m[1])
+		pos := Position{Filename: filename, Line: line}
+		lines = append(lines, fmt.Sprintf("%v: %v", pos, m[2]))
+	}
+
+	return &yamlError{msg: strings.Join(lines, "\n"), err: err}
+}
+
+// yamlError is a yaml error with the messages rewritten by positionedError.
+type yamlError struct {
+	msg string
+	err error
+}
+
+func (e *yamlError) Error() string { return e.msg }
+
+func (e *yamlError) Unwrap() error { return e.err }
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPositionString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pos  Position
+		want string
+	}{
+		{pos: Position{}, want: "-"},
+		{pos: Position{Filename: "schema.yaml"}, want: "schema.yaml"},
+		{pos: Position{Line: 42}, want: "42"},
+		{pos: Position{Line: 42, Column: 7}, want: "42:7"},
+		{pos: Position{Filename: "schema.yaml", Line: 42}, want: "schema.yaml:42"},
+		{pos: Position{Filename: "schema.yaml", Line: 42, Column: 7}, want: "schema.yaml:42:7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.pos.String(); got != tt.want {
+				t.Errorf("String = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var positionsYaml = `version: v1-draft
+exports:
+  - name: processUser
+    input:
+      $ref: '#/schemas/User'
+    output:
+      type: array
+      items:
+        type: string
+imports:
+  - name: notify
+    input:
+      type: string
+schemas:
+  - name: User
+    properties:
+      - name: age
+        type: integer
+      - name: tags
+        type: object
+        additionalProperties:
+          type: string
+`
+
+func TestParseNamedStr_Positions(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseNamedStr("schema.yaml", positionsYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := plugin.Filename, "schema.yaml"; got != want {
+		t.Errorf("Filename = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		name string
+		pos  Position
+		want string
+	}{
+		{name: "export", pos: plugin.Exports[0].Pos, want: "schema.yaml:3:5"},
+		{name: "export input", pos: plugin.Exports[0].Input.Pos, want: "schema.yaml:5:7"},
+		{name: "export output", pos: plugin.Exports[0].Output.Pos, want: "schema.yaml:7:7"},
+		{name: "export output items", pos: plugin.Exports[0].Output.Items.Pos, want: "schema.yaml:9:9"},
+		{name: "import", pos: plugin.Imports[0].Pos, want: "schema.yaml:11:5"},
+		{name: "import input", pos: plugin.Imports[0].Input.Pos, want: "schema.yaml:13:7"},
+		{name: "schema", pos: plugin.CustomTypes[0].Pos, want: "schema.yaml:15:5"},
+		{name: "property", pos: plugin.CustomTypes[0].Properties[0].Pos, want: "schema.yaml:17:9"},
+		{name: "additionalProperties", pos: plugin.CustomTypes[0].Properties[1].AdditionalProperties.Pos, want: "schema.yaml:22:11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pos.String(); got != tt.want {
+				t.Errorf("Pos = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNamedStr_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yamlStr string
+		want    string
+	}{
+		{
+			name:    "syntax error",
+			yamlStr: "version: v1-draft\nexports:\n  - name: [\n",
+			want:    "schema.yaml:3: did not find expected node content",
+		},
+		{
+			name:    "type error",
+			yamlStr: "version: v1-draft\nexports: 5\n",
+			want:    "schema.yaml:2: cannot unmarshal !!int `5` into []*schema.Export",
+		},
+		{
+			name:    "unsupported version",
+			yamlStr: "# An XTP schema\nversion: v2\n",
+			want:    "schema.yaml:2:10: unsupported yaml version 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNamedStr("schema.yaml", tt.yamlStr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseNamedStr err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNamedStr_WrapsYAMLErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseNamedStr("schema.yaml", "version: v1-draft\nexports: 5\n")
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("ParseNamedStr err = %v, want a *yaml.TypeError", err)
+	}
+	if want := "line 2: cannot unmarshal !!int `5` into []*schema.Export"; typeErr.Errors[0] != want {
+		t.Errorf("TypeError.Errors[0] = %q, want %q", typeErr.Errors[0], want)
+	}
+}
+
+func TestValidate_Filename(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseNamedStr("schema.yaml", invalidYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `schema.yaml:5:13: exports[0].input.$ref: unresolved reference "#/schemas/Nope"`
+	if got := plugin.Validate()[0].Error(); got != want {
+		t.Errorf("Validate()[0] = %q, want %q", got, want)
+	}
+}
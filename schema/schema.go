@@ -4,7 +4,12 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -20,6 +25,11 @@ type Plugin struct {
 
 	// PkgName is used by the code generator.
 	PkgName string `yaml:"-"`
+	// Filename is the name of the parsed schema, used to report positions.
+	Filename string `yaml:"-"`
+
+	// node is the parsed YAML document, used to report positions.
+	node *yaml.Node
 }
 
 // Export represents an exported function by the XTP Extension Plugin.
@@ -29,22 +39,35 @@ type Export struct {
 	Input       *Input        `yaml:"input,omitempty"`
 	Output      *Output       `yaml:"output,omitempty"`
 	CodeSamples []*CodeSample `yaml:"codeSamples,omitempty"`
+
+	// Pos is the position of the export within the parsed YAML.
+	Pos Position `yaml:"-"`
 }
 
 // Input represents an input to the exported function.
 type Input struct {
-	Ref         string `yaml:"$ref,omitempty"`
-	Type        string `yaml:"type,omitempty"`
-	Description string `yaml:"description,omitempty"`
-	ContentType string `yaml:"contentType,omitempty"`
+	Ref                  string    `yaml:"$ref,omitempty"`
+	Type                 string    `yaml:"type,omitempty"`
+	Items                *Property `yaml:"items,omitempty"`
+	AdditionalProperties *Property `yaml:"additionalProperties,omitempty"`
+	Description          string    `yaml:"description,omitempty"`
+	ContentType          string    `yaml:"contentType,omitempty"`
+
+	// Pos is the position of the input within the parsed YAML.
+	Pos Position `yaml:"-"`
 }
 
 // Output represents an output from the exported function.
 type Output struct {
-	Ref         string `yaml:"$ref,omitempty"`
-	Type        string `yaml:"type,omitempty"`
-	Description string `yaml:"description,omitempty"`
-	ContentType string `yaml:"contentType,omitempty"`
+	Ref                  string    `yaml:"$ref,omitempty"`
+	Type                 string    `yaml:"type,omitempty"`
+	Items                *Property `yaml:"items,omitempty"`
+	AdditionalProperties *Property `yaml:"additionalProperties,omitempty"`
+	Description          string    `yaml:"description,omitempty"`
+	ContentType          string    `yaml:"contentType,omitempty"`
+
+	// Pos is the position of the output within the parsed YAML.
+	Pos Position `yaml:"-"`
 }
 
 // CodeSample represents a code sample for calling the function in a
@@ -61,6 +84,9 @@ type Import struct {
 	Description string  `yaml:"description,omitempty"`
 	Input       *Input  `yaml:"input,omitempty"`
 	Output      *Output `yaml:"output,omitempty"`
+
+	// Pos is the position of the import within the parsed YAML.
+	Pos Position `yaml:"-"`
 }
 
 // CustomType represents an XTP Extension Plugin custom datatype.
@@ -71,6 +97,39 @@ type CustomType struct {
 	Enum        []string    `yaml:"enum,omitempty"`
 	Required    []string    `yaml:"required,omitempty"`
 	Properties  []*Property `yaml:"properties,omitempty"`
+	// OneOf lists the variants of a discriminated union, each a `$ref`
+	// to a schema with properties, and Discriminator selects between them.
+	OneOf         []*Property    `yaml:"oneOf,omitempty"`
+	Discriminator *Discriminator `yaml:"discriminator,omitempty"`
+
+	// Pos is the position of the custom type within the parsed YAML.
+	Pos Position `yaml:"-"`
+}
+
+// Discriminator names the property whose value selects the variant of a
+// `oneOf` custom type. The property is not declared by the variants, but is
+// added to their JSON objects.
+type Discriminator struct {
+	PropertyName string `yaml:"propertyName"`
+	// Mapping maps each value of the property to the `$ref` of its variant.
+	// A variant that is not mapped is selected by the name of its schema.
+	Mapping map[string]string `yaml:"mapping,omitempty"`
+}
+
+// Value returns the value of the discriminator property that selects the
+// variant with the given `$ref`.
+func (d *Discriminator) Value(ref string) string {
+	keys := make([]string, 0, len(d.Mapping))
+	for key := range d.Mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if d.Mapping[key] == ref {
+			return key
+		}
+	}
+	return refName(ref)
 }
 
 // GetRequiredProps returns the required properties for this CustomType.
@@ -89,15 +148,24 @@ func (ct *CustomType) GetRequiredProps() []*Property {
 }
 
 // Property represents an argument to a plugin function.
+//
+// A Property is also used to describe the element type of an `array`
+// (its `Items`) and the value type of an `object` map (its
+// `AdditionalProperties`), in which case it has no Name and is always required.
 type Property struct {
-	Name        string   `yaml:"name"`
-	Ref         string   `yaml:"$ref,omitempty"`
-	Type        string   `yaml:"type,omitempty"`
-	Format      string   `yaml:"format,omitempty"`
-	Description string   `yaml:"description,omitempty"`
-	Maximum     *float64 `yaml:"maximum,omitempty"`
-	Minimum     *float64 `yaml:"minimum,omitempty"`
-	Default     *string  `yaml:"default,omitempty"`
+	Name                 string    `yaml:"name,omitempty"`
+	Ref                  string    `yaml:"$ref,omitempty"`
+	Type                 string    `yaml:"type,omitempty"`
+	Format               string    `yaml:"format,omitempty"`
+	Items                *Property `yaml:"items,omitempty"`
+	AdditionalProperties *Property `yaml:"additionalProperties,omitempty"`
+	Description          string    `yaml:"description,omitempty"`
+	Maximum              *float64  `yaml:"maximum,omitempty"`
+	Minimum              *float64  `yaml:"minimum,omitempty"`
+	Default              *string   `yaml:"default,omitempty"`
+
+	// Pos is the position of the property within the parsed YAML.
+	Pos Position `yaml:"-"`
 
 	// the following fields are only used by the code generator:
 	FirstEnumValue string      `yaml:"-"`
@@ -107,17 +175,51 @@ type Property struct {
 
 // ParseStr parses an XTP Extension Plugin schema yaml string and returns it.
 func ParseStr(yamlStr string) (*Plugin, error) {
-	m := versionRE.FindStringSubmatch(yamlStr)
-	if len(m) != 2 {
+	return ParseNamedStr("", yamlStr)
+}
+
+// ParseNamedStr parses an XTP Extension Plugin schema yaml string and returns it.
+// The name (typically a filename) prefixes the position of every error and
+// is recorded in the Position of each parsed value.
+func ParseNamedStr(name, yamlStr string) (*Plugin, error) {
+	m := versionRE.FindStringSubmatchIndex(yamlStr)
+	if m == nil {
+		if name != "" {
+			return nil, fmt.Errorf("%v: unable to find schema version", name)
+		}
 		return nil, errors.New("unable to find schema version")
 	}
 
-	switch m[1] {
+	var plugin *Plugin
+	var err error
+	switch version := yamlStr[m[2]:m[3]]; version {
 	case "0":
-		return ParseV0(yamlStr)
+		plugin, err = ParseV0(yamlStr)
 	case "1":
-		return ParseV1(yamlStr)
+		plugin, err = ParseV1(yamlStr)
 	default:
-		return nil, fmt.Errorf("unsupported yaml version %v", m[1])
+		// The position of the "v" of the version.
+		start := m[2] - 1
+		pos := Position{
+			Filename: name,
+			Line:     strings.Count(yamlStr[:start], "\n") + 1,
+			Column:   start - strings.LastIndex(yamlStr[:start], "\n"),
+		}
+		return nil, fmt.Errorf("%v: unsupported yaml version %v", pos, version)
+	}
+	if err != nil {
+		return nil, positionedError(name, err)
+	}
+
+	plugin.setFilename(name)
+	return plugin, nil
+}
+
+// ParseFile reads and parses an XTP Extension Plugin schema yaml file.
+func ParseFile(filename string) (*Plugin, error) {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
 	}
+	return ParseNamedStr(filename, string(buf))
 }
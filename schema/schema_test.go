@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 //go:embed testdata/fruit.yaml
@@ -16,6 +17,24 @@ var userYaml string
 //go:embed testdata/v0.yaml
 var v0Yaml string
 
+//go:embed testdata/arrays.yaml
+var arraysYaml string
+
+//go:embed testdata/maps.yaml
+var mapsYaml string
+
+//go:embed testdata/buffers.yaml
+var buffersYaml string
+
+//go:embed testdata/primitives.yaml
+var primitivesYaml string
+
+//go:embed testdata/trees.yaml
+var treesYaml string
+
+//go:embed testdata/unions.yaml
+var unionsYaml string
+
 func floatPtr(f float64) *float64 { return &f }
 
 func TestParseStr(t *testing.T) {
@@ -32,6 +51,100 @@ func TestParseStr(t *testing.T) {
 		ContentType: "application/json",
 	}
 
+	// Likewise, the "arrays" items refer to these custom types:
+	colorCustomType := &CustomType{
+		Name:        "Color",
+		Description: "A color",
+		Enum:        []string{"red", "green", "blue"},
+	}
+	pointCustomType := &CustomType{
+		Name:        "Point",
+		Description: "A point in 2D space",
+		Required:    []string{"x", "y"},
+		Properties: []*Property{
+			{Name: "x", Description: "The X coordinate", Type: "integer", IsRequired: true},
+			{Name: "y", Description: "The Y coordinate", Type: "integer", IsRequired: true},
+		},
+		ContentType: "application/json",
+	}
+	shapeCustomType := &CustomType{
+		Name:        "Shape",
+		Description: "A shape made of points",
+		Required:    []string{"name", "points"},
+		Properties: []*Property{
+			{Name: "name", Description: "The name of the shape", Type: "string", IsRequired: true},
+			{
+				Name:        "points",
+				Description: "The vertices of the shape",
+				Type:        "array",
+				Items:       &Property{Ref: "#/schemas/Point", IsRequired: true, RefCustomType: pointCustomType},
+				IsRequired:  true,
+			},
+			{
+				Name:        "colors",
+				Description: "The colors of the shape",
+				Type:        "array",
+				Items:       &Property{Ref: "#/schemas/Color", IsRequired: true, FirstEnumValue: "red"},
+			},
+			{
+				Name:  "tags",
+				Type:  "array",
+				Items: &Property{Type: "string", IsRequired: true},
+			},
+			{
+				Name:        "matrix",
+				Description: "A transformation matrix",
+				Type:        "array",
+				Items: &Property{
+					Type:       "array",
+					Items:      &Property{Type: "number", IsRequired: true},
+					IsRequired: true,
+				},
+			},
+		},
+		ContentType: "application/json",
+	}
+
+	// And the "maps" values refer to this custom type:
+	metricCustomType := &CustomType{
+		Name:        "Metric",
+		Description: "A named measurement with free-form labels",
+		Required:    []string{"name", "labels"},
+		Properties: []*Property{
+			{Name: "name", Description: "The name of the metric", Type: "string", IsRequired: true},
+			{
+				Name:                 "labels",
+				Description:          "The labels of the metric",
+				Type:                 "object",
+				AdditionalProperties: &Property{Type: "string", IsRequired: true},
+				IsRequired:           true,
+			},
+			{
+				Name:                 "thresholds",
+				Description:          "The severity of each threshold",
+				Type:                 "object",
+				AdditionalProperties: &Property{Ref: "#/schemas/Severity", IsRequired: true, FirstEnumValue: "low"},
+			},
+			{
+				Name:                 "counts",
+				Type:                 "object",
+				AdditionalProperties: &Property{Type: "integer", IsRequired: true},
+			},
+			{
+				Name:        "series",
+				Description: "The samples of each series",
+				Type:        "object",
+				AdditionalProperties: &Property{
+					Type:       "array",
+					Items:      &Property{Type: "number", IsRequired: true},
+					IsRequired: true,
+				},
+			},
+			{Name: "attributes", Description: "Arbitrary attributes", Type: "object"},
+		},
+		ContentType: "application/json",
+	}
+
 	tests := []struct {
 		name    string
 		yamlStr string
@@ -185,6 +298,179 @@ func TestParseStr(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "arrays",
+			yamlStr: arraysYaml,
+			want: &Plugin{
+				Version: "v1-draft",
+				Exports: []*Export{
+					{
+						Name:        "sortTags",
+						Description: "Sorts a list of tags.",
+						Input: &Input{
+							Type:        "array",
+							Items:       &Property{Type: "string", IsRequired: true},
+							ContentType: "application/json",
+						},
+						Output: &Output{
+							Type:        "array",
+							Items:       &Property{Type: "string", IsRequired: true},
+							ContentType: "application/json",
+						},
+					},
+					{
+						Name:        "shapesByColor",
+						Description: "Returns all the shapes having the given color.",
+						Input:       &Input{Ref: "#/schemas/Color"},
+						Output: &Output{
+							Type:        "array",
+							Items:       &Property{Ref: "#/schemas/Shape", IsRequired: true, RefCustomType: shapeCustomType},
+							ContentType: "application/json",
+						},
+					},
+				},
+				Imports: []*Import{
+					{
+						Name:        "lookupPoints",
+						Description: "Looks up the points with the given IDs.",
+						Input: &Input{
+							Type:        "array",
+							Items:       &Property{Type: "integer", IsRequired: true},
+							ContentType: "application/json",
+						},
+						Output: &Output{
+							Type:        "array",
+							Items:       &Property{Ref: "#/schemas/Point", IsRequired: true, RefCustomType: pointCustomType},
+							ContentType: "application/json",
+						},
+					},
+				},
+				CustomTypes: []*CustomType{
+					colorCustomType,
+					pointCustomType,
+					shapeCustomType,
+				},
+			},
+		},
+		{
+			name:    "maps",
+			yamlStr: mapsYaml,
+			want: &Plugin{
+				Version: "v1-draft",
+				Exports: []*Export{
+					{
+						Name:        "summarize",
+						Description: "Summarizes the metric by label.",
+						Input:       &Input{Ref: "#/schemas/Metric"},
+						Output: &Output{
+							Type:                 "object",
+							AdditionalProperties: &Property{Type: "number", IsRequired: true},
+							ContentType:          "application/json",
+						},
+					},
+				},
+				Imports: []*Import{
+					{
+						Name:        "lookupMetrics",
+						Description: "Looks up the metrics having the given labels.",
+						Input: &Input{
+							Type:                 "object",
+							AdditionalProperties: &Property{Type: "string", IsRequired: true},
+							ContentType:          "application/json",
+						},
+						Output: &Output{
+							Type:                 "object",
+							AdditionalProperties: &Property{Ref: "#/schemas/Metric", IsRequired: true, RefCustomType: metricCustomType},
+							ContentType:          "application/json",
+						},
+					},
+				},
+				CustomTypes: []*CustomType{
+					{
+						Name:        "Severity",
+						Description: "A severity level",
+						Enum:        []string{"low", "high"},
+					},
+					metricCustomType,
+				},
+			},
+		},
+		{
+			name:    "buffers",
+			yamlStr: buffersYaml,
+			want: &Plugin{
+				Version: "v1-draft",
+				Exports: []*Export{
+					{
+						Name:        "resizeImage",
+						Description: "Resizes the image to half its size.",
+						Input:       &Input{Type: "buffer", Description: "The raw image", ContentType: "application/x-binary"},
+						Output:      &Output{Type: "buffer", Description: "The resized image", ContentType: "application/x-binary"},
+					},
+					{
+						Name:        "describeImage",
+						Description: "Describes the image.",
+						Input:       &Input{Type: "buffer", ContentType: "application/x-binary"},
+						Output:      &Output{Ref: "#/schemas/ImageInfo"},
+					},
+					{
+						Name:        "renderThumbnail",
+						Description: "Renders the thumbnail as an image.",
+						Input:       &Input{Ref: "#/schemas/Thumbnail"},
+						Output:      &Output{Type: "buffer", ContentType: "application/x-binary"},
+					},
+				},
+				Imports: []*Import{
+					{
+						Name:        "storeImage",
+						Description: "Stores the image on the host.",
+						Input:       &Input{Type: "buffer", ContentType: "application/x-binary"},
+						Output:      &Output{Ref: "#/schemas/ImageInfo"},
+					},
+					{
+						Name:        "fetchImage",
+						Description: "Fetches the image from the host.",
+						Input:       &Input{Ref: "#/schemas/ImageInfo"},
+						Output:      &Output{Type: "buffer", ContentType: "application/x-binary"},
+					},
+				},
+				CustomTypes: []*CustomType{
+					{
+						Name:        "ImageFormat",
+						Description: "An image format",
+						Enum:        []string{"png", "jpeg"},
+					},
+					{
+						Name:        "Thumbnail",
+						ContentType: "application/json",
+						Description: "A small preview of an image",
+						Required:    []string{"data", "width"},
+						Properties: []*Property{
+							{Name: "data", Type: "buffer", Description: "The raw thumbnail", IsRequired: true},
+							{Name: "width", Type: "integer", Description: "The width in pixels", IsRequired: true},
+						},
+					},
+					{
+						Name:        "ImageInfo",
+						ContentType: "application/json",
+						Description: "Information about an image",
+						Required:    []string{"format", "width", "height"},
+						Properties: []*Property{
+							{
+								Name:           "format",
+								Ref:            "#/schemas/ImageFormat",
+								Description:    "The format of the image",
+								IsRequired:     true,
+								FirstEnumValue: "png",
+							},
+							{Name: "width", Type: "integer", Description: "The width in pixels", IsRequired: true},
+							{Name: "height", Type: "integer", Description: "The height in pixels", IsRequired: true},
+							{Name: "checksum", Type: "buffer", Description: "The SHA-256 checksum of the image"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:    "v0",
 			yamlStr: v0Yaml,
@@ -205,7 +491,7 @@ func TestParseStr(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(tt.want, got); diff != "" {
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(Plugin{}), cmpopts.IgnoreTypes(Position{})); diff != "" {
 				t.Errorf("ParseStr mismatch (-want +got):\n%v", diff)
 			}
 		})
@@ -225,6 +511,26 @@ func TestToYaml(t *testing.T) {
 			name:    "user",
 			yamlStr: userYaml,
 		},
+		{
+			name:    "arrays",
+			yamlStr: arraysYaml,
+		},
+		{
+			name:    "maps",
+			yamlStr: mapsYaml,
+		},
+		{
+			name:    "buffers",
+			yamlStr: buffersYaml,
+		},
+		{
+			name:    "primitives",
+			yamlStr: primitivesYaml,
+		},
+		{
+			name:    "trees",
+			yamlStr: treesYaml,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,213 @@
+package schema
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// JSONSchemaDialect is the `$schema` of the documents returned by ToJSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema represents the subset of a JSON Schema (draft 2020-12)
+// needed to describe an XTP Extension Plugin.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	ContentMediaType     string                 `json:"contentMediaType,omitempty"`
+	Const                string                 `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+
+	// Exports and Imports describe the functions of the plugin.
+	// They are not JSON Schema keywords, but the input and output schemas
+	// within them may be referenced by JSON Pointer,
+	// e.g. "#/exports/processUser/input".
+	Exports map[string]*JSONSchemaFunction `json:"exports,omitempty"`
+	Imports map[string]*JSONSchemaFunction `json:"imports,omitempty"`
+}
+
+// JSONSchemaFunction describes the input and output of an export or import.
+// A missing Input or Output means that the function takes or returns nothing.
+type JSONSchemaFunction struct {
+	Description string      `json:"description,omitempty"`
+	Input       *JSONSchema `json:"input,omitempty"`
+	Output      *JSONSchema `json:"output,omitempty"`
+}
+
+// JSONSchema returns the plugin as a JSON Schema document with one `$defs`
+// entry per custom type, which `$ref`s refer to as "#/$defs/<name>".
+//
+// Buffers are described as base64-encoded strings, as they are within JSON.
+// The input or output of a function that is not passed as JSON also has
+// the `contentMediaType` of its payload.
+func (p *Plugin) JSONSchema() *JSONSchema {
+	doc := &JSONSchema{Schema: JSONSchemaDialect}
+
+	if len(p.CustomTypes) > 0 {
+		doc.Defs = map[string]*JSONSchema{}
+		for _, ct := range p.CustomTypes {
+			doc.Defs[ct.Name] = customTypeJSONSchema(ct)
+		}
+	}
+
+	if len(p.Exports) > 0 {
+		doc.Exports = map[string]*JSONSchemaFunction{}
+		for _, export := range p.Exports {
+			doc.Exports[export.Name] = functionJSONSchema(export.Description, export.Input, export.Output)
+		}
+	}
+
+	if len(p.Imports) > 0 {
+		doc.Imports = map[string]*JSONSchemaFunction{}
+		for _, imp := range p.Imports {
+			doc.Imports[imp.Name] = functionJSONSchema(imp.Description, imp.Input, imp.Output)
+		}
+	}
+
+	return doc
+}
+
+// ToJSONSchema returns the plugin as an indented JSON Schema document.
+func (p *Plugin) ToJSONSchema() (string, error) {
+	buf, err := json.MarshalIndent(p.JSONSchema(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(buf) + "\n", nil
+}
+
+func customTypeJSONSchema(ct *CustomType) *JSONSchema {
+	if len(ct.Enum) > 0 {
+		return &JSONSchema{
+			Description: ct.Description,
+			Type:        "string",
+			Enum:        ct.Enum,
+		}
+	}
+
+	if len(ct.OneOf) > 0 {
+		return oneOfJSONSchema(ct)
+	}
+
+	s := &JSONSchema{
+		Description: ct.Description,
+		Type:        "object",
+		Properties:  map[string]*JSONSchema{},
+		Required:    ct.Required,
+	}
+	for _, prop := range ct.Properties {
+		s.Properties[prop.Name] = propertyJSONSchema(prop)
+	}
+	return s
+}
+
+// oneOfJSONSchema returns the JSON Schema of a `oneOf` custom type:
+// each variant is its referenced schema with the discriminator property
+// required to be the value that selects it.
+func oneOfJSONSchema(ct *CustomType) *JSONSchema {
+	s := &JSONSchema{Description: ct.Description}
+	for _, variant := range ct.OneOf {
+		vs := &JSONSchema{Ref: "#/$defs/" + refName(variant.Ref)}
+		if d := ct.Discriminator; d != nil {
+			vs.Properties = map[string]*JSONSchema{
+				d.PropertyName: {Type: "string", Const: d.Value(variant.Ref)},
+			}
+			vs.Required = []string{d.PropertyName}
+		}
+		s.OneOf = append(s.OneOf, vs)
+	}
+	return s
+}
+
+func functionJSONSchema(description string, input *Input, output *Output) *JSONSchemaFunction {
+	f := &JSONSchemaFunction{Description: description}
+	if input != nil {
+		f.Input = typeJSONSchema(input.Ref, input.Type, "", input.Items, input.AdditionalProperties)
+		f.Input.Description = input.Description
+		f.Input.ContentMediaType = payloadMediaType(input.Type, input.ContentType)
+	}
+	if output != nil {
+		f.Output = typeJSONSchema(output.Ref, output.Type, "", output.Items, output.AdditionalProperties)
+		f.Output.Description = output.Description
+		f.Output.ContentMediaType = payloadMediaType(output.Type, output.ContentType)
+	}
+	return f
+}
+
+func propertyJSONSchema(prop *Property) *JSONSchema {
+	s := typeJSONSchema(prop.Ref, prop.Type, prop.Format, prop.Items, prop.AdditionalProperties)
+	s.Description = prop.Description
+	s.Minimum = prop.Minimum
+	s.Maximum = prop.Maximum
+	if prop.Default != nil {
+		s.Default = jsonSchemaDefault(prop.Type, *prop.Default)
+	}
+	return s
+}
+
+// typeJSONSchema returns the JSON Schema of a `$ref` or `type`.
+func typeJSONSchema(ref, typ, format string, items, additionalProperties *Property) *JSONSchema {
+	if ref != "" {
+		return &JSONSchema{Ref: "#/$defs/" + refName(ref)}
+	}
+
+	switch typ {
+	case "array":
+		s := &JSONSchema{Type: "array"}
+		if items != nil {
+			s.Items = propertyJSONSchema(items)
+		}
+		return s
+	case "object":
+		s := &JSONSchema{Type: "object"}
+		if additionalProperties != nil {
+			s.AdditionalProperties = propertyJSONSchema(additionalProperties)
+		}
+		return s
+	case "buffer":
+		return &JSONSchema{Type: "string", ContentEncoding: "base64"}
+	default:
+		return &JSONSchema{Type: typ, Format: format}
+	}
+}
+
+// payloadMediaType returns the contentType of a function input or output
+// unless it is passed as JSON.
+func payloadMediaType(typ, contentType string) string {
+	if ct := contentTypeOf(typ, contentType); mediaType(ct) != "application/json" {
+		return ct
+	}
+	return ""
+}
+
+// jsonSchemaDefault returns the `default` value of a property as the JSON
+// value of its type, falling back to the string if it cannot be converted.
+func jsonSchemaDefault(typ, value string) any {
+	switch typ {
+	case "integer":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"embed"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+//go:embed testdata/*.schema.json
+var wantJSONSchemaFS embed.FS
+
+func TestToJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yamlStr string
+	}{
+		{name: "fruit", yamlStr: fruitYaml},
+		{name: "user", yamlStr: userYaml},
+		{name: "arrays", yamlStr: arraysYaml},
+		{name: "maps", yamlStr: mapsYaml},
+		{name: "buffers", yamlStr: buffersYaml},
+		{name: "primitives", yamlStr: primitivesYaml},
+		{name: "trees", yamlStr: treesYaml},
+		{name: "unions", yamlStr: unionsYaml},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := ParseStr(tt.yamlStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := plugin.ToJSONSchema()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, err := wantJSONSchemaFS.ReadFile("testdata/" + tt.name + ".schema.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(string(want), got); diff != "" {
+				t.Logf("got:\n%v", got)
+				t.Errorf("ToJSONSchema mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ   string
+		value string
+		want  any
+	}{
+		{typ: "integer", value: "42", want: int64(42)},
+		{typ: "number", value: "1.5", want: 1.5},
+		{typ: "boolean", value: "true", want: true},
+		{typ: "string", value: "hello", want: "hello"},
+		{typ: "integer", value: "many", want: "many"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ+"/"+tt.value, func(t *testing.T) {
+			if got := jsonSchemaDefault(tt.typ, tt.value); got != tt.want {
+				t.Errorf("jsonSchemaDefault = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayloadMediaType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ         string
+		contentType string
+		want        string
+	}{
+		{typ: "string", contentType: "", want: ""},
+		{typ: "string", contentType: "application/json", want: ""},
+		{typ: "string", contentType: "application/json; charset=utf-8", want: ""},
+		{typ: "string", contentType: "Application/JSON", want: ""},
+		{typ: "string", contentType: "text/plain; charset=utf-8", want: "text/plain; charset=utf-8"},
+		{typ: "buffer", contentType: "", want: "application/x-binary"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ+"/"+tt.contentType, func(t *testing.T) {
+			if got := payloadMediaType(tt.typ, tt.contentType); got != tt.want {
+				t.Errorf("payloadMediaType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,8 +7,12 @@ import (
 )
 
 func ParseV1(yamlStr string) (*Plugin, error) {
-	result := &Plugin{}
-	if err := yaml.Unmarshal([]byte(yamlStr), &result); err != nil {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlStr), &node); err != nil {
+		return nil, err
+	}
+	result := &Plugin{node: &node}
+	if err := node.Decode(result); err != nil {
 		return nil, err
 	}
 
@@ -25,6 +29,30 @@ func ParseV1(yamlStr string) (*Plugin, error) {
 		}
 	}
 
+	var linkProperty func(prop *Property)
+	// linkElement links the items of an array or the values of a map,
+	// which are always present.
+	linkElement := func(elem *Property) {
+		if elem != nil {
+			elem.IsRequired = true
+			linkProperty(elem)
+		}
+	}
+	linkProperty = func(prop *Property) {
+		if prop.Ref != "" {
+			parts := strings.Split(prop.Ref, "/")
+			refName := parts[len(parts)-1]
+			if p := isStruct[refName]; p != nil {
+				prop.RefCustomType = p
+			}
+			if v := firstEnumValue[refName]; v != "" {
+				prop.FirstEnumValue = v
+			}
+		}
+		linkElement(prop.Items)
+		linkElement(prop.AdditionalProperties)
+	}
+
 	for _, ct := range result.CustomTypes {
 		reqFields := map[string]bool{}
 		for _, req := range ct.Required {
@@ -34,16 +62,28 @@ func ParseV1(yamlStr string) (*Plugin, error) {
 			if reqFields[prop.Name] {
 				prop.IsRequired = true
 			}
-			if prop.Ref != "" {
-				parts := strings.Split(prop.Ref, "/")
-				refName := parts[len(parts)-1]
-				if p := isStruct[refName]; p != nil {
-					prop.RefCustomType = p
-				}
-				if v := firstEnumValue[refName]; v != "" {
-					prop.FirstEnumValue = v
-				}
-			}
+			linkProperty(prop)
+		}
+	}
+
+	for _, export := range result.Exports {
+		if export.Input != nil {
+			linkElement(export.Input.Items)
+			linkElement(export.Input.AdditionalProperties)
+		}
+		if export.Output != nil {
+			linkElement(export.Output.Items)
+			linkElement(export.Output.AdditionalProperties)
+		}
+	}
+	for _, imp := range result.Imports {
+		if imp.Input != nil {
+			linkElement(imp.Input.Items)
+			linkElement(imp.Input.AdditionalProperties)
+		}
+		if imp.Output != nil {
+			linkElement(imp.Output.Items)
+			linkElement(imp.Output.AdditionalProperties)
 		}
 	}
 
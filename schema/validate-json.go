@@ -0,0 +1,368 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueError represents a single way in which a JSON value violates a schema.
+type ValueError struct {
+	// Path locates the offending value within the JSON document,
+	// e.g. "$.address.street" or "$.tags[2]".
+	Path    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Path, e.Message)
+}
+
+// ValueErrors represents all the ways in which a JSON value violates a schema.
+type ValueErrors []*ValueError
+
+// Error implements the error interface with one error per line.
+func (es ValueErrors) Error() string {
+	lines := make([]string, 0, len(es))
+	for _, e := range es {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateJSON checks the JSON document against the named custom type.
+//
+// It returns ValueErrors if the document violates the schema, or another
+// error if the document is not JSON or the schema has no such type.
+// Properties that are not described by the schema are ignored,
+// just as they are by the generated code.
+func (p *Plugin) ValidateJSON(schemaName string, data []byte) error {
+	v := newValueValidator(p)
+	if v.schemas[schemaName] == nil {
+		return fmt.Errorf("schema %q not found", schemaName)
+	}
+	return v.validateDoc(data, "#/schemas/"+schemaName, "", "", nil, nil)
+}
+
+// ValidateExportInput checks the JSON document against the input of the
+// named export. See ValidateJSON.
+func (p *Plugin) ValidateExportInput(exportName string, data []byte) error {
+	export := p.findExport(exportName)
+	if export == nil {
+		return fmt.Errorf("export %q not found", exportName)
+	}
+	if export.Input == nil {
+		return fmt.Errorf("export %q has no input", exportName)
+	}
+	in := export.Input
+	if ct := contentTypeOf(in.Type, in.ContentType); mediaType(ct) != "application/json" {
+		return fmt.Errorf("input of export %q is %v, not JSON", exportName, ct)
+	}
+	return newValueValidator(p).validateDoc(data, in.Ref, in.Type, "", in.Items, in.AdditionalProperties)
+}
+
+// ValidateExportOutput checks the JSON document against the output of the
+// named export. See ValidateJSON.
+func (p *Plugin) ValidateExportOutput(exportName string, data []byte) error {
+	export := p.findExport(exportName)
+	if export == nil {
+		return fmt.Errorf("export %q not found", exportName)
+	}
+	if export.Output == nil {
+		return fmt.Errorf("export %q has no output", exportName)
+	}
+	out := export.Output
+	if ct := contentTypeOf(out.Type, out.ContentType); mediaType(ct) != "application/json" {
+		return fmt.Errorf("output of export %q is %v, not JSON", exportName, ct)
+	}
+	return newValueValidator(p).validateDoc(data, out.Ref, out.Type, "", out.Items, out.AdditionalProperties)
+}
+
+func (p *Plugin) findExport(name string) *Export {
+	for _, export := range p.Exports {
+		if export.Name == name {
+			return export
+		}
+	}
+	return nil
+}
+
+type valueValidator struct {
+	schemas map[string]*CustomType
+	errs    ValueErrors
+}
+
+func newValueValidator(p *Plugin) *valueValidator {
+	v := &valueValidator{schemas: map[string]*CustomType{}}
+	for _, ct := range p.CustomTypes {
+		v.schemas[ct.Name] = ct
+	}
+	return v
+}
+
+func (v *valueValidator) add(at, format string, args ...any) {
+	v.errs = append(v.errs, &ValueError{Path: at, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateDoc decodes the JSON document and checks it against the type.
+func (v *valueValidator) validateDoc(data []byte, ref, typ, format string, items, additionalProperties *Property) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid JSON: unexpected data after top-level value")
+	}
+
+	v.validateType("$", value, ref, typ, format, items, additionalProperties)
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// validateType checks the value against a `$ref` or `type`.
+func (v *valueValidator) validateType(at string, value any, ref, typ, format string, items, additionalProperties *Property) {
+	if ref != "" {
+		ct := v.schemas[refName(ref)]
+		if ct == nil {
+			v.add(at, "unresolved reference %q", ref)
+			return
+		}
+		v.validateCustomType(at, value, ct)
+		return
+	}
+
+	switch typ {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			v.add(at, "expected string, got %v", jsonKind(value))
+			return
+		}
+		switch format {
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				v.add(at, "invalid date-time %q", s)
+			}
+		case "byte":
+			if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+				v.add(at, "invalid base64: %v", err)
+			}
+		}
+	case "buffer":
+		s, ok := value.(string)
+		if !ok {
+			v.add(at, "expected base64-encoded string, got %v", jsonKind(value))
+			return
+		}
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			v.add(at, "invalid base64: %v", err)
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			v.add(at, "expected integer, got %v", jsonKind(value))
+			return
+		}
+		bitSize := 64
+		if format == "int32" {
+			bitSize = 32
+		}
+		if _, err := strconv.ParseInt(n.String(), 10, bitSize); err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				v.add(at, "%v out of range for int%v", n, bitSize)
+			} else {
+				v.add(at, "expected integer, got %v", n)
+			}
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			v.add(at, "expected number, got %v", jsonKind(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.add(at, "expected boolean, got %v", jsonKind(value))
+		}
+	case "array":
+		elems, ok := value.([]any)
+		if !ok {
+			v.add(at, "expected array, got %v", jsonKind(value))
+			return
+		}
+		if items == nil {
+			return
+		}
+		for i, elem := range elems {
+			v.validateProperty(fmt.Sprintf("%v[%v]", at, i), elem, items)
+		}
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.add(at, "expected object, got %v", jsonKind(value))
+			return
+		}
+		if additionalProperties == nil {
+			return
+		}
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			v.validateProperty(jsonPath(at, key), obj[key], additionalProperties)
+		}
+	}
+}
+
+// validateProperty checks a non-null value against the property,
+// including its `minimum` and `maximum`.
+func (v *valueValidator) validateProperty(at string, value any, prop *Property) {
+	if value == nil {
+		v.add(at, "must not be null")
+		return
+	}
+
+	before := len(v.errs)
+	v.validateType(at, value, prop.Ref, prop.Type, prop.Format, prop.Items, prop.AdditionalProperties)
+	if len(v.errs) > before {
+		return
+	}
+
+	n, ok := value.(json.Number)
+	if !ok {
+		return
+	}
+	f, err := n.Float64()
+	if err != nil || math.IsInf(f, 0) {
+		return
+	}
+	if prop.Minimum != nil && f < *prop.Minimum {
+		v.add(at, "%v is less than minimum %v", n, *prop.Minimum)
+	}
+	if prop.Maximum != nil && f > *prop.Maximum {
+		v.add(at, "%v is greater than maximum %v", n, *prop.Maximum)
+	}
+}
+
+func (v *valueValidator) validateCustomType(at string, value any, ct *CustomType) {
+	if len(ct.Enum) > 0 {
+		s, ok := value.(string)
+		if !ok {
+			v.add(at, "expected string, got %v", jsonKind(value))
+			return
+		}
+		for _, e := range ct.Enum {
+			if s == e {
+				return
+			}
+		}
+		v.add(at, "%q is not one of %q", s, ct.Enum)
+		return
+	}
+
+	obj, ok := value.(map[string]any)
+	if !ok {
+		v.add(at, "expected object, got %v", jsonKind(value))
+		return
+	}
+
+	if len(ct.OneOf) > 0 {
+		v.validateOneOf(at, obj, ct)
+		return
+	}
+
+	required := map[string]bool{}
+	for _, name := range ct.Required {
+		required[name] = true
+	}
+	for _, prop := range ct.Properties {
+		propAt := jsonPath(at, prop.Name)
+		value, ok := obj[prop.Name]
+		switch {
+		case !ok && required[prop.Name]:
+			v.add(propAt, "required")
+		case !ok, value == nil && !required[prop.Name]:
+			// A missing or null optional property is valid.
+		default:
+			v.validateProperty(propAt, value, prop)
+		}
+	}
+}
+
+// validateOneOf checks the object against the variant of the `oneOf`
+// custom type that is selected by its discriminator property.
+func (v *valueValidator) validateOneOf(at string, obj map[string]any, ct *CustomType) {
+	d := ct.Discriminator
+	if d == nil {
+		v.add(at, "schema %q has no discriminator", ct.Name)
+		return
+	}
+
+	propAt := jsonPath(at, d.PropertyName)
+	value, ok := obj[d.PropertyName]
+	if !ok {
+		v.add(propAt, "required")
+		return
+	}
+	s, ok := value.(string)
+	if !ok {
+		v.add(propAt, "expected string, got %v", jsonKind(value))
+		return
+	}
+
+	values := make([]string, 0, len(ct.OneOf))
+	for _, variant := range ct.OneOf {
+		if d.Value(variant.Ref) != s {
+			values = append(values, d.Value(variant.Ref))
+			continue
+		}
+		vt := v.schemas[refName(variant.Ref)]
+		if vt == nil {
+			v.add(at, "unresolved reference %q", variant.Ref)
+			return
+		}
+		v.validateCustomType(at, obj, vt)
+		return
+	}
+	v.add(propAt, "%q is not one of %q", s, values)
+}
+
+// jsonPath returns the path of the key within the object at path.
+func jsonPath(path, key string) string {
+	if identifierRE.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%v[%q]", path, key)
+}
+
+// jsonKind returns the JSON kind of a decoded value for error messages.
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
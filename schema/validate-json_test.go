@@ -0,0 +1,297 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var validateJSONYaml = `version: v1-draft
+exports:
+  - name: register
+    input:
+      $ref: '#/schemas/Person'
+    output:
+      type: array
+      items:
+        type: integer
+        minimum: 1
+  - name: greet
+    input:
+      type: string
+      contentType: text/plain; charset=UTF-8
+  - name: shout
+    input:
+      type: string
+      contentType: application/json; charset=utf-8
+    output:
+      $ref: '#/schemas/Color'
+      contentType: Application/JSON
+schemas:
+  - name: Color
+    enum:
+      - red
+      - green
+  - name: Address
+    required:
+      - street
+    properties:
+      - name: street
+        type: string
+      - name: zip
+        type: integer
+        format: int32
+  - name: Person
+    required:
+      - name
+      - age
+      - address
+    properties:
+      - name: name
+        type: string
+      - name: age
+        type: integer
+        minimum: 0
+        maximum: 150
+      - name: height
+        type: number
+        maximum: 2.5
+      - name: address
+        $ref: '#/schemas/Address'
+      - name: color
+        $ref: '#/schemas/Color'
+      - name: born
+        type: string
+        format: date-time
+      - name: photo
+        type: buffer
+      - name: thumbnail
+        type: string
+        format: byte
+      - name: tags
+        type: array
+        items:
+          type: string
+      - name: scores
+        type: object
+        additionalProperties:
+          type: boolean
+      - name: extra
+        type: object
+`
+
+func TestValidateJSON(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(validateJSONYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{
+			name: "valid",
+			json: `{"name":"Ann","age":30,"height":1.7,"address":{"street":"Main","zip":12345},"color":"red",
+				"born":"2000-01-02T03:04:05Z","photo":"aGk=","thumbnail":"aGk=","tags":["a","b"],"scores":{"x":true},"extra":{"any":[1]},"unknown":1}`,
+		},
+		{
+			name: "null optional properties",
+			json: `{"name":"Ann","age":0,"address":{"street":""},"color":null,"tags":null}`,
+		},
+		{
+			name: "missing required",
+			json: `{"address":{}}`,
+			want: []string{
+				"$.name: required",
+				"$.age: required",
+				"$.address.street: required",
+			},
+		},
+		{
+			name: "null required",
+			json: `{"name":null,"age":1,"address":null}`,
+			want: []string{
+				"$.name: must not be null",
+				"$.address: must not be null",
+			},
+		},
+		{
+			name: "out of bounds",
+			json: `{"name":"Ann","age":151,"height":2.6,"address":{"street":"Main","zip":2147483648}}`,
+			want: []string{
+				"$.age: 151 is greater than maximum 150",
+				"$.height: 2.6 is greater than maximum 2.5",
+				"$.address.zip: 2147483648 out of range for int32",
+			},
+		},
+		{
+			name: "below minimum",
+			json: `{"name":"Ann","age":-1,"address":{"street":"Main"}}`,
+			want: []string{"$.age: -1 is less than minimum 0"},
+		},
+		{
+			name: "wrong types",
+			json: `{"name":1,"age":1.5,"address":"Main","color":"blue","born":"yesterday","photo":"!","thumbnail":"?",
+				"tags":["a",2],"scores":{"b":true,"a":"yes","not ok":1},"extra":[]}`,
+			want: []string{
+				"$.name: expected string, got number",
+				"$.age: expected integer, got 1.5",
+				"$.address: expected object, got string",
+				`$.color: "blue" is not one of ["red" "green"]`,
+				`$.born: invalid date-time "yesterday"`,
+				"$.photo: invalid base64: illegal base64 data at input byte 0",
+				"$.thumbnail: invalid base64: illegal base64 data at input byte 0",
+				"$.tags[1]: expected string, got number",
+				"$.scores.a: expected boolean, got string",
+				`$.scores["not ok"]: expected boolean, got number`,
+				"$.extra: expected object, got array",
+			},
+		},
+		{
+			name: "not an object",
+			json: `[]`,
+			want: []string{"$: expected object, got array"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := plugin.ValidateJSON("Person", []byte(tt.json))
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateJSON = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValueErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("ValidateJSON = %v, want ValueErrors", err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateJSON mismatch (-want +got):\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestValidateJSON_Fruit(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(fruitYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = plugin.ValidateExportOutput("referenceTypeFunc", []byte(`{"aBoolean":true,"aString":"s","anInt":1}`))
+	if want := "$.ghost: required"; err == nil || err.Error() != want {
+		t.Errorf("ValidateExportOutput = %v, want %v", err, want)
+	}
+
+	if err := plugin.ValidateExportInput("referenceTypeFunc", []byte(`"banana"`)); err != nil {
+		t.Errorf("ValidateExportInput = %v, want nil", err)
+	}
+	if err := plugin.ValidateJSON("Fruit", []byte(`"kiwi"`)); err == nil {
+		t.Error("ValidateJSON(Fruit) = nil, want error")
+	}
+}
+
+func TestValidateJSON_OneOf(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(unionsYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{name: "mapped variant", json: `{"type":"click","x":1,"y":2,"button":"left"}`},
+		{name: "unmapped variant", json: `{"type":"Scroll","delta":1.5}`},
+		{name: "invalid variant", json: `{"type":"keyPress","repeat":0}`, want: "$.key: required\n$.repeat: 0 is less than minimum 1"},
+		{name: "missing discriminator", json: `{"x":1,"y":2,"button":"left"}`, want: "$.type: required"},
+		{name: "wrong discriminator type", json: `{"type":1}`, want: "$.type: expected string, got number"},
+		{name: "unknown discriminator", json: `{"type":"Click"}`, want: `$.type: "Click" is not one of ["click" "keyPress" "Scroll"]`},
+		{name: "not an object", json: `"click"`, want: "$: expected object, got string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := plugin.ValidateExportInput("handleEvent", []byte(tt.json))
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateExportInput = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("ValidateExportInput = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateExport(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(validateJSONYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := plugin.ValidateExportOutput("register", []byte(`[1, 2]`)); err != nil {
+		t.Errorf("ValidateExportOutput = %v, want nil", err)
+	}
+	if err := plugin.ValidateExportOutput("register", []byte(`[1, 0]`)); err == nil || err.Error() != "$[1]: 0 is less than minimum 1" {
+		t.Errorf("ValidateExportOutput = %v, want $[1]: 0 is less than minimum 1", err)
+	}
+	if err := plugin.ValidateExportInput("register", []byte(`{"name":"Ann","age":3,"address":{"street":"Main"}}`)); err != nil {
+		t.Errorf("ValidateExportInput = %v, want nil", err)
+	}
+	if err := plugin.ValidateExportInput("shout", []byte(`"hi"`)); err != nil {
+		t.Errorf("ValidateExportInput = %v, want nil", err)
+	}
+	if err := plugin.ValidateExportOutput("shout", []byte(`"blue"`)); err == nil || err.Error() != `$: "blue" is not one of ["red" "green"]` {
+		t.Errorf("ValidateExportOutput = %v, want $: \"blue\" is not one of [\"red\" \"green\"]", err)
+	}
+
+	tests := []struct {
+		name string
+		fn   func(string, []byte) error
+		arg  string
+		json string
+		want string
+	}{
+		{name: "unknown export", fn: plugin.ValidateExportInput, arg: "nope", json: `{}`, want: `export "nope" not found`},
+		{name: "no output", fn: plugin.ValidateExportOutput, arg: "greet", json: `{}`, want: `export "greet" has no output`},
+		{name: "not JSON content", fn: plugin.ValidateExportInput, arg: "greet", json: `"hi"`, want: `input of export "greet" is text/plain; charset=UTF-8, not JSON`},
+		{name: "unknown schema", fn: plugin.ValidateJSON, arg: "Nope", json: `{}`, want: `schema "Nope" not found`},
+		{name: "invalid JSON", fn: plugin.ValidateJSON, arg: "Color", json: `{`, want: "invalid JSON: unexpected EOF"},
+		{name: "trailing data", fn: plugin.ValidateJSON, arg: "Color", json: `"red" "green"`, want: "invalid JSON: unexpected data after top-level value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn(tt.arg, []byte(tt.json))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+			var errs ValueErrors
+			if errors.As(err, &errs) {
+				t.Errorf("got ValueErrors, want another error")
+			}
+		})
+	}
+}
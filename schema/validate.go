@@ -0,0 +1,449 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DiagnosticKind identifies the kind of problem reported by a Diagnostic.
+type DiagnosticKind string
+
+const (
+	// UnresolvedRef is reported for a `$ref` that does not name a schema.
+	UnresolvedRef DiagnosticKind = "unresolved-ref"
+	// Duplicate is reported for a repeated export, import, schema,
+	// property, enum value or required entry.
+	Duplicate DiagnosticKind = "duplicate"
+	// UnknownType is reported for a missing or unsupported `type`.
+	UnknownType DiagnosticKind = "unknown-type"
+	// UnknownFormat is reported for a `format` that is not supported by its `type`.
+	UnknownFormat DiagnosticKind = "unknown-format"
+	// UnknownProperty is reported for a `required` entry that names no property.
+	UnknownProperty DiagnosticKind = "unknown-property"
+	// InvalidIdentifier is reported for a name that cannot be used as an
+	// identifier in generated code.
+	InvalidIdentifier DiagnosticKind = "invalid-identifier"
+	// EmptyEnum is reported for a schema with neither enum values nor properties.
+	EmptyEnum DiagnosticKind = "empty-enum"
+	// CyclicRef is reported for a cycle of required references, see RefCycle.
+	CyclicRef DiagnosticKind = "cyclic-ref"
+	// InvalidOneOf is reported for a `oneOf` schema without a discriminator,
+	// or whose variants cannot be told apart by it.
+	InvalidOneOf DiagnosticKind = "invalid-one-of"
+)
+
+// Diagnostic represents a single problem found by Validate.
+type Diagnostic struct {
+	Kind DiagnosticKind
+	// Path locates the offending value within the schema,
+	// e.g. "exports[2].input.$ref".
+	Path    string
+	Message string
+	// Pos is the position of the offending value within the parsed YAML,
+	// or the zero Position if the Plugin was not parsed from YAML.
+	Pos Position
+}
+
+// Error implements the error interface.
+func (d *Diagnostic) Error() string {
+	if d.Pos.IsValid() || d.Pos.Filename != "" {
+		return fmt.Sprintf("%v: %v: %v", d.Pos, d.Path, d.Message)
+	}
+	return fmt.Sprintf("%v: %v", d.Path, d.Message)
+}
+
+// Diagnostics represents all the problems found by Validate.
+type Diagnostics []*Diagnostic
+
+// Error implements the error interface with one diagnostic per line.
+func (ds Diagnostics) Error() string {
+	lines := make([]string, 0, len(ds))
+	for _, d := range ds {
+		lines = append(lines, d.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	identifierRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	knownTypes = map[string]bool{
+		"string":  true,
+		"integer": true,
+		"number":  true,
+		"boolean": true,
+		"object":  true,
+		"array":   true,
+		"buffer":  true,
+	}
+
+	knownFormats = map[string]map[string]bool{
+		"string":  {"byte": true, "date-time": true},
+		"integer": {"int32": true, "int64": true},
+		"number":  {"float": true, "double": true},
+	}
+)
+
+// Validate checks the plugin for problems that would prevent code from being
+// generated and returns them in the order they appear in the YAML.
+// It returns nil if no problems are found.
+func (p *Plugin) Validate() Diagnostics {
+	v := &validator{plugin: p, schemas: map[string]*CustomType{}}
+
+	for i, ct := range p.CustomTypes {
+		if _, ok := v.schemas[ct.Name]; !ok {
+			v.schemas[ct.Name] = ct
+		} else {
+			v.add(Duplicate, path{"schemas", i, "name"}, "duplicate schema %q", ct.Name)
+		}
+	}
+
+	exports := map[string]bool{}
+	for i, export := range p.Exports {
+		v.checkName(path{"exports", i}, "export", export.Name, exports)
+		if export.Input != nil {
+			v.checkInOut(path{"exports", i, "input"}, export.Input.Ref, export.Input.Type, export.Input.Items, export.Input.AdditionalProperties)
+		}
+		if export.Output != nil {
+			v.checkInOut(path{"exports", i, "output"}, export.Output.Ref, export.Output.Type, export.Output.Items, export.Output.AdditionalProperties)
+		}
+	}
+
+	imports := map[string]bool{}
+	for i, imp := range p.Imports {
+		v.checkName(path{"imports", i}, "import", imp.Name, imports)
+		if imp.Input != nil {
+			v.checkInOut(path{"imports", i, "input"}, imp.Input.Ref, imp.Input.Type, imp.Input.Items, imp.Input.AdditionalProperties)
+		}
+		if imp.Output != nil {
+			v.checkInOut(path{"imports", i, "output"}, imp.Output.Ref, imp.Output.Type, imp.Output.Items, imp.Output.AdditionalProperties)
+		}
+	}
+
+	for i, ct := range p.CustomTypes {
+		v.checkCustomType(path{"schemas", i}, ct)
+	}
+	v.checkCycles()
+
+	if len(v.diags) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(v.diags, func(i, j int) bool {
+		a, b := v.diags[i].Pos, v.diags[j].Pos
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return v.diags
+}
+
+// path locates a value within the schema as a sequence of
+// mapping keys (string) and sequence indices (int).
+type path []any
+
+func (p path) String() string {
+	var sb strings.Builder
+	for _, elem := range p {
+		switch e := elem.(type) {
+		case int:
+			fmt.Fprintf(&sb, "[%v]", e)
+		case string:
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(e)
+		}
+	}
+	return sb.String()
+}
+
+func (p path) with(elems ...any) path {
+	return append(append(path{}, p...), elems...)
+}
+
+type validator struct {
+	plugin  *Plugin
+	schemas map[string]*CustomType
+	diags   Diagnostics
+}
+
+func (v *validator) add(kind DiagnosticKind, at path, format string, args ...any) {
+	v.diags = append(v.diags, &Diagnostic{
+		Kind:    kind,
+		Path:    at.String(),
+		Message: fmt.Sprintf(format, args...),
+		Pos:     v.plugin.position(at),
+	})
+}
+
+// checkName checks the name of an export or import.
+func (v *validator) checkName(at path, kind, name string, seen map[string]bool) {
+	if !identifierRE.MatchString(name) {
+		v.add(InvalidIdentifier, at.with("name"), "invalid %v name %q", kind, name)
+	}
+	if seen[name] {
+		v.add(Duplicate, at.with("name"), "duplicate %v %q", kind, name)
+	}
+	seen[name] = true
+}
+
+func (v *validator) checkInOut(at path, ref, typ string, items, additionalProperties *Property) {
+	if ref != "" {
+		v.checkRef(at.with("$ref"), ref)
+		return
+	}
+	v.checkType(at, typ, items, additionalProperties)
+}
+
+func (v *validator) checkRef(at path, ref string) {
+	name, ok := strings.CutPrefix(ref, "#/schemas/")
+	if !ok || v.schemas[name] == nil {
+		v.add(UnresolvedRef, at, "unresolved reference %q", ref)
+	}
+}
+
+func (v *validator) checkType(at path, typ string, items, additionalProperties *Property) {
+	switch {
+	case typ == "":
+		v.add(UnknownType, at, "missing type or $ref")
+		return
+	case !knownTypes[typ]:
+		v.add(UnknownType, at.with("type"), "unknown type %q", typ)
+		return
+	}
+
+	if typ == "array" && items != nil {
+		v.checkProperty(at.with("items"), items)
+	}
+	if typ == "object" && additionalProperties != nil {
+		v.checkProperty(at.with("additionalProperties"), additionalProperties)
+	}
+}
+
+func (v *validator) checkProperty(at path, prop *Property) {
+	if prop.Ref != "" {
+		v.checkRef(at.with("$ref"), prop.Ref)
+		return
+	}
+
+	v.checkType(at, prop.Type, prop.Items, prop.AdditionalProperties)
+	if prop.Format != "" && knownTypes[prop.Type] && !knownFormats[prop.Type][prop.Format] {
+		v.add(UnknownFormat, at.with("format"), "unknown format %q for type %q", prop.Format, prop.Type)
+	}
+}
+
+func (v *validator) checkCustomType(at path, ct *CustomType) {
+	if !identifierRE.MatchString(ct.Name) {
+		v.add(InvalidIdentifier, at.with("name"), "invalid schema name %q", ct.Name)
+	}
+
+	if len(ct.OneOf) > 0 {
+		v.checkOneOf(at, ct)
+		return
+	}
+
+	if len(ct.Enum) == 0 && len(ct.Properties) == 0 {
+		v.add(EmptyEnum, at, "schema %q must have enum values or properties", ct.Name)
+		return
+	}
+
+	values := map[string]bool{}
+	for i, value := range ct.Enum {
+		if !identifierRE.MatchString(value) {
+			v.add(InvalidIdentifier, at.with("enum", i), "invalid enum value %q", value)
+		}
+		if values[value] {
+			v.add(Duplicate, at.with("enum", i), "duplicate enum value %q", value)
+		}
+		values[value] = true
+	}
+
+	props := map[string]bool{}
+	for i, prop := range ct.Properties {
+		propAt := at.with("properties", i)
+		if !identifierRE.MatchString(prop.Name) {
+			v.add(InvalidIdentifier, propAt.with("name"), "invalid property name %q", prop.Name)
+		}
+		if props[prop.Name] {
+			v.add(Duplicate, propAt.with("name"), "duplicate property %q", prop.Name)
+		}
+		props[prop.Name] = true
+		v.checkProperty(propAt, prop)
+	}
+
+	required := map[string]bool{}
+	for i, name := range ct.Required {
+		if !props[name] {
+			v.add(UnknownProperty, at.with("required", i), "required property %q is not defined", name)
+		}
+		if required[name] {
+			v.add(Duplicate, at.with("required", i), "duplicate required property %q", name)
+		}
+		required[name] = true
+	}
+}
+
+// checkOneOf checks that every variant of the `oneOf` schema is a schema
+// with properties that is selected by a distinct discriminator value.
+func (v *validator) checkOneOf(at path, ct *CustomType) {
+	if len(ct.Enum) > 0 || len(ct.Properties) > 0 {
+		v.add(InvalidOneOf, at.with("oneOf"), "oneOf schema %q must not have enum values or properties", ct.Name)
+	}
+
+	d := ct.Discriminator
+	switch {
+	case d == nil:
+		v.add(InvalidOneOf, at.with("oneOf"), "oneOf schema %q must have a discriminator", ct.Name)
+	case !identifierRE.MatchString(d.PropertyName):
+		v.add(InvalidIdentifier, at.with("discriminator", "propertyName"), "invalid discriminator property name %q", d.PropertyName)
+	}
+
+	refs := map[string]bool{}
+	values := map[string]bool{}
+	for i, variant := range ct.OneOf {
+		variantAt := at.with("oneOf", i)
+		if variant.Ref == "" {
+			v.add(InvalidOneOf, variantAt, "oneOf variant must be a $ref")
+			continue
+		}
+		if refs[variant.Ref] {
+			v.add(Duplicate, variantAt.with("$ref"), "duplicate oneOf variant %q", variant.Ref)
+			continue
+		}
+		refs[variant.Ref] = true
+
+		name, ok := strings.CutPrefix(variant.Ref, "#/schemas/")
+		vt := v.schemas[name]
+		switch {
+		case !ok || vt == nil:
+			v.add(UnresolvedRef, variantAt.with("$ref"), "unresolved reference %q", variant.Ref)
+			continue
+		case len(vt.Properties) == 0:
+			v.add(InvalidOneOf, variantAt.with("$ref"), "oneOf variant %q must be a schema with properties", name)
+			continue
+		case d == nil:
+			continue
+		}
+
+		for _, prop := range vt.Properties {
+			if prop.Name == d.PropertyName {
+				v.add(InvalidOneOf, variantAt.with("$ref"), "oneOf variant %q must not declare the discriminator property %q", name, d.PropertyName)
+			}
+		}
+		value := d.Value(variant.Ref)
+		if values[value] {
+			v.add(Duplicate, variantAt.with("$ref"), "duplicate discriminator value %q", value)
+		}
+		values[value] = true
+	}
+
+	if d == nil {
+		return
+	}
+	keys := make([]string, 0, len(d.Mapping))
+	for key := range d.Mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if ref := d.Mapping[key]; !refs[ref] {
+			v.add(InvalidOneOf, at.with("discriminator", "mapping", key), "discriminator value %q maps to %q, which is not a oneOf variant", key, ref)
+		} else if d.Value(ref) != key {
+			v.add(Duplicate, at.with("discriminator", "mapping", key), "duplicate discriminator mapping to %q", ref)
+		}
+	}
+}
+
+// checkCycles reports each cycle of required references once,
+// at the first property of the cycle declared by its earliest schema.
+func (v *validator) checkCycles() {
+	index := map[*CustomType]int{}
+	for i, ct := range v.plugin.CustomTypes {
+		if _, ok := index[ct]; !ok {
+			index[ct] = i
+		}
+	}
+
+	resolve := func(prop *Property) *CustomType {
+		name, ok := strings.CutPrefix(prop.Ref, "#/schemas/")
+		if !ok {
+			return nil
+		}
+		return v.schemas[name]
+	}
+
+	for i, ct := range v.plugin.CustomTypes {
+		cycle := findRequiredRefCycle(ct, resolve)
+		if cycle == nil {
+			continue
+		}
+
+		earliest := true
+		for _, prop := range cycle {
+			if index[resolve(prop)] < i {
+				earliest = false
+				break
+			}
+		}
+		if !earliest {
+			continue
+		}
+
+		for j, prop := range ct.Properties {
+			if prop == cycle[0] {
+				v.add(CyclicRef, path{"schemas", i, "properties", j, "$ref"},
+					"required reference cycle %v; make one of these properties optional", cycle)
+				break
+			}
+		}
+	}
+}
+
+// position returns the position of the YAML value at the given path,
+// or of its closest ancestor that exists. It returns the zero Position
+// if the plugin was not parsed from YAML.
+func (p *Plugin) position(at path) Position {
+	if p.node == nil {
+		return Position{}
+	}
+
+	node := p.node
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, elem := range at {
+		next := childNode(node, elem)
+		if next == nil {
+			break
+		}
+		node = next
+	}
+
+	return Position{Filename: p.Filename, Line: node.Line, Column: node.Column}
+}
+
+// childNode returns the mapping value for a string key or the sequence item
+// for an int index, or nil if not found.
+func childNode(node *yaml.Node, elem any) *yaml.Node {
+	switch e := elem.(type) {
+	case string:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == e {
+				return node.Content[i+1]
+			}
+		}
+	case int:
+		if node.Kind != yaml.SequenceNode || e >= len(node.Content) {
+			return nil
+		}
+		return node.Content[e]
+	}
+	return nil
+}
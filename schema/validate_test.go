@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidate_Valid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yamlStr string
+	}{
+		{name: "fruit", yamlStr: fruitYaml},
+		{name: "user", yamlStr: userYaml},
+		{name: "arrays", yamlStr: arraysYaml},
+		{name: "maps", yamlStr: mapsYaml},
+		{name: "buffers", yamlStr: buffersYaml},
+		{name: "primitives", yamlStr: primitivesYaml},
+		{name: "trees", yamlStr: treesYaml},
+		{name: "unions", yamlStr: unionsYaml},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := ParseStr(tt.yamlStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diags := plugin.Validate(); diags != nil {
+				t.Errorf("Validate = %v, want nil", diags)
+			}
+		})
+	}
+}
+
+var invalidYaml = `version: v1-draft
+exports:
+  - name: getUser
+    input:
+      $ref: '#/schemas/Nope'
+    output:
+      type: strng
+  - name: getUser
+  - name: get-user
+    input:
+      type: array
+      items:
+        $ref: '#/schemas/Missing'
+imports:
+  - name: log
+    input:
+      type: object
+      additionalProperties:
+        type: integer
+        format: uuid
+schemas:
+  - name: Color
+    enum: []
+  - name: User
+    required:
+      - name
+      - age
+      - name
+    properties:
+      - name: name
+        type: string
+        format: date
+      - name: name
+        type: string
+      - name: 2fa
+        type: boolean
+      - name: color
+        $ref: '#/Color'
+  - name: Fruit
+    enum:
+      - apple
+      - apple
+      - blood-orange
+  - name: User
+    properties:
+      - name: id
+        type: integer
+`
+
+func TestValidate_Invalid(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(invalidYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Diagnostics{
+		{Kind: UnresolvedRef, Path: "exports[0].input.$ref", Message: `unresolved reference "#/schemas/Nope"`, Pos: Position{Line: 5, Column: 13}},
+		{Kind: UnknownType, Path: "exports[0].output.type", Message: `unknown type "strng"`, Pos: Position{Line: 7, Column: 13}},
+		{Kind: Duplicate, Path: "exports[1].name", Message: `duplicate export "getUser"`, Pos: Position{Line: 8, Column: 11}},
+		{Kind: InvalidIdentifier, Path: "exports[2].name", Message: `invalid export name "get-user"`, Pos: Position{Line: 9, Column: 11}},
+		{Kind: UnresolvedRef, Path: "exports[2].input.items.$ref", Message: `unresolved reference "#/schemas/Missing"`, Pos: Position{Line: 13, Column: 15}},
+		{Kind: UnknownFormat, Path: "imports[0].input.additionalProperties.format", Message: `unknown format "uuid" for type "integer"`, Pos: Position{Line: 20, Column: 17}},
+		{Kind: EmptyEnum, Path: "schemas[0]", Message: `schema "Color" must have enum values or properties`, Pos: Position{Line: 22, Column: 5}},
+		{Kind: UnknownProperty, Path: "schemas[1].required[1]", Message: `required property "age" is not defined`, Pos: Position{Line: 27, Column: 9}},
+		{Kind: Duplicate, Path: "schemas[1].required[2]", Message: `duplicate required property "name"`, Pos: Position{Line: 28, Column: 9}},
+		{Kind: UnknownFormat, Path: "schemas[1].properties[0].format", Message: `unknown format "date" for type "string"`, Pos: Position{Line: 32, Column: 17}},
+		{Kind: Duplicate, Path: "schemas[1].properties[1].name", Message: `duplicate property "name"`, Pos: Position{Line: 33, Column: 15}},
+		{Kind: InvalidIdentifier, Path: "schemas[1].properties[2].name", Message: `invalid property name "2fa"`, Pos: Position{Line: 35, Column: 15}},
+		{Kind: UnresolvedRef, Path: "schemas[1].properties[3].$ref", Message: `unresolved reference "#/Color"`, Pos: Position{Line: 38, Column: 15}},
+		{Kind: Duplicate, Path: "schemas[2].enum[1]", Message: `duplicate enum value "apple"`, Pos: Position{Line: 42, Column: 9}},
+		{Kind: InvalidIdentifier, Path: "schemas[2].enum[2]", Message: `invalid enum value "blood-orange"`, Pos: Position{Line: 43, Column: 9}},
+		{Kind: Duplicate, Path: "schemas[3].name", Message: `duplicate schema "User"`, Pos: Position{Line: 44, Column: 11}},
+	}
+
+	got := plugin.Validate()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Validate mismatch (-want +got):\n%v", diff)
+	}
+}
+
+func TestValidate_NoYaml(t *testing.T) {
+	t.Parallel()
+
+	plugin := &Plugin{
+		Version: "v1-draft",
+		Exports: []*Export{{Name: "run", Input: &Input{Ref: "#/schemas/Job"}}},
+	}
+
+	want := `exports[0].input.$ref: unresolved reference "#/schemas/Job"`
+	got := plugin.Validate()
+	if len(got) != 1 || got.Error() != want {
+		t.Errorf("Validate = %q, want %q", got.Error(), want)
+	}
+}
+
+func TestDiagnosticError(t *testing.T) {
+	t.Parallel()
+
+	d := &Diagnostic{Kind: UnknownType, Path: "exports[0].output.type", Message: `unknown type "strng"`, Pos: Position{Line: 7, Column: 13}}
+	if got, want := d.Error(), `7:13: exports[0].output.type: unknown type "strng"`; got != want {
+		t.Errorf("Error = %q, want %q", got, want)
+	}
+}
+
+var cyclesYaml = `version: v1-draft
+exports:
+  - name: walk
+    input:
+      $ref: '#/schemas/Tree'
+schemas:
+  - name: Node
+    required:
+      - parent
+    properties:
+      - name: parent
+        $ref: '#/schemas/Node'
+  - name: Expr
+    required:
+      - term
+    properties:
+      - name: op
+        type: string
+      - name: term
+        $ref: '#/schemas/Term'
+  - name: Term
+    required:
+      - expr
+    properties:
+      - name: expr
+        $ref: '#/schemas/Expr'
+  - name: Tree
+    required:
+      - children
+    properties:
+      - name: parent
+        $ref: '#/schemas/Tree'
+      - name: children
+        type: array
+        items:
+          $ref: '#/schemas/Tree'
+`
+
+func TestValidate_Cycles(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(cyclesYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Diagnostics{
+		{Kind: CyclicRef, Path: "schemas[0].properties[0].$ref", Message: "required reference cycle Node.parent -> Node; make one of these properties optional", Pos: Position{Line: 12, Column: 15}},
+		{Kind: CyclicRef, Path: "schemas[1].properties[1].$ref", Message: "required reference cycle Expr.term -> Term.expr -> Expr; make one of these properties optional", Pos: Position{Line: 20, Column: 15}},
+	}
+
+	got := plugin.Validate()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Validate mismatch (-want +got):\n%v", diff)
+	}
+}
+
+var oneOfYaml = `version: v1-draft
+exports:
+  - name: handle
+    input:
+      $ref: '#/schemas/Event'
+schemas:
+  - name: Color
+    enum:
+      - red
+  - name: Click
+    properties:
+      - name: kind
+        type: string
+  - name: Key
+    properties:
+      - name: code
+        type: integer
+  - name: Event
+    oneOf:
+      - $ref: '#/schemas/Click'
+      - $ref: '#/schemas/Color'
+      - $ref: '#/schemas/Missing'
+      - type: string
+      - $ref: '#/schemas/Key'
+      - $ref: '#/schemas/Key'
+    discriminator:
+      propertyName: kind
+      mapping:
+        Click: '#/schemas/Key'
+        tap: '#/schemas/Tap'
+  - name: Untagged
+    properties:
+      - name: id
+        type: integer
+    oneOf:
+      - $ref: '#/schemas/Key'
+`
+
+func TestValidate_OneOf(t *testing.T) {
+	t.Parallel()
+
+	plugin, err := ParseStr(oneOfYaml)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Diagnostics{
+		{Kind: InvalidOneOf, Path: "schemas[3].oneOf[0].$ref", Message: `oneOf variant "Click" must not declare the discriminator property "kind"`, Pos: Position{Line: 20, Column: 15}},
+		{Kind: InvalidOneOf, Path: "schemas[3].oneOf[1].$ref", Message: `oneOf variant "Color" must be a schema with properties`, Pos: Position{Line: 21, Column: 15}},
+		{Kind: UnresolvedRef, Path: "schemas[3].oneOf[2].$ref", Message: `unresolved reference "#/schemas/Missing"`, Pos: Position{Line: 22, Column: 15}},
+		{Kind: InvalidOneOf, Path: "schemas[3].oneOf[3]", Message: "oneOf variant must be a $ref", Pos: Position{Line: 23, Column: 9}},
+		{Kind: Duplicate, Path: "schemas[3].oneOf[4].$ref", Message: `duplicate discriminator value "Click"`, Pos: Position{Line: 24, Column: 15}},
+		{Kind: Duplicate, Path: "schemas[3].oneOf[5].$ref", Message: `duplicate oneOf variant "#/schemas/Key"`, Pos: Position{Line: 25, Column: 15}},
+		{Kind: InvalidOneOf, Path: "schemas[3].discriminator.mapping.tap", Message: `discriminator value "tap" maps to "#/schemas/Tap", which is not a oneOf variant`, Pos: Position{Line: 30, Column: 14}},
+		{Kind: InvalidOneOf, Path: "schemas[4].oneOf", Message: `oneOf schema "Untagged" must not have enum values or properties`, Pos: Position{Line: 36, Column: 7}},
+		{Kind: InvalidOneOf, Path: "schemas[4].oneOf", Message: `oneOf schema "Untagged" must have a discriminator`, Pos: Position{Line: 36, Column: 7}},
+	}
+
+	got := plugin.Validate()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Validate mismatch (-want +got):\n%v", diff)
+	}
+}